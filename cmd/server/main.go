@@ -3,16 +3,19 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/grpcserver"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/handlers"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/middleware"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/routes"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/archive"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/cache"
@@ -20,10 +23,13 @@ import (
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/text"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -38,6 +44,11 @@ func main() {
 
 	logger.Info("Starting Lingualink Core server...")
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, logger)
+	if err != nil {
+		logrus.Fatalf("Failed to set up tracing: %v", err)
+	}
+
 	// 初始化组件
 	metricsCollector := metrics.NewSimpleMetricsCollector(logger)
 	authenticator := auth.NewMultiAuthenticator(cfg.Auth, logger)
@@ -57,14 +68,49 @@ func main() {
 		logrus.Fatalf("Failed to create prompt engine: %v", err)
 	}
 
+	statusStore := processing.NewInMemoryStatusStore(30 * time.Minute)
 	audioProcessor := audio.NewProcessor(asrManager, llmManager, promptEngine, cfg.Prompt, cfg.Correction, logger, metricsCollector).
-		WithPipelineConfig(cfg.Pipeline)
+		WithPipelineConfig(cfg.Pipeline).
+		WithAudioConfig(cfg.Audio).
+		WithStatusStore(statusStore)
+	if cfg.ASR.Cache.Enabled {
+		audioProcessor.WithASRCache(cache.NewInMemoryTranscriptionCache(cfg.ASR.Cache.MaxEntries), cfg.ASR.Cache.TTL)
+	}
+	var archiver *archive.Archiver
+	if cfg.Archive.Enabled {
+		sink, err := archive.NewSink(cfg.Archive)
+		if err != nil {
+			logrus.Fatalf("Failed to create archive sink: %v", err)
+		}
+		archiver = archive.NewArchiver(sink, cfg.Archive.QueueSize, cfg.Archive.PruneInterval, logger)
+		audioProcessor.WithArchiver(archiver)
+		logger.Infof("Archival enabled for opted-in requests (layout=%s, path=%s)", cfg.Archive.Layout, cfg.Archive.Path)
+	}
 	translationCache := cache.NewInMemoryCache(1000)
 	textProcessor := text.NewProcessorWithCache(llmManager, promptEngine, metricsCollector, cfg.Prompt, logger, translationCache, 5*time.Minute).
-		WithCorrectionConfig(cfg.Correction)
+		WithCorrectionConfig(cfg.Correction).
+		WithPipelineConfig(cfg.Pipeline).
+		WithStatusStore(statusStore)
+	var ttsStore *tts.Store
+	if len(cfg.TTS.Providers) > 0 {
+		ttsManager, err := tts.NewManager(cfg.TTS, logger)
+		if err != nil {
+			logrus.Fatalf("Failed to create TTS manager: %v", err)
+		}
+		ttsStore = tts.NewStore(cfg.TTS.URLTTL, cfg.TTS.MaxEntries)
+		audioProcessor.WithTTS(ttsManager, ttsStore, cfg.TTS)
+		textProcessor.WithTTS(ttsManager, ttsStore, cfg.TTS)
+		logger.Infof("TTS enabled (providers=%d, format=%s)", len(cfg.TTS.Providers), cfg.TTS.Format)
+	}
+	// 启动时对照工具注册表校验配置定义的 pipeline
+	if err := audioProcessor.ValidatePipelines(); err != nil {
+		logrus.Fatalf("Invalid pipeline configuration: %v", err)
+	}
+	if err := textProcessor.ValidatePipelines(); err != nil {
+		logrus.Fatalf("Invalid pipeline configuration: %v", err)
+	}
 	audioProcessingService := processing.NewService[audio.ProcessRequest, *audio.ProcessResponse](llmManager, promptEngine, logger)
 	textProcessingService := processing.NewService[text.ProcessRequest, *text.ProcessResponse](llmManager, promptEngine, logger)
-	statusStore := processing.NewInMemoryStatusStore(30 * time.Minute)
 
 	// 注册认证策略
 	for _, strategy := range cfg.Auth.Strategies {
@@ -84,7 +130,7 @@ func main() {
 	}
 
 	// 设置路由
-	router := setupRouter(cfg, llmManager, asrManager, authenticator, audioProcessor, textProcessor, audioProcessingService, textProcessingService, statusStore, metricsCollector, logger)
+	router := setupRouter(cfg, llmManager, asrManager, authenticator, audioProcessor, textProcessor, audioProcessingService, textProcessingService, statusStore, ttsStore, metricsCollector, logger)
 
 	// 创建HTTP服务器
 	server := &http.Server{
@@ -100,6 +146,24 @@ func main() {
 		}
 	}()
 
+	// 启动 gRPC 服务器（可选）
+	var grpcService *grpcserver.Server
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.GRPC.Port))
+		if err != nil {
+			logger.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		grpcService = grpcserver.NewServer(audioProcessor, textProcessor, audioProcessingService, textProcessingService, statusStore, authenticator, logger, metricsCollector, cfg)
+		grpcServer = grpcService.GRPCServer()
+		go func() {
+			logger.Infof("Starting gRPC server on port %d", cfg.GRPC.Port)
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// 等待中断信号
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -114,24 +178,48 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Errorf("Server forced to shutdown: %v", err)
 	}
+	if grpcServer != nil {
+		grpcService.Shutdown()
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
+	}
+	if archiver != nil {
+		if err := archiver.Close(); err != nil {
+			logger.Errorf("Failed to close archive: %v", err)
+		}
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Errorf("Failed to flush traces: %v", err)
+	}
 
 	logger.Info("Server exited")
 }
 
 // setupRouter 设置路由
-func setupRouter(cfg *config.Config, llmManager *llm.Manager, asrManager *asr.Manager, authenticator *auth.MultiAuthenticator, audioProcessor *audio.Processor, textProcessor *text.Processor, audioProcessingService *processing.Service[audio.ProcessRequest, *audio.ProcessResponse], textProcessingService *processing.Service[text.ProcessRequest, *text.ProcessResponse], statusStore processing.StatusStore, metricsCollector metrics.MetricsCollector, logger *logrus.Logger) *gin.Engine {
+func setupRouter(cfg *config.Config, llmManager *llm.Manager, asrManager *asr.Manager, authenticator *auth.MultiAuthenticator, audioProcessor *audio.Processor, textProcessor *text.Processor, audioProcessingService *processing.Service[audio.ProcessRequest, *audio.ProcessResponse], textProcessingService *processing.Service[text.ProcessRequest, *text.ProcessResponse], statusStore processing.StatusStore, ttsStore *tts.Store, metricsCollector metrics.MetricsCollector, logger *logrus.Logger) *gin.Engine {
 	// 创建Gin引擎
 	router := gin.New()
 
 	// 添加中间件
 	router.Use(middleware.CORS())
 	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing())
 	router.Use(middleware.Logging(logger))
 	router.Use(middleware.Metrics(metricsCollector))
 	router.Use(middleware.Recovery(logger))
 
 	// 创建处理器
-	handler := handlers.NewHandler(audioProcessor, textProcessor, audioProcessingService, textProcessingService, statusStore, authenticator, logger, metricsCollector, cfg, llmManager, asrManager)
+	handler := handlers.NewHandler(audioProcessor, textProcessor, audioProcessingService, textProcessingService, statusStore, authenticator, logger, metricsCollector, cfg, llmManager, asrManager).
+		WithTTSStore(ttsStore)
 
 	// 注册路由
 	routes.RegisterRoutes(router, handler, authenticator)
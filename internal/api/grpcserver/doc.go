@@ -0,0 +1,4 @@
+// Package grpcserver exposes the Lingualink API over gRPC (see proto/lingualink/v1).
+// It shares the processors, processing services, status store, authenticator and rate limits
+// with the Gin handlers; only the transport differs.
+package grpcserver
@@ -0,0 +1,228 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/middleware"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/text"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+	lingualinkv1 "github.com/Lingualink-VRChat/Lingualink_Core/pkg/pb/lingualink/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys; they mirror the HTTP headers of the REST API.
+const (
+	metadataAPIKey        = "x-api-key"
+	metadataAuthorization = "authorization"
+	metadataRequestID     = "x-request-id"
+	metadataRetryAfter    = "retry-after"
+)
+
+// publicMethods can be called without credentials, like the public REST routes.
+var publicMethods = map[string]bool{
+	lingualinkv1.Lingualink_GetCapabilities_FullMethodName: true,
+	lingualinkv1.Lingualink_ListLanguages_FullMethodName:   true,
+	healthpb.Health_Check_FullMethodName:                   true,
+	healthpb.Health_Watch_FullMethodName:                   true,
+	healthpb.Health_List_FullMethodName:                    true,
+}
+
+// Server implements the Lingualink gRPC service.
+type Server struct {
+	lingualinkv1.UnimplementedLingualinkServer
+
+	config                 *config.Config
+	audioProcessor         *audio.Processor
+	textProcessor          *text.Processor
+	audioProcessingService *processing.Service[audio.ProcessRequest, *audio.ProcessResponse]
+	textProcessingService  *processing.Service[text.ProcessRequest, *text.ProcessResponse]
+	statusStore            processing.StatusStore
+	authenticator          *auth.MultiAuthenticator
+	logger                 *logrus.Logger
+	metrics                metrics.MetricsCollector
+	health                 *health.Server
+}
+
+// NewServer 创建 gRPC 服务实现
+func NewServer(
+	audioProcessor *audio.Processor,
+	textProcessor *text.Processor,
+	audioProcessingService *processing.Service[audio.ProcessRequest, *audio.ProcessResponse],
+	textProcessingService *processing.Service[text.ProcessRequest, *text.ProcessResponse],
+	statusStore processing.StatusStore,
+	authenticator *auth.MultiAuthenticator,
+	logger *logrus.Logger,
+	metrics metrics.MetricsCollector,
+	cfg *config.Config,
+) *Server {
+	return &Server{
+		config:                 cfg,
+		audioProcessor:         audioProcessor,
+		textProcessor:          textProcessor,
+		audioProcessingService: audioProcessingService,
+		textProcessingService:  textProcessingService,
+		statusStore:            statusStore,
+		authenticator:          authenticator,
+		logger:                 logger,
+		metrics:                metrics,
+		health:                 health.NewServer(),
+	}
+}
+
+// GRPCServer builds a *grpc.Server with the authentication interceptors, the Lingualink service
+// and the standard grpc.health.v1 service registered.
+func (s *Server) GRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	if limit := s.config.GRPC.MaxMessageBytes; limit > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(limit), grpc.MaxSendMsgSize(limit))
+	}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	)
+	srv := grpc.NewServer(opts...)
+	lingualinkv1.RegisterLingualinkServer(srv, s)
+	healthpb.RegisterHealthServer(srv, s.health)
+	s.health.SetServingStatus(lingualinkv1.Lingualink_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return srv
+}
+
+// Shutdown marks all services as not serving so health checks fail during a graceful stop.
+func (s *Server) Shutdown() {
+	s.health.Shutdown()
+}
+
+type identityKey struct{}
+
+func identityFromContext(ctx context.Context) *auth.Identity {
+	identity, _ := ctx.Value(identityKey{}).(*auth.Identity)
+	return identity
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.WithFields(logrus.Fields{"error": r, "method": info.FullMethod}).Error("Panic recovered")
+			err = status.Error(codes.Internal, "internal server error")
+		}
+		s.observe(ctx, info.FullMethod, start, err)
+	}()
+
+	ctx, header, err := s.authenticate(ctx, info.FullMethod)
+	_ = grpc.SetHeader(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	ctx := ss.Context()
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.WithFields(logrus.Fields{"error": r, "method": info.FullMethod}).Error("Panic recovered")
+			err = status.Error(codes.Internal, "internal server error")
+		}
+		s.observe(ctx, info.FullMethod, start, err)
+	}()
+
+	ctx, header, err := s.authenticate(ctx, info.FullMethod)
+	_ = ss.SetHeader(header)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate attaches the request ID to ctx and, for non-public methods, authenticates the
+// caller from metadata and applies the same per-identity rate limit as the HTTP API.
+// The returned metadata is sent back as response headers.
+func (s *Server) authenticate(ctx context.Context, fullMethod string) (context.Context, metadata.MD, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := firstValue(md, metadataRequestID)
+	if requestID == "" {
+		requestID = middleware.GenerateRequestID()
+	}
+	ctx = logging.WithRequestID(ctx, requestID)
+	header := metadata.Pairs(metadataRequestID, requestID)
+
+	if publicMethods[fullMethod] {
+		return ctx, header, nil
+	}
+
+	credentials := middleware.CredentialsFromHeaders(firstValue(md, metadataAPIKey), firstValue(md, metadataAuthorization))
+	identity, err := s.authenticator.Authenticate(ctx, credentials)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			logging.FieldRequestID: requestID,
+			"method":               fullMethod,
+			"type":                 credentials.Type,
+			"error":                err.Error(),
+		}).Warn("Authentication failed")
+		return ctx, header, status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	if rejection := middleware.CheckRateLimit(identity, time.Now()); rejection != nil {
+		if rejection.RetryAfter > 0 {
+			header.Set(metadataRetryAfter, fmt.Sprintf("%.0f", rejection.RetryAfter.Seconds()))
+		}
+		return ctx, header, status.Errorf(codes.ResourceExhausted, "%s: %s", rejection.Code, rejection.Message)
+	}
+
+	return context.WithValue(ctx, identityKey{}, identity), header, nil
+}
+
+// observe 记录与 HTTP 中间件对应的访问日志与指标
+func (s *Server) observe(ctx context.Context, fullMethod string, start time.Time, err error) {
+	latency := time.Since(start)
+	code := status.Code(err)
+	tags := map[string]string{"method": fullMethod, "code": code.String()}
+	s.metrics.RecordLatency("grpc_request_duration", latency, tags)
+	s.metrics.RecordCounter("grpc_requests_total", 1, tags)
+
+	entry := s.logger.WithFields(logrus.Fields{
+		"method":  fullMethod,
+		"code":    code.String(),
+		"latency": latency,
+	})
+	if requestID, ok := logging.RequestIDFromContext(ctx); ok {
+		entry = entry.WithField(logging.FieldRequestID, requestID)
+	}
+	if code == codes.Internal || code == codes.Unknown {
+		entry.WithError(err).Error("gRPC call failed")
+	} else {
+		entry.Info("gRPC call completed")
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// wrappedStream replaces the stream context with the authenticated one.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,457 @@
+package grpcserver_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/grpcserver"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/middleware"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/text"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+	lingualinkv1 "github.com/Lingualink-VRChat/Lingualink_Core/pkg/pb/lingualink/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type testEnv struct {
+	client      lingualinkv1.LingualinkClient
+	health      healthpb.HealthClient
+	statusStore processing.StatusStore
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	return newTestEnvWithPipelines(t, config.PipelineConfig{})
+}
+
+func newTestEnvWithPipelines(t *testing.T, pipelineCfg config.PipelineConfig) *testEnv {
+	t.Helper()
+
+	middleware.ResetRateLimitStore()
+	logger := testutil.NewTestLogger()
+	metricsCollector := metrics.NewSimpleMetricsCollector(logger)
+
+	llmServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/audio/speech" {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(body["voice"].(string) + ":" + body["input"].(string)))
+			return
+		}
+		if r.URL.Path != "/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "```json\n{\"translations\":{\"en\":\"hello\"}}\n```"}},
+			},
+			"usage": map[string]interface{}{"prompt_tokens": 1, "total_tokens": 2},
+		})
+	}))
+	t.Cleanup(llmServer.Close)
+	asrServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/transcriptions" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"language": "zh", "duration": 1.0, "text": "你好"})
+	}))
+	t.Cleanup(asrServer.Close)
+
+	backendCfg := config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers:    []config.BackendProvider{{Name: "test", Type: "openai", URL: llmServer.URL, Model: "test-model"}},
+	}
+	llmManager, err := llm.NewManager(backendCfg, logger)
+	if err != nil {
+		t.Fatalf("llm.NewManager: %v", err)
+	}
+	asrManager, err := asr.NewManager(config.ASRConfig{
+		Providers: []config.ASRProvider{{Name: "asr", Type: "whisper", URL: asrServer.URL + "/v1", Model: "whisper-1"}},
+	}, logger)
+	if err != nil {
+		t.Fatalf("asr.NewManager: %v", err)
+	}
+	promptCfg := config.PromptConfig{
+		Defaults: config.PromptDefaults{Task: "translate", TargetLanguages: []string{"en"}},
+		Languages: []config.Language{
+			{Code: "zh", Names: map[string]string{"display": "中文", "english": "Chinese"}, Aliases: []string{"chinese"}},
+			{Code: "en", Names: map[string]string{"display": "英文", "english": "English"}, Aliases: []string{"english"}},
+		},
+	}
+	promptEngine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+
+	ttsCfg := config.TTSConfig{
+		Providers:    []config.TTSProvider{{Name: "tts", Type: "openai", URL: llmServer.URL, Model: "tts-1"}},
+		DefaultVoice: "alloy",
+		Format:       "mp3",
+	}
+	ttsManager, err := tts.NewManager(ttsCfg, logger)
+	if err != nil {
+		t.Fatalf("tts.NewManager: %v", err)
+	}
+	ttsStore := tts.NewStore(time.Minute, 8)
+
+	correctionCfg := config.CorrectionConfig{Enabled: false, MergeWithTranslation: true}
+	statusStore := processing.NewInMemoryStatusStore(5 * time.Minute)
+	audioProcessor := audio.NewProcessor(asrManager, llmManager, promptEngine, promptCfg, correctionCfg, logger, metricsCollector).WithStatusStore(statusStore).WithTTS(ttsManager, ttsStore, ttsCfg)
+	textProcessor := text.NewProcessor(llmManager, promptEngine, metricsCollector, promptCfg, logger).WithCorrectionConfig(correctionCfg).WithPipelineConfig(pipelineCfg).WithStatusStore(statusStore).WithTTS(ttsManager, ttsStore, ttsCfg)
+	if err := textProcessor.ValidatePipelines(); err != nil {
+		t.Fatalf("ValidatePipelines: %v", err)
+	}
+	audioService := processing.NewService[audio.ProcessRequest, *audio.ProcessResponse](llmManager, promptEngine, logger)
+	textService := processing.NewService[text.ProcessRequest, *text.ProcessResponse](llmManager, promptEngine, logger)
+
+	keysPath := filepath.Join(t.TempDir(), "api_keys.json")
+	keys := `{"keys": {
+  "user-key": {"id": "user-1", "requests_per_minute": 60, "enabled": true},
+  "limited-key": {"id": "user-limited", "requests_per_minute": 1, "enabled": true}
+}}`
+	if err := os.WriteFile(keysPath, []byte(keys), 0600); err != nil {
+		t.Fatalf("write keys file: %v", err)
+	}
+	t.Setenv("LINGUALINK_KEYS_FILE", keysPath)
+	authenticator := auth.NewMultiAuthenticator(config.AuthConfig{
+		Strategies: []config.AuthStrategy{{Type: "api_key", Enabled: true}},
+	}, logger)
+
+	cfg := &config.Config{
+		GRPC:       config.GRPCConfig{Enabled: true, Port: 9090, MaxMessageBytes: 8 << 20},
+		Correction: correctionCfg,
+		Prompt:     promptCfg,
+	}
+	server := grpcserver.NewServer(audioProcessor, textProcessor, audioService, textService, statusStore, authenticator, logger, metricsCollector, cfg)
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := server.GRPCServer()
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &testEnv{
+		client:      lingualinkv1.NewLingualinkClient(conn),
+		health:      healthpb.NewHealthClient(conn),
+		statusStore: statusStore,
+	}
+}
+
+func withAPIKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-api-key", key)
+}
+
+func pcmTone(seconds, amplitude float64) []byte {
+	n := int(seconds * 16000)
+	out := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		v := int16(amplitude * math.Sin(2*math.Pi*440*float64(i)/16000))
+		binary.LittleEndian.PutUint16(out[2*i:], uint16(v))
+	}
+	return out
+}
+
+func TestPublicMethods_NoCredentials(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	health, err := env.health.Check(ctx, &healthpb.HealthCheckRequest{Service: lingualinkv1.Lingualink_ServiceDesc.ServiceName})
+	if err != nil || health.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("health=%v err=%v", health, err)
+	}
+
+	capabilities, err := env.client.GetCapabilities(ctx, &lingualinkv1.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("GetCapabilities: %v", err)
+	}
+	if _, ok := capabilities.GetCapabilities().GetFields()["supported_formats"]; !ok {
+		t.Fatalf("capabilities missing supported_formats: %v", capabilities)
+	}
+
+	languages, err := env.client.ListLanguages(ctx, &lingualinkv1.ListLanguagesRequest{})
+	if err != nil {
+		t.Fatalf("ListLanguages: %v", err)
+	}
+	if got := languages.GetLanguages(); len(got) != 2 || got[0].GetCode() != "en" || got[0].GetNames()["english"] != "English" {
+		t.Fatalf("unexpected languages: %v", got)
+	}
+}
+
+func TestProcessText_AuthAndStatus(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req := &lingualinkv1.ProcessTextRequest{Text: "你好", Task: "translate", SourceLanguage: "zh", TargetLanguages: []string{"en"}}
+
+	if _, err := env.client.ProcessText(ctx, req); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("err=%v want Unauthenticated", err)
+	}
+	if _, err := env.client.ProcessText(withAPIKey(ctx, "wrong-key"), req); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("err=%v want Unauthenticated", err)
+	}
+
+	callCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer user-key", "x-request-id", "grpc-req-1")
+	var header metadata.MD
+	resp, err := env.client.ProcessText(callCtx, req, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("ProcessText: %v", err)
+	}
+	if resp.GetRequestId() != "grpc-req-1" || resp.GetTranslations()["en"] != "hello" || resp.GetSourceText() != "你好" {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "grpc-req-1" {
+		t.Fatalf("x-request-id header=%v", got)
+	}
+	if st, err := env.statusStore.Get("grpc-req-1"); err != nil || st.Status != "completed" {
+		t.Fatalf("status=%+v err=%v", st, err)
+	}
+
+	_, err = env.client.ProcessText(withAPIKey(ctx, "user-key"), &lingualinkv1.ProcessTextRequest{Task: "translate", TargetLanguages: []string{"en"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("empty text err=%v want InvalidArgument", err)
+	}
+}
+
+func TestProcessText_DictionaryTranslations(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The fake LLM always answers "hello", so a term that requires "Hi" is retried and reported.
+	resp, err := env.client.ProcessText(withAPIKey(ctx, "user-key"), &lingualinkv1.ProcessTextRequest{
+		Text:            "你好",
+		Task:            "translate",
+		TargetLanguages: []string{"en"},
+		UserDictionary: []*lingualinkv1.DictionaryTerm{
+			{Term: "你好", Translations: map[string]string{"en": "Hi"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ProcessText: %v", err)
+	}
+	metadata := resp.GetMetadata().AsMap()
+	if _, ok := metadata["glossary_violations"]; !ok {
+		t.Fatalf("metadata=%v want glossary_violations", metadata)
+	}
+	if retried, _ := metadata["glossary_retried_languages"].([]interface{}); len(retried) != 1 || retried[0] != "en" {
+		t.Fatalf("glossary_retried_languages=%v want [en]", metadata["glossary_retried_languages"])
+	}
+}
+
+func TestProcessText_Moderation(t *testing.T) {
+	env := newTestEnvWithPipelines(t, config.PipelineConfig{
+		Definitions: map[string]config.PipelineDefinition{
+			"safe_translate": {Type: "text", Steps: []config.PipelineStepConfig{
+				{Tool: "text_translate", InputMapping: map[string]string{
+					"text":             "request.text",
+					"target_languages": "request.target_languages",
+				}, OutputKey: "translated"},
+				{Tool: "moderate", InputMapping: map[string]string{"translations": "translated.translations"}, OutputKey: "moderated"},
+			}},
+		},
+		Tasks: config.PipelineTaskMapping{Text: map[string]string{"translate": "safe_translate"}},
+		Moderation: config.ModerationConfig{Rules: []config.ModerationRule{
+			{Name: "greetings", Words: []string{"hello"}, Action: config.ModerationMask},
+		}},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := env.client.ProcessText(withAPIKey(ctx, "user-key"), &lingualinkv1.ProcessTextRequest{
+		Text:            "你好",
+		Task:            "translate",
+		TargetLanguages: []string{"en"},
+	})
+	if err != nil {
+		t.Fatalf("ProcessText: %v", err)
+	}
+	if got := resp.GetTranslations()["en"]; got != "*****" {
+		t.Fatalf("translations=%v want masked", resp.GetTranslations())
+	}
+	moderation := resp.GetModeration()
+	if moderation == nil || !moderation.GetFlagged() || moderation.GetBlocked() || len(moderation.GetMatches()) != 1 {
+		t.Fatalf("moderation=%v", moderation)
+	}
+	if m := moderation.GetMatches()[0]; m.GetField() != "translations.en" || m.GetRule() != "greetings" || m.GetAction() != "mask" {
+		t.Fatalf("match=%v", m)
+	}
+}
+
+func TestProcessText_Speech(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	options, err := structpb.NewStruct(map[string]interface{}{"tts": true})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+	resp, err := env.client.ProcessText(withAPIKey(ctx, "user-key"), &lingualinkv1.ProcessTextRequest{
+		Text:            "你好",
+		Task:            "translate",
+		TargetLanguages: []string{"en"},
+		Options:         options,
+	})
+	if err != nil {
+		t.Fatalf("ProcessText: %v", err)
+	}
+	speech := resp.GetSpeech()
+	if speech == nil || speech.GetLanguage() != "en" || speech.GetVoice() != "alloy" || string(speech.GetAudio()) != "alloy:hello" {
+		t.Fatalf("speech=%v", speech)
+	}
+}
+
+func TestProcessText_RateLimited(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = withAPIKey(ctx, "limited-key")
+	req := &lingualinkv1.ProcessTextRequest{Text: "你好", Task: "translate", TargetLanguages: []string{"en"}}
+
+	if _, err := env.client.ProcessText(ctx, req); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	var header metadata.MD
+	_, err := env.client.ProcessText(ctx, req, grpc.Header(&header))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second call err=%v want ResourceExhausted", err)
+	}
+	if len(header.Get("retry-after")) != 1 {
+		t.Fatalf("missing retry-after header: %v", header)
+	}
+}
+
+func TestProcessAudio_PCM(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := env.client.ProcessAudio(withAPIKey(ctx, "user-key"), &lingualinkv1.ProcessAudioRequest{
+		Audio:           pcmTone(1, 8000),
+		AudioFormat:     "pcm_s16le",
+		SampleRate:      16000,
+		Task:            "translate",
+		TargetLanguages: []string{"en"},
+	})
+	if err != nil {
+		t.Fatalf("ProcessAudio: %v", err)
+	}
+	if resp.GetTranscription() != "你好" || resp.GetTranslations()["en"] != "hello" || resp.GetMetadata() == nil {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+}
+
+func TestStreamTranslate_TranslatesUtterances(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	unauth, err := env.client.StreamTranslate(ctx)
+	if err != nil {
+		t.Fatalf("StreamTranslate: %v", err)
+	}
+	if _, err := unauth.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("err=%v want Unauthenticated", err)
+	}
+
+	streamClient, err := env.client.StreamTranslate(withAPIKey(ctx, "user-key"))
+	if err != nil {
+		t.Fatalf("StreamTranslate: %v", err)
+	}
+	partials := false
+	start := &lingualinkv1.StreamStart{AudioFormat: "pcm_s16le", SampleRate: 16000, Task: "translate", TargetLanguages: []string{"en"}, PartialResults: &partials}
+	if err := streamClient.Send(&lingualinkv1.StreamTranslateRequest{Payload: &lingualinkv1.StreamTranslateRequest_Start{Start: start}}); err != nil {
+		t.Fatalf("send start: %v", err)
+	}
+	ready, err := streamClient.Recv()
+	if err != nil || ready.GetType() != lingualinkv1.StreamEvent_TYPE_READY || ready.GetSampleRate() != 16000 {
+		t.Fatalf("ready=%v err=%v", ready, err)
+	}
+
+	audioStream := append(pcmTone(1, 8000), pcmTone(1, 0)...)
+	for off := 0; off < len(audioStream); off += 3200 {
+		if err := streamClient.Send(&lingualinkv1.StreamTranslateRequest{Payload: &lingualinkv1.StreamTranslateRequest_Audio{Audio: audioStream[off : off+3200]}}); err != nil {
+			t.Fatalf("send audio: %v", err)
+		}
+	}
+	// Closing the send side finishes the session like an explicit STOP.
+	if err := streamClient.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+
+	var finals []*lingualinkv1.StreamEvent
+	for {
+		event, err := streamClient.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+		switch event.GetType() {
+		case lingualinkv1.StreamEvent_TYPE_FINAL:
+			finals = append(finals, event)
+		case lingualinkv1.StreamEvent_TYPE_ERROR:
+			t.Fatalf("unexpected error event: %v", event)
+		}
+	}
+	if len(finals) != 1 || finals[0].GetTranscription() != "你好" || finals[0].GetTranslations()["en"] != "hello" || finals[0].GetUtteranceId() != 1 {
+		t.Fatalf("unexpected finals: %v", finals)
+	}
+}
+
+func TestStreamTranslate_RejectsInvalidStart(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	streamClient, err := env.client.StreamTranslate(withAPIKey(ctx, "user-key"))
+	if err != nil {
+		t.Fatalf("StreamTranslate: %v", err)
+	}
+	if err := streamClient.Send(&lingualinkv1.StreamTranslateRequest{Payload: &lingualinkv1.StreamTranslateRequest_Start{Start: &lingualinkv1.StreamStart{AudioFormat: "pcm_s16le", Task: "translate"}}}); err != nil {
+		t.Fatalf("send start: %v", err)
+	}
+	if _, err := streamClient.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err=%v want InvalidArgument", err)
+	}
+}
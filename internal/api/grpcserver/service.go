@@ -0,0 +1,321 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/text"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+	lingualinkv1 "github.com/Lingualink-VRChat/Lingualink_Core/pkg/pb/lingualink/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ProcessText implements lingualinkv1.LingualinkServer.
+func (s *Server) ProcessText(ctx context.Context, in *lingualinkv1.ProcessTextRequest) (*lingualinkv1.ProcessTextResponse, error) {
+	req := text.ProcessRequest{
+		Text:            in.GetText(),
+		Task:            prompt.TaskType(in.GetTask()),
+		SourceLanguage:  in.GetSourceLanguage(),
+		TargetLanguages: in.GetTargetLanguages(),
+		UserDictionary:  dictionaryFromProto(in.GetUserDictionary()),
+		Options:         optionsFromProto(in.GetOptions()),
+	}
+
+	resp, err := process(ctx, s, s.textProcessingService, s.textProcessor, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Release()
+
+	out := &lingualinkv1.ProcessTextResponse{
+		RequestId:      resp.RequestID,
+		Status:         resp.Status,
+		SourceText:     resp.SourceText,
+		CorrectedText:  resp.CorrectedText,
+		Translations:   maps.Clone(resp.Translations), // Release 会清空池化的 map
+		RawResponse:    resp.RawResponse,
+		ProcessingTime: resp.ProcessingTime,
+		Moderation:     moderationToProto(resp.Moderation),
+	}
+	if out.Metadata, err = structFromMap(resp.Metadata); err != nil {
+		return nil, status.Errorf(codes.Internal, "encode metadata: %v", err)
+	}
+	if out.Speech, err = speechToProto(resp.Speech); err != nil {
+		return nil, status.Errorf(codes.Internal, "encode speech: %v", err)
+	}
+	return out, nil
+}
+
+// ProcessAudio implements lingualinkv1.LingualinkServer.
+func (s *Server) ProcessAudio(ctx context.Context, in *lingualinkv1.ProcessAudioRequest) (*lingualinkv1.ProcessAudioResponse, error) {
+	limits := s.audioLimits(ctx)
+	if len(in.GetAudio()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty audio")
+	}
+	if len(in.GetAudio()) > limits.MaxSizeBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "audio size exceeds maximum allowed size (%d bytes)", limits.MaxSizeBytes)
+	}
+
+	req := audio.ProcessRequest{
+		Audio:           in.GetAudio(),
+		AudioFormat:     strings.ToLower(strings.TrimSpace(in.GetAudioFormat())),
+		SampleRate:      int(in.GetSampleRate()),
+		Channels:        int(in.GetChannels()),
+		Task:            prompt.TaskType(in.GetTask()),
+		SourceLanguage:  in.GetSourceLanguage(),
+		TargetLanguages: in.GetTargetLanguages(),
+		UserDictionary:  dictionaryFromProto(in.GetUserDictionary()),
+		Options:         optionsFromProto(in.GetOptions()),
+	}
+	if req.AudioFormat == "" {
+		req.AudioFormat = audio.FormatAuto
+	}
+	s.annotateAudioRequest(ctx, &req, limits)
+
+	resp, err := process(ctx, s, s.audioProcessingService, s.audioProcessor, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Release()
+	metrics.ObserveAudioProcessingDuration(time.Duration(resp.ProcessingTime * float64(time.Second)))
+
+	out := &lingualinkv1.ProcessAudioResponse{
+		RequestId:      resp.RequestID,
+		Status:         resp.Status,
+		Transcription:  resp.Transcription,
+		CorrectedText:  resp.CorrectedText,
+		Translations:   maps.Clone(resp.Translations), // Release 会清空池化的 map
+		RawResponse:    resp.RawResponse,
+		ProcessingTime: resp.ProcessingTime,
+		Moderation:     moderationToProto(resp.Moderation),
+	}
+	if out.Metadata, err = structFromMap(resp.Metadata); err != nil {
+		return nil, status.Errorf(codes.Internal, "encode metadata: %v", err)
+	}
+	if out.Speech, err = speechToProto(resp.Speech); err != nil {
+		return nil, status.Errorf(codes.Internal, "encode speech: %v", err)
+	}
+	return out, nil
+}
+
+// GetCapabilities implements lingualinkv1.LingualinkServer.
+func (s *Server) GetCapabilities(ctx context.Context, _ *lingualinkv1.GetCapabilitiesRequest) (*lingualinkv1.GetCapabilitiesResponse, error) {
+	caps := s.audioProcessor.GetCapabilities()
+	if s.textProcessor != nil {
+		caps["text_pipelines"] = s.textProcessor.PipelineNames()
+	}
+	capabilities, err := structFromMap(caps)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encode capabilities: %v", err)
+	}
+	return &lingualinkv1.GetCapabilitiesResponse{Capabilities: capabilities}, nil
+}
+
+// ListLanguages implements lingualinkv1.LingualinkServer.
+func (s *Server) ListLanguages(ctx context.Context, _ *lingualinkv1.ListLanguagesRequest) (*lingualinkv1.ListLanguagesResponse, error) {
+	languages := s.audioProcessor.GetSupportedLanguages()
+	out := make([]*lingualinkv1.Language, 0, len(languages))
+	for _, info := range languages {
+		lang := &lingualinkv1.Language{Names: make(map[string]string)}
+		for key, value := range info {
+			switch key {
+			case "code":
+				lang.Code, _ = value.(string)
+			case "type":
+				lang.Type, _ = value.(string)
+			case "aliases":
+				lang.Aliases, _ = value.([]string)
+			case "style_note":
+				lang.StyleNote, _ = value.(string)
+			default:
+				// 其余字段为各语言的显示名称
+				if name, ok := value.(string); ok {
+					lang.Names[key] = name
+				}
+			}
+		}
+		out = append(out, lang)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return &lingualinkv1.ListLanguagesResponse{Languages: out}, nil
+}
+
+// process mirrors the REST handleProcessingRequest: it tracks the request in the status store,
+// runs the processing service and converts failures to gRPC status errors.
+func process[Req processing.ProcessableRequest, Resp interface{ SetRequestID(string) }](
+	ctx context.Context,
+	s *Server,
+	service *processing.Service[Req, Resp],
+	logicHandler processing.Handler[Req, Resp],
+	req Req,
+) (Resp, error) {
+	var zero Resp
+	requestID, _ := logging.RequestIDFromContext(ctx)
+	s.setStatus(requestID, "processing", 0, "Processing started")
+
+	identity := identityFromContext(ctx)
+	if identity == nil {
+		s.setStatus(requestID, "failed", 0, "authentication required")
+		return zero, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	resp, err := service.Process(ctx, req, logicHandler)
+	if err != nil {
+		s.logger.WithError(err).WithField(logging.FieldRequestID, requestID).Error("Processing failed")
+		s.setStatus(requestID, "failed", 100, err.Error())
+		return zero, statusFromError(err)
+	}
+	if requestID != "" {
+		resp.SetRequestID(requestID)
+	}
+
+	s.metrics.RecordCounter("api.process.success", 1, map[string]string{"user_id": identity.ID})
+	s.setStatus(requestID, "completed", 100, "Processing completed")
+	return resp, nil
+}
+
+func (s *Server) setStatus(requestID, state string, progress int, message string) {
+	if s.statusStore != nil && requestID != "" {
+		_ = s.statusStore.Set(requestID, &processing.ProcessingStatus{
+			Status:   state,
+			Progress: progress,
+			Message:  message,
+		})
+	}
+}
+
+// audioLimits returns the processor limits with the caller's identity overrides.
+func (s *Server) audioLimits(ctx context.Context) audio.Limits {
+	limits := s.audioProcessor.Limits()
+	if identity := identityFromContext(ctx); identity != nil {
+		limits = limits.WithOverrides(identity.Metadata)
+	}
+	return limits
+}
+
+// annotateAudioRequest attaches the caller's limits and archival opt-in to req.
+func (s *Server) annotateAudioRequest(ctx context.Context, req *audio.ProcessRequest, limits audio.Limits) {
+	req.Limits = &limits
+	if identity := identityFromContext(ctx); identity != nil {
+		req.CallerID = identity.ID
+		req.ArchiveOptIn = audio.ArchiveOptedIn(identity.Metadata)
+	}
+}
+
+// statusFromError maps AppError codes to gRPC codes, matching the REST status mapping.
+func statusFromError(err error) error {
+	var appErr *coreerrors.AppError
+	if !errors.As(err, &appErr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	switch appErr.Code {
+	case coreerrors.ErrCodeValidation:
+		return status.Error(codes.InvalidArgument, appErr.Message)
+	case coreerrors.ErrCodeAuth:
+		return status.Error(codes.Unauthenticated, appErr.Message)
+	case coreerrors.ErrCodeLLM, coreerrors.ErrCodeParsing:
+		return status.Error(codes.Unavailable, appErr.Message)
+	default:
+		return status.Error(codes.Internal, appErr.Message)
+	}
+}
+
+func dictionaryFromProto(terms []*lingualinkv1.DictionaryTerm) []config.DictionaryTerm {
+	if len(terms) == 0 {
+		return nil
+	}
+	out := make([]config.DictionaryTerm, 0, len(terms))
+	for _, term := range terms {
+		out = append(out, config.DictionaryTerm{Term: term.GetTerm(), Aliases: term.GetAliases(), Translations: term.GetTranslations()})
+	}
+	return out
+}
+
+func moderationToProto(m *tool.ModerationResult) *lingualinkv1.Moderation {
+	if m == nil {
+		return nil
+	}
+	out := &lingualinkv1.Moderation{Flagged: m.Flagged, Blocked: m.Blocked}
+	for _, match := range m.Matches {
+		out.Matches = append(out.Matches, &lingualinkv1.ModerationMatch{
+			Field:    match.Field,
+			Language: match.Language,
+			Rule:     match.Rule,
+			Action:   match.Action,
+			Match:    match.Match,
+			Category: match.Category,
+		})
+	}
+	return out
+}
+
+// speechToProto carries base64-delivered audio as raw bytes, like ProcessAudioRequest.audio.
+func speechToProto(sp *tool.SpeechResult) (*lingualinkv1.Speech, error) {
+	if sp == nil {
+		return nil, nil
+	}
+	out := &lingualinkv1.Speech{
+		Language:    sp.Language,
+		Voice:       sp.Voice,
+		Format:      sp.Format,
+		ContentType: sp.ContentType,
+		Url:         sp.URL,
+	}
+	if sp.Base64 != "" {
+		audio, err := base64.StdEncoding.DecodeString(sp.Base64)
+		if err != nil {
+			return nil, err
+		}
+		out.Audio = audio
+	}
+	if sp.ExpiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*sp.ExpiresAt)
+	}
+	return out, nil
+}
+
+// optionsFromProto returns nil for absent options, like an omitted JSON field.
+func optionsFromProto(options *structpb.Struct) map[string]interface{} {
+	if options == nil {
+		return nil
+	}
+	return options.AsMap()
+}
+
+// structFromMap converts response metadata to a Struct. It goes through JSON because the metadata
+// holds typed slices and maps (e.g. []string) that structpb.NewStruct rejects.
+func structFromMap(m map[string]interface{}) (*structpb.Struct, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var generic map[string]interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	out, err := structpb.NewStruct(generic)
+	if err != nil {
+		return nil, fmt.Errorf("convert to struct: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,216 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/stream"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	lingualinkv1 "github.com/Lingualink-VRChat/Lingualink_Core/pkg/pb/lingualink/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamTranslate implements lingualinkv1.LingualinkServer. It follows the /ws/stream protocol:
+// a start message, then audio chunks; FLUSH ends the current utterance, STOP (or closing the send
+// side) finishes pending utterances and ends the call.
+func (s *Server) StreamTranslate(srv lingualinkv1.Lingualink_StreamTranslateServer) error {
+	ctx := srv.Context()
+	identity := identityFromContext(ctx)
+	if identity == nil {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	cfg := stream.WithDefaults(s.config.Stream)
+	sessionID, ok := logging.RequestIDFromContext(ctx)
+	if !ok || sessionID == "" {
+		sessionID = fmt.Sprintf("grpc_%d", time.Now().UnixNano())
+	}
+	logger := s.logger.WithFields(logrus.Fields{
+		logging.FieldRequestID: sessionID,
+		logging.FieldUserID:    identity.ID,
+	})
+
+	// Recv 不支持超时，由单独的 goroutine 读取以便按 idle_timeout 结束会话
+	messages := make(chan *lingualinkv1.StreamTranslateRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := srv.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	idle := time.NewTimer(cfg.IdleTimeout)
+	defer idle.Stop()
+	next := func() (*lingualinkv1.StreamTranslateRequest, error) {
+		idle.Reset(cfg.IdleTimeout)
+		select {
+		case msg := <-messages:
+			return msg, nil
+		case err := <-recvErr:
+			return nil, err
+		case <-idle.C:
+			return nil, status.Error(codes.DeadlineExceeded, "stream idle timeout")
+		}
+	}
+
+	first, err := next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "the first message must be a start message")
+		}
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return status.Error(codes.InvalidArgument, "the first message must be a start message")
+	}
+	settings, err := s.streamSettings(ctx, start, sessionID)
+	if err != nil {
+		return statusFromError(coreerrors.NewValidationError(err.Error(), err))
+	}
+
+	var sendMu sync.Mutex
+	send := func(e stream.Event) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return srv.Send(eventToProto(e))
+	}
+
+	session, err := stream.NewSession(ctx, settings, cfg, stream.NewProcessorBackend(s.audioProcessor, s.audioProcessingService), send, s.logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start stream session")
+		return status.Errorf(codes.Internal, "session start failed: %v", err)
+	}
+	// 客户端断开或超时：放弃尚未完成的语句
+	abort := func() {
+		session.Cancel()
+		_ = session.Close()
+	}
+	finish := func() error {
+		if err := session.Close(); err != nil {
+			logger.WithError(err).Warn("Stream session closed with error")
+		}
+		logger.Info("Stream session finished")
+		return nil
+	}
+
+	s.metrics.RecordCounter("api.stream.sessions", 1, map[string]string{"user_id": identity.ID})
+	logger.WithField(logging.FieldAudioFormat, settings.AudioFormat).Info("Stream session started")
+	if err := send(stream.Event{Type: stream.EventReady, SessionID: sessionID, SampleRate: session.SampleRate()}); err != nil {
+		abort()
+		return err
+	}
+
+	for {
+		msg, err := next()
+		if errors.Is(err, io.EOF) {
+			// 客户端关闭发送方向等同于 STOP
+			return finish()
+		}
+		if err != nil {
+			logger.WithError(err).Debug("Stream connection closed")
+			abort()
+			return err
+		}
+
+		switch payload := msg.GetPayload().(type) {
+		case *lingualinkv1.StreamTranslateRequest_Audio:
+			if len(payload.Audio) > cfg.MaxFrameBytes {
+				abort()
+				return status.Errorf(codes.InvalidArgument, "audio chunk exceeds %d bytes", cfg.MaxFrameBytes)
+			}
+			if err := session.WriteAudio(payload.Audio); err != nil {
+				logger.WithError(err).Warn("Stream audio decoding failed")
+				abort()
+				return status.Errorf(codes.InvalidArgument, "audio decoding failed: %v", err)
+			}
+		case *lingualinkv1.StreamTranslateRequest_Control:
+			switch payload.Control.GetAction() {
+			case lingualinkv1.StreamControl_ACTION_FLUSH:
+				session.Flush()
+			case lingualinkv1.StreamControl_ACTION_STOP:
+				return finish()
+			default:
+				_ = send(stream.Event{Type: stream.EventError, SessionID: sessionID, Code: string(coreerrors.ErrCodeValidation), Error: fmt.Sprintf("unknown control action: %s", payload.Control.GetAction())})
+			}
+		default:
+			_ = send(stream.Event{Type: stream.EventError, SessionID: sessionID, Code: string(coreerrors.ErrCodeValidation), Error: "unexpected message: only audio and control may follow start"})
+		}
+	}
+}
+
+// streamSettings validates the start message like the WebSocket handler does.
+func (s *Server) streamSettings(ctx context.Context, start *lingualinkv1.StreamStart, sessionID string) (stream.Settings, error) {
+	template := audio.ProcessRequest{
+		Task:            prompt.TaskType(start.GetTask()),
+		SourceLanguage:  start.GetSourceLanguage(),
+		TargetLanguages: start.GetTargetLanguages(),
+		UserDictionary:  dictionaryFromProto(start.GetUserDictionary()),
+		Options:         optionsFromProto(start.GetOptions()),
+	}
+	limits := s.audioLimits(ctx)
+	s.annotateAudioRequest(ctx, &template, limits)
+
+	return stream.PrepareSettings(s.audioProcessor, stream.Settings{
+		ID:          sessionID,
+		AudioFormat: strings.TrimSpace(start.GetAudioFormat()),
+		SampleRate:  int(start.GetSampleRate()),
+		Channels:    int(start.GetChannels()),
+		Request:     template,
+		Partials:    start.PartialResults == nil || start.GetPartialResults(),
+	}, limits)
+}
+
+// eventToProto converts a session event to its protobuf form.
+func eventToProto(e stream.Event) *lingualinkv1.StreamEvent {
+	out := &lingualinkv1.StreamEvent{
+		SessionId:      e.SessionID,
+		UtteranceId:    int32(e.UtteranceID),
+		Start:          e.Start,
+		End:            e.End,
+		Transcription:  e.Transcription,
+		CorrectedText:  e.CorrectedText,
+		Translations:   e.Translations,
+		ProcessingTime: e.ProcessingTime,
+		Forced:         e.Forced,
+		SampleRate:     int32(e.SampleRate),
+		Moderation:     moderationToProto(e.Moderation),
+		Code:           e.Code,
+		Error:          e.Error,
+	}
+	switch e.Type {
+	case stream.EventReady:
+		out.Type = lingualinkv1.StreamEvent_TYPE_READY
+	case stream.EventPartial:
+		out.Type = lingualinkv1.StreamEvent_TYPE_PARTIAL
+	case stream.EventFinal:
+		out.Type = lingualinkv1.StreamEvent_TYPE_FINAL
+	case stream.EventError:
+		out.Type = lingualinkv1.StreamEvent_TYPE_ERROR
+	}
+	if metadata, err := structFromMap(e.Metadata); err == nil {
+		out.Metadata = metadata
+	}
+	if speech, err := speechToProto(e.Speech); err == nil {
+		out.Speech = speech
+	}
+	return out
+}
@@ -12,6 +12,9 @@ import (
 // GetCapabilities 获取能力API
 func (h *Handler) GetCapabilities(c *gin.Context) {
 	capabilities := h.audioProcessor.GetCapabilities()
+	if h.textProcessor != nil {
+		capabilities["text_pipelines"] = h.textProcessor.PipelineNames()
+	}
 	c.JSON(http.StatusOK, capabilities)
 }
 
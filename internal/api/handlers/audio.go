@@ -3,14 +3,19 @@ package handlers
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net/textproto"
+	"strconv"
 	"strings"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,6 +27,8 @@ func (h *Handler) ProcessAudioJSON(c *gin.Context) {
 		var req struct {
 			Audio           string                  `json:"audio"` // base64编码的音频数据
 			AudioFormat     string                  `json:"audio_format"`
+			SampleRate      int                     `json:"sample_rate,omitempty"`
+			Channels        int                     `json:"channels,omitempty"`
 			Task            prompt.TaskType         `json:"task"`
 			SourceLanguage  string                  `json:"source_language,omitempty"`
 			TargetLanguages []string                `json:"target_languages"` // 期望短代码
@@ -31,9 +38,10 @@ func (h *Handler) ProcessAudioJSON(c *gin.Context) {
 		if err := c.ShouldBindJSON(&req); err != nil {
 			return audio.ProcessRequest{}, fmt.Errorf("invalid JSON: %w", err)
 		}
+		limits := h.audioLimits(c)
 		decodedLen := base64.StdEncoding.DecodedLen(len(req.Audio))
-		if decodedLen > 32*1024*1024 {
-			return audio.ProcessRequest{}, fmt.Errorf("audio size exceeds maximum allowed size")
+		if decodedLen > limits.MaxSizeBytes {
+			return audio.ProcessRequest{}, fmt.Errorf("audio size exceeds maximum allowed size (%d bytes)", limits.MaxSizeBytes)
 		}
 		buf := audio.AcquireAudioBuffer(decodedLen)
 		base64Decoder := base64.NewDecoder(base64.StdEncoding, strings.NewReader(req.Audio))
@@ -53,15 +61,262 @@ func (h *Handler) ProcessAudioJSON(c *gin.Context) {
 		audioReq := audio.ProcessRequest{
 			Audio:           buf[:n],
 			AudioFormat:     req.AudioFormat,
+			SampleRate:      req.SampleRate,
+			Channels:        req.Channels,
 			Task:            req.Task,
 			SourceLanguage:  req.SourceLanguage,
 			TargetLanguages: req.TargetLanguages,
 			UserDictionary:  req.UserDictionary,
 			Options:         req.Options,
 		}
+		h.annotateAudioRequest(c, &audioReq, limits)
 		audioReq.SetCleanup(func() { audio.ReleaseAudioBuffer(buf) })
 		return audioReq, nil
 	}
 
 	handleProcessingRequest(c, h, h.audioProcessingService, h.audioProcessor, decoder)
 }
+
+// maxAudioMetadataBytes bounds the total size of non-file multipart fields.
+const maxAudioMetadataBytes = 1 << 20
+
+// ProcessAudio dispatches /process_audio by Content-Type:
+// multipart/form-data and application/octet-stream (or audio/*) uploads stream the audio
+// straight into pooled buffers; everything else is handled as base64 JSON.
+func (h *Handler) ProcessAudio(c *gin.Context) {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	switch {
+	case mediaType == "multipart/form-data":
+		h.ProcessAudioMultipart(c)
+	case mediaType == "application/octet-stream" || strings.HasPrefix(mediaType, "audio/"):
+		h.ProcessAudioRaw(c)
+	default:
+		h.ProcessAudioJSON(c)
+	}
+}
+
+// ProcessAudioMultipart 处理 multipart/form-data 上传的音频请求
+// The audio is read from the "audio" (or "file") part; other parts carry the request metadata.
+func (h *Handler) ProcessAudioMultipart(c *gin.Context) {
+	h.logger.Info("Processing multipart audio request")
+
+	decoder := func(c *gin.Context) (audio.ProcessRequest, error) {
+		limits := h.audioLimits(c)
+		reader, err := c.Request.MultipartReader()
+		if err != nil {
+			return audio.ProcessRequest{}, fmt.Errorf("invalid multipart body: %w", err)
+		}
+
+		var buf []byte
+		fields := make(map[string][]string)
+		metadataBytes := 0
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				audio.ReleaseAudioBuffer(buf)
+				return audio.ProcessRequest{}, fmt.Errorf("invalid multipart body: %w", err)
+			}
+
+			name := part.FormName()
+			if name == "audio" || name == "file" {
+				if buf != nil {
+					audio.ReleaseAudioBuffer(buf)
+					return audio.ProcessRequest{}, fmt.Errorf("multiple audio parts in request")
+				}
+				buf, err = audio.ReadAudioBuffer(part, -1, limits.MaxSizeBytes)
+				if err != nil {
+					return audio.ProcessRequest{}, fmt.Errorf("read audio part: %w", err)
+				}
+				if _, ok := fields["audio_format"]; !ok {
+					if format := audioFormatFromMediaType(part.Header.Get("Content-Type")); format != "" {
+						fields["audio_format"] = []string{format}
+					}
+				}
+				continue
+			}
+
+			value, err := io.ReadAll(io.LimitReader(part, int64(maxAudioMetadataBytes-metadataBytes)+1))
+			if err == nil && metadataBytes+len(value) > maxAudioMetadataBytes {
+				err = fmt.Errorf("metadata fields exceed %d bytes", maxAudioMetadataBytes)
+			}
+			if err != nil {
+				audio.ReleaseAudioBuffer(buf)
+				return audio.ProcessRequest{}, fmt.Errorf("read field %q: %w", name, err)
+			}
+			metadataBytes += len(value)
+			fields[name] = append(fields[name], string(value))
+		}
+
+		if len(buf) == 0 {
+			audio.ReleaseAudioBuffer(buf)
+			return audio.ProcessRequest{}, fmt.Errorf("missing audio part")
+		}
+
+		audioReq, err := parseAudioMetadata(func(key string) []string { return fields[key] })
+		if err != nil {
+			audio.ReleaseAudioBuffer(buf)
+			return audio.ProcessRequest{}, err
+		}
+		audioReq.Audio = buf
+		h.annotateAudioRequest(c, &audioReq, limits)
+		audioReq.SetCleanup(func() { audio.ReleaseAudioBuffer(buf) })
+		return audioReq, nil
+	}
+
+	handleProcessingRequest(c, h, h.audioProcessingService, h.audioProcessor, decoder)
+}
+
+// ProcessAudioRaw 处理原始二进制音频上传
+// The body is the audio itself; metadata comes from query parameters or X-* headers
+// (e.g. ?task=translate&target_languages=en,ja or X-Audio-Format: opus).
+func (h *Handler) ProcessAudioRaw(c *gin.Context) {
+	h.logger.Info("Processing raw audio request")
+
+	decoder := func(c *gin.Context) (audio.ProcessRequest, error) {
+		lookup := func(key string) []string {
+			if values := c.QueryArray(key); len(values) > 0 {
+				return values
+			}
+			return c.Request.Header.Values(metadataHeaderName(key))
+		}
+
+		audioReq, err := parseAudioMetadata(lookup)
+		if err != nil {
+			return audio.ProcessRequest{}, err
+		}
+		if audioReq.AudioFormat == "" {
+			audioReq.AudioFormat = audioFormatFromMediaType(c.GetHeader("Content-Type"))
+		}
+
+		limits := h.audioLimits(c)
+		buf, err := audio.ReadAudioBuffer(c.Request.Body, c.Request.ContentLength, limits.MaxSizeBytes)
+		if err != nil {
+			return audio.ProcessRequest{}, fmt.Errorf("read audio body: %w", err)
+		}
+		if len(buf) == 0 {
+			audio.ReleaseAudioBuffer(buf)
+			return audio.ProcessRequest{}, fmt.Errorf("empty audio body")
+		}
+
+		audioReq.Audio = buf
+		h.annotateAudioRequest(c, &audioReq, limits)
+		audioReq.SetCleanup(func() { audio.ReleaseAudioBuffer(buf) })
+		return audioReq, nil
+	}
+
+	handleProcessingRequest(c, h, h.audioProcessingService, h.audioProcessor, decoder)
+}
+
+// audioLimits returns the audio limits for the authenticated caller:
+// the audio config section with overrides from the identity metadata.
+func (h *Handler) audioLimits(c *gin.Context) audio.Limits {
+	limits := audio.LimitsFromConfig(config.AudioConfig{})
+	if h.audioProcessor != nil {
+		limits = h.audioProcessor.Limits()
+	}
+	if identity, ok := c.Get("identity"); ok {
+		if userIdentity, ok := identity.(*auth.Identity); ok && userIdentity != nil {
+			limits = limits.WithOverrides(userIdentity.Metadata)
+		}
+	}
+	return limits
+}
+
+// annotateAudioRequest attaches the caller's limits and archival opt-in to req.
+func (h *Handler) annotateAudioRequest(c *gin.Context, req *audio.ProcessRequest, limits audio.Limits) {
+	req.Limits = &limits
+	if identity, ok := c.Get("identity"); ok {
+		if userIdentity, ok := identity.(*auth.Identity); ok && userIdentity != nil {
+			req.CallerID = userIdentity.ID
+			req.ArchiveOptIn = audio.ArchiveOptedIn(userIdentity.Metadata)
+		}
+	}
+}
+
+// parseAudioMetadata builds a ProcessRequest (without audio) from form/query/header values.
+// sample_rate and channels describe raw PCM input; target_languages accepts repeated values, a comma-separated list or a JSON array;
+// user_dictionary and options are JSON encoded.
+func parseAudioMetadata(lookup func(key string) []string) (audio.ProcessRequest, error) {
+	first := func(key string) string {
+		if values := lookup(key); len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+
+	req := audio.ProcessRequest{
+		AudioFormat:    strings.ToLower(first("audio_format")),
+		Task:           prompt.TaskType(first("task")),
+		SourceLanguage: first("source_language"),
+	}
+
+	for _, value := range lookup("target_languages") {
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "[") {
+			var langs []string
+			if err := json.Unmarshal([]byte(value), &langs); err != nil {
+				return audio.ProcessRequest{}, fmt.Errorf("invalid target_languages: %w", err)
+			}
+			req.TargetLanguages = append(req.TargetLanguages, langs...)
+			continue
+		}
+		for _, lang := range strings.Split(value, ",") {
+			if lang = strings.TrimSpace(lang); lang != "" {
+				req.TargetLanguages = append(req.TargetLanguages, lang)
+			}
+		}
+	}
+
+	for key, dst := range map[string]*int{"sample_rate": &req.SampleRate, "channels": &req.Channels} {
+		if raw := first(key); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return audio.ProcessRequest{}, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			*dst = v
+		}
+	}
+
+	if raw := first("user_dictionary"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.UserDictionary); err != nil {
+			return audio.ProcessRequest{}, fmt.Errorf("invalid user_dictionary: %w", err)
+		}
+	}
+	if raw := first("options"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.Options); err != nil {
+			return audio.ProcessRequest{}, fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	return req, nil
+}
+
+// metadataHeaderName maps a metadata key such as "audio_format" to its header form "X-Audio-Format".
+func metadataHeaderName(key string) string {
+	return textproto.CanonicalMIMEHeaderKey("X-" + strings.ReplaceAll(key, "_", "-"))
+}
+
+// audioFormatFromMediaType derives an audio_format from an audio/* Content-Type.
+func audioFormatFromMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "audio/") {
+		return ""
+	}
+	switch subtype := strings.TrimPrefix(mediaType, "audio/"); subtype {
+	case "wav", "wave", "x-wav", "vnd.wave":
+		return "wav"
+	case "mpeg", "mp3":
+		return "mp3"
+	case "ogg", "opus":
+		return "opus"
+	case "mp4", "m4a", "x-m4a":
+		return "m4a"
+	case "flac", "x-flac":
+		return "flac"
+	default:
+		return subtype
+	}
+}
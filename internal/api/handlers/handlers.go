@@ -9,6 +9,7 @@ import (
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/text"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
 	"github.com/sirupsen/logrus"
@@ -26,6 +27,7 @@ type Handler struct {
 	audioProcessingService *processing.Service[audio.ProcessRequest, *audio.ProcessResponse]
 	textProcessingService  *processing.Service[text.ProcessRequest, *text.ProcessResponse]
 	statusStore            processing.StatusStore
+	ttsStore               *tts.Store
 	authenticator          *auth.MultiAuthenticator
 	logger                 *logrus.Logger
 	metrics                metrics.MetricsCollector
@@ -3,12 +3,16 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"math"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,6 +30,7 @@ import (
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 )
 
 func newLLMServer(t *testing.T, content string) *httptest.Server {
@@ -71,6 +76,12 @@ func writeTestKeysFile(t *testing.T, path string) {
       "id": "backend-service",
       "requests_per_minute": 60,
       "enabled": true
+    },
+    "small-audio-key": {
+      "id": "user-small-audio",
+      "requests_per_minute": 60,
+      "enabled": true,
+      "metadata": {"audio_max_size_bytes": 16000}
     }
   }
 }`
@@ -116,14 +127,20 @@ func minimalWAV() []byte {
 func newTestRouter(t *testing.T) *gin.Engine {
 	t.Helper()
 
+	llmContent := "```json\n{\"translations\":{\"en\":\"hello\"}}\n```"
+	return newTestRouterWithLLM(t, newLLMServer(t, llmContent))
+}
+
+// newTestRouterWithLLM builds the test router against llmServer, which it closes on cleanup.
+func newTestRouterWithLLM(t *testing.T, llmServer *httptest.Server) *gin.Engine {
+	t.Helper()
+
 	gin.SetMode(gin.TestMode)
 	middleware.ResetRateLimitStore()
 
 	logger := testutil.NewTestLogger()
 	metricsCollector := metrics.NewSimpleMetricsCollector(logger)
 
-	llmContent := "```json\n{\"translations\":{\"en\":\"hello\"}}\n```"
-	llmServer := newLLMServer(t, llmContent)
 	t.Cleanup(llmServer.Close)
 
 	asrServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -173,11 +190,11 @@ func newTestRouter(t *testing.T) *gin.Engine {
 	}
 
 	correctionCfg := config.CorrectionConfig{Enabled: false, MergeWithTranslation: true}
-	audioProcessor := audio.NewProcessor(asrManager, llmManager, promptEngine, promptCfg, correctionCfg, logger, metricsCollector)
-	textProcessor := text.NewProcessor(llmManager, promptEngine, metricsCollector, promptCfg, logger).WithCorrectionConfig(correctionCfg)
+	statusStore := processing.NewInMemoryStatusStore(5 * time.Minute)
+	audioProcessor := audio.NewProcessor(asrManager, llmManager, promptEngine, promptCfg, correctionCfg, logger, metricsCollector).WithStatusStore(statusStore)
+	textProcessor := text.NewProcessor(llmManager, promptEngine, metricsCollector, promptCfg, logger).WithCorrectionConfig(correctionCfg).WithStatusStore(statusStore)
 	audioProcessingService := processing.NewService[audio.ProcessRequest, *audio.ProcessResponse](llmManager, promptEngine, logger)
 	textProcessingService := processing.NewService[text.ProcessRequest, *text.ProcessResponse](llmManager, promptEngine, logger)
-	statusStore := processing.NewInMemoryStatusStore(5 * time.Minute)
 
 	keysPath := filepath.Join(t.TempDir(), "api_keys.json")
 	writeTestKeysFile(t, keysPath)
@@ -270,6 +287,16 @@ func TestLivenessCheck(t *testing.T) {
 	}
 }
 
+func TestGetSpeech_NotFound(t *testing.T) {
+	router := newTestRouter(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tts/0123456789abcdef", nil)
+	resp := doRequest(t, router, req)
+
+	if resp.Code != http.StatusNotFound {
+		t.Fatalf("status=%d want 404", resp.Code)
+	}
+}
+
 func TestReadinessCheck(t *testing.T) {
 	router := newTestRouter(t)
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/ready", nil)
@@ -486,6 +513,242 @@ func TestProcessAudioJSON_InvalidBase64(t *testing.T) {
 	}
 }
 
+func TestProcessAudio_MultipartUpload(t *testing.T) {
+	router := newTestRouter(t)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("task", "translate")
+	_ = writer.WriteField("target_languages", "en")
+	part, err := writer.CreateFormFile("audio", "clip.wav")
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	_, _ = part.Write(minimalWAV())
+	_ = writer.WriteField("audio_format", "wav")
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/process_audio", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-API-Key", "user-key")
+
+	resp := doRequest(t, router, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("status=%d want 200, body=%s", resp.Code, resp.Body.String())
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["transcription"] != "你好" {
+		t.Fatalf("transcription=%v want 你好", out["transcription"])
+	}
+	translations, _ := out["translations"].(map[string]interface{})
+	if translations["en"] != "hello" {
+		t.Fatalf("translations=%v want en=hello", out["translations"])
+	}
+}
+
+func TestProcessAudio_MultipartMissingAudio(t *testing.T) {
+	router := newTestRouter(t)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("task", "transcribe")
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/process_audio", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-API-Key", "user-key")
+
+	resp := doRequest(t, router, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want 400, body=%s", resp.Code, resp.Body.String())
+	}
+}
+
+func TestProcessAudio_RawUpload(t *testing.T) {
+	router := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/process_audio?task=transcribe", bytes.NewReader(minimalWAV()))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Audio-Format", "wav")
+	req.Header.Set("X-API-Key", "user-key")
+
+	resp := doRequest(t, router, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("status=%d want 200, body=%s", resp.Code, resp.Body.String())
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["transcription"] != "你好" {
+		t.Fatalf("transcription=%v want 你好", out["transcription"])
+	}
+}
+
+func TestProcessAudio_RawPCMUpload(t *testing.T) {
+	router := newTestRouter(t)
+
+	pcm := make([]byte, 32000) // 0.5s of 16kHz stereo s16le
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/process_audio?task=transcribe&audio_format=pcm_s16le&sample_rate=16000&channels=2", bytes.NewReader(pcm))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-API-Key", "user-key")
+
+	resp := doRequest(t, router, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("status=%d want 200, body=%s", resp.Code, resp.Body.String())
+	}
+
+	var out struct {
+		Metadata map[string]interface{} `json:"metadata"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Metadata["processed_format"] != "wav" || out.Metadata["conversion_applied"] != true {
+		t.Fatalf("expected pcm to be converted to wav, metadata=%v", out.Metadata)
+	}
+}
+
+func TestProcessAudio_IdentityAudioLimitOverride(t *testing.T) {
+	router := newTestRouter(t)
+
+	pcm := make([]byte, 32000)
+	newReq := func(apiKey string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/process_audio?task=transcribe&audio_format=pcm_s16le&sample_rate=16000", bytes.NewReader(pcm))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("X-API-Key", apiKey)
+		return req
+	}
+
+	if resp := doRequest(t, router, newReq("user-key")); resp.Code != http.StatusOK {
+		t.Fatalf("status=%d want 200, body=%s", resp.Code, resp.Body.String())
+	}
+	if resp := doRequest(t, router, newReq("small-audio-key")); resp.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want 400 for identity audio_max_size_bytes override, body=%s", resp.Code, resp.Body.String())
+	}
+}
+
+func pcmTone(seconds float64, amplitude float64) []byte {
+	n := int(seconds * 16000)
+	out := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		v := int16(amplitude * math.Sin(2*math.Pi*440*float64(i)/16000))
+		binary.LittleEndian.PutUint16(out[2*i:], uint16(v))
+	}
+	return out
+}
+
+func TestStreamWebSocket_TranslatesUtterances(t *testing.T) {
+	server := httptest.NewServer(newTestRouter(t))
+	t.Cleanup(server.Close)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/stream"
+
+	if _, resp, err := websocket.DefaultDialer.Dial(wsURL, nil); err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without credentials, err=%v resp=%v", err, resp)
+	}
+
+	// Browsers cannot set headers on the handshake, so the key may come from the query string.
+	legacy, _, err := websocket.DefaultDialer.Dial(wsURL+"?api_key=user-key", nil)
+	if err != nil {
+		t.Fatalf("dial with query credentials: %v", err)
+	}
+	_ = legacy.Close()
+
+	// Preferred: the key travels in Sec-WebSocket-Protocol and the server selects lingualink.v1.
+	dialer := websocket.Dialer{Subprotocols: []string{middleware.WebSocketProtocol, middleware.WebSocketKeyProtocolPrefix + "user-key"}}
+	conn, resp, err := dialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != middleware.WebSocketProtocol {
+		t.Fatalf("selected subprotocol=%q want %q", got, middleware.WebSocketProtocol)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	start := map[string]interface{}{
+		"type":             "start",
+		"audio_format":     "pcm_s16le",
+		"sample_rate":      16000,
+		"task":             "translate",
+		"target_languages": []string{"en"},
+		"partial_results":  false,
+	}
+	if err := conn.WriteJSON(start); err != nil {
+		t.Fatalf("write start: %v", err)
+	}
+	var ready map[string]interface{}
+	if err := conn.ReadJSON(&ready); err != nil || ready["type"] != "ready" {
+		t.Fatalf("ready=%v err=%v", ready, err)
+	}
+
+	audioStream := append(pcmTone(1, 8000), pcmTone(1, 0)...)
+	for off := 0; off < len(audioStream); off += 3200 {
+		if err := conn.WriteMessage(websocket.BinaryMessage, audioStream[off:off+3200]); err != nil {
+			t.Fatalf("write audio: %v", err)
+		}
+	}
+	if err := conn.WriteJSON(map[string]string{"type": "stop"}); err != nil {
+		t.Fatalf("write stop: %v", err)
+	}
+
+	var finals []map[string]interface{}
+	for {
+		var event map[string]interface{}
+		if err := conn.ReadJSON(&event); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				t.Fatalf("read: %v", err)
+			}
+			break
+		}
+		switch event["type"] {
+		case "final":
+			finals = append(finals, event)
+		case "error":
+			t.Fatalf("unexpected error event: %v", event)
+		}
+	}
+	if len(finals) != 1 {
+		t.Fatalf("finals=%v want 1", finals)
+	}
+	translations, _ := finals[0]["translations"].(map[string]interface{})
+	if finals[0]["transcription"] != "你好" || translations["en"] != "hello" {
+		t.Fatalf("unexpected final event: %v", finals[0])
+	}
+}
+
+func TestStreamWebSocket_RejectsInvalidStart(t *testing.T) {
+	server := httptest.NewServer(newTestRouter(t))
+	t.Cleanup(server.Close)
+
+	header := http.Header{"X-Api-Key": []string{"user-key"}}
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws/stream", header)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "start", "audio_format": "pcm_s16le", "task": "translate"}); err != nil {
+		t.Fatalf("write start: %v", err)
+	}
+	var event map[string]interface{}
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if event["type"] != "error" || event["code"] != "VALIDATION_ERROR" {
+		t.Fatalf("event=%v want validation error", event)
+	}
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+		t.Fatalf("expected policy violation close, got %v", err)
+	}
+}
+
 func TestProcessText_Success(t *testing.T) {
 	router := newTestRouter(t)
 	body := []byte(`{"text":"你好","target_languages":["en"]}`)
@@ -525,6 +788,159 @@ func TestProcessText_Success(t *testing.T) {
 	}
 }
 
+func TestProcessText_EventStream(t *testing.T) {
+	router := newTestRouter(t)
+	body := []byte(`{"text":"你好","target_languages":["en"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/process_text", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-API-Key", "user-key")
+
+	resp := doRequest(t, router, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("status=%d want 200, body=%s", resp.Code, resp.Body.String())
+	}
+	if ct := resp.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("Content-Type=%q want text/event-stream", ct)
+	}
+
+	type sseEvent struct {
+		name string
+		data string
+	}
+	var events []sseEvent
+	for _, block := range strings.Split(strings.TrimSpace(resp.Body.String()), "\n\n") {
+		var e sseEvent
+		for _, line := range strings.Split(block, "\n") {
+			if v, ok := strings.CutPrefix(line, "event:"); ok {
+				e.name = v
+			} else if v, ok := strings.CutPrefix(line, "data:"); ok {
+				e.data = v
+			}
+		}
+		events = append(events, e)
+	}
+	if len(events) != 2 || events[0].name != "translation" || events[1].name != "final" {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	var translation map[string]string
+	if err := json.Unmarshal([]byte(events[0].data), &translation); err != nil {
+		t.Fatalf("unmarshal translation: %v", err)
+	}
+	if translation["language"] != "en" || translation["text"] != "hello" {
+		t.Fatalf("translation event=%v", translation)
+	}
+	var final text.ProcessResponse
+	if err := json.Unmarshal([]byte(events[1].data), &final); err != nil {
+		t.Fatalf("unmarshal final: %v", err)
+	}
+	if final.RequestID == "" || final.Translations["en"] != "hello" || final.Metadata["pipeline"] != "text_translate" {
+		t.Fatalf("unexpected final payload: %+v", final)
+	}
+
+	// Errors raised before the first event are plain JSON responses.
+	badReq := httptest.NewRequest(http.MethodPost, "/api/v1/process_text", bytes.NewReader([]byte(`{"text":"你好","target_languages":[]}`)))
+	badReq.Header.Set("Content-Type", "application/json")
+	badReq.Header.Set("Accept", "text/event-stream")
+	badReq.Header.Set("X-API-Key", "user-key")
+	badResp := doRequest(t, router, badReq)
+	if badResp.Code != http.StatusBadRequest || !strings.Contains(badResp.Header().Get("Content-Type"), "application/json") {
+		t.Fatalf("status=%d content-type=%q body=%s", badResp.Code, badResp.Header().Get("Content-Type"), badResp.Body.String())
+	}
+}
+
+func TestProcessText_EventStreamResendsGlossaryFix(t *testing.T) {
+	var calls int32
+	llmServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 首次翻译违反术语，术语校验的重译返回修正后的译文
+		content := "```json\n{\"translations\":{\"en\":\"Lingo is fun\"}}\n```"
+		if atomic.AddInt32(&calls, 1) > 1 {
+			content = "```json\n{\"translations\":{\"en\":\"Lingualink is fun\"}}\n```"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": content}},
+			},
+		})
+	}))
+	router := newTestRouterWithLLM(t, llmServer)
+
+	body := []byte(`{"text":"灵语很好玩","target_languages":["en"],"user_dictionary":[{"term":"灵语","translations":{"en":"Lingualink"}}]}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/process_text", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-API-Key", "user-key")
+	resp := doRequest(t, router, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("status=%d want 200, body=%s", resp.Code, resp.Body.String())
+	}
+
+	var translations []string
+	var final text.ProcessResponse
+	for _, block := range strings.Split(strings.TrimSpace(resp.Body.String()), "\n\n") {
+		var name, data string
+		for _, line := range strings.Split(block, "\n") {
+			if v, ok := strings.CutPrefix(line, "event:"); ok {
+				name = v
+			} else if v, ok := strings.CutPrefix(line, "data:"); ok {
+				data = v
+			}
+		}
+		switch name {
+		case "translation":
+			var e map[string]string
+			if err := json.Unmarshal([]byte(data), &e); err != nil {
+				t.Fatalf("unmarshal translation: %v", err)
+			}
+			translations = append(translations, e["text"])
+		case "final":
+			if err := json.Unmarshal([]byte(data), &final); err != nil {
+				t.Fatalf("unmarshal final: %v", err)
+			}
+		}
+	}
+	want := []string{"Lingo is fun", "Lingualink is fun"}
+	if len(translations) != len(want) || translations[0] != want[0] || translations[1] != want[1] {
+		t.Fatalf("translation events=%q want %q", translations, want)
+	}
+	if final.Translations["en"] != "Lingualink is fun" {
+		t.Fatalf("final translations=%v", final.Translations)
+	}
+}
+
+func TestWatchProcessingStatus_StreamsUntilTerminal(t *testing.T) {
+	router := newTestRouter(t)
+	body := []byte(`{"text":"你好","target_languages":["en"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/process_text", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", "user-key")
+	req.Header.Set("X-Request-ID", "req-watch")
+	if resp := doRequest(t, router, req); resp.Code != http.StatusOK {
+		t.Fatalf("status=%d want 200, body=%s", resp.Code, resp.Body.String())
+	}
+
+	watchReq := httptest.NewRequest(http.MethodGet, "/api/v1/status/req-watch/events", nil)
+	watchReq.Header.Set("X-API-Key", "user-key")
+	watchResp := doRequest(t, router, watchReq)
+	if watchResp.Code != http.StatusOK || !strings.HasPrefix(watchResp.Header().Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("status=%d content-type=%q", watchResp.Code, watchResp.Header().Get("Content-Type"))
+	}
+	events := strings.TrimSpace(watchResp.Body.String())
+	if strings.Count(events, "event:status") != 1 || !strings.Contains(events, `"status":"completed"`) || !strings.Contains(events, `"progress":100`) {
+		t.Fatalf("unexpected events: %s", events)
+	}
+
+	// Unknown requests are watched until the timeout.
+	pendingReq := httptest.NewRequest(http.MethodGet, "/api/v1/status/req-pending/events?timeout=300ms", nil)
+	pendingReq.Header.Set("X-API-Key", "user-key")
+	pendingResp := doRequest(t, router, pendingReq)
+	if got := pendingResp.Body.String(); !strings.Contains(got, "event:timeout") || strings.Contains(got, "event:status") {
+		t.Fatalf("unexpected events: %s", got)
+	}
+}
+
 func TestProcessTextBatch_Success(t *testing.T) {
 	router := newTestRouter(t)
 	body := []byte(`{"texts":["你好","再见"],"target_languages":["en"]}`)
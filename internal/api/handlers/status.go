@@ -2,8 +2,11 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
@@ -35,3 +38,94 @@ func (h *Handler) GetProcessingStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, status)
 }
+
+const (
+	statusWatchInterval       = 200 * time.Millisecond
+	statusWatchKeepAlive      = 15 * time.Second
+	statusWatchDefaultTimeout = time.Minute
+	statusWatchMaxTimeout     = 5 * time.Minute
+)
+
+// WatchProcessingStatus streams status changes as Server-Sent Events until the request
+// completes or fails, or the timeout (?timeout=30s, default 60s, max 5m) expires.
+// The request does not need to exist yet: clients may send their own X-Request-ID and subscribe first.
+func (h *Handler) WatchProcessingStatus(c *gin.Context) {
+	requestID := c.Param("request_id")
+	if requestID == "" {
+		respondError(c, http.StatusBadRequest, coreerrors.NewValidationError("request_id is required", nil))
+		return
+	}
+	if h.statusStore == nil {
+		respondError(c, http.StatusInternalServerError, coreerrors.NewInternalError("status store not configured", nil))
+		return
+	}
+
+	timeout := statusWatchDefaultTimeout
+	if raw := c.Query("timeout"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			// 也接受纯数字秒数
+			secs, convErr := strconv.Atoi(raw)
+			d, err = time.Duration(secs)*time.Second, convErr
+		}
+		if err != nil || d <= 0 {
+			respondError(c, http.StatusBadRequest, coreerrors.NewValidationError("timeout must be a positive duration", err))
+			return
+		}
+		timeout = min(d, statusWatchMaxTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	poll := time.NewTicker(statusWatchInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(statusWatchKeepAlive)
+	defer keepAlive.Stop()
+
+	var last *processing.ProcessingStatus
+	for {
+		status, err := h.statusStore.Get(requestID)
+		switch {
+		case err == nil:
+			if last == nil || !sameStatus(*last, *status) {
+				c.SSEvent("status", status)
+				c.Writer.Flush()
+				if status.Terminal() {
+					return
+				}
+				last = status
+			}
+		case !errors.Is(err, processing.ErrStatusNotFound):
+			c.SSEvent("error", ErrorResponse{Error: "failed to get processing status", Code: string(coreerrors.ErrCodeInternal)})
+			c.Writer.Flush()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			if c.Request.Context().Err() == nil {
+				c.SSEvent("timeout", gin.H{"request_id": requestID})
+				c.Writer.Flush()
+			}
+			return
+		case <-keepAlive.C:
+			_, _ = c.Writer.WriteString(": keep-alive\n\n")
+			c.Writer.Flush()
+		case <-poll.C:
+		}
+	}
+}
+
+// sameStatus compares two snapshots ignoring UpdatedAt.
+func sameStatus(a, b processing.ProcessingStatus) bool {
+	a.UpdatedAt = b.UpdatedAt
+	return a == b
+}
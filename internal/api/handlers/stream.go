@@ -0,0 +1,217 @@
+// stream.go contains the WebSocket endpoint for real-time speech translation.
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/api/middleware"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/stream"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const streamWriteTimeout = 10 * time.Second
+
+var streamUpgrader = websocket.Upgrader{
+	ReadBufferSize:  16 * 1024,
+	WriteBufferSize: 16 * 1024,
+	// 与 CORS 中间件一致允许任意来源；认证基于 API Key / JWT 而非 Cookie
+	CheckOrigin: func(*http.Request) bool { return true },
+	// 客户端通过子协议传递凭据时须回应一个它提供的子协议，否则浏览器会关闭连接
+	Subprotocols: []string{middleware.WebSocketProtocol},
+}
+
+// streamStartMessage is the first (text) message of a session.
+type streamStartMessage struct {
+	Type            string                  `json:"type"`
+	AudioFormat     string                  `json:"audio_format"`
+	SampleRate      int                     `json:"sample_rate,omitempty"`
+	Channels        int                     `json:"channels,omitempty"`
+	Task            prompt.TaskType         `json:"task"`
+	SourceLanguage  string                  `json:"source_language,omitempty"`
+	TargetLanguages []string                `json:"target_languages"`
+	UserDictionary  []config.DictionaryTerm `json:"user_dictionary,omitempty"`
+	Options         map[string]interface{}  `json:"options,omitempty"`
+	PartialResults  *bool                   `json:"partial_results,omitempty"` // 默认 true
+}
+
+// streamControlMessage is any later text message: {"type":"flush"} or {"type":"stop"}.
+type streamControlMessage struct {
+	Type string `json:"type"`
+}
+
+// HandleWebSocket serves /ws/stream. Authentication happens in the Auth middleware before the upgrade.
+// Protocol: a JSON "start" message, then binary audio frames (raw PCM, or Opus in Ogg/WebM);
+// "flush" ends the current utterance, "stop" finishes pending utterances and closes the session.
+// The server sends "ready", then "partial" / "final" / "error" events per utterance.
+func (h *Handler) HandleWebSocket(c *gin.Context) {
+	identity, exists := c.Get("identity")
+	if !exists {
+		respondError(c, http.StatusUnauthorized, coreerrors.NewAuthError("authentication required", nil))
+		return
+	}
+	userIdentity := identity.(*auth.Identity)
+
+	cfg := stream.WithDefaults(h.config.Stream)
+	conn, err := streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade 已写回 HTTP 错误
+		h.logger.WithError(err).Warn("WebSocket upgrade failed")
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(int64(cfg.MaxFrameBytes))
+
+	sessionID, ok := logging.RequestIDFromContext(c.Request.Context())
+	if !ok || sessionID == "" {
+		sessionID = fmt.Sprintf("ws_%d", time.Now().UnixNano())
+	}
+	logger := h.logger.WithFields(logrus.Fields{
+		logging.FieldRequestID: sessionID,
+		logging.FieldUserID:    userIdentity.ID,
+	})
+
+	var writeMu sync.Mutex
+	writeJSON := func(v interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_ = conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		return conn.WriteJSON(v)
+	}
+	closeWith := func(code int, text string) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(streamWriteTimeout))
+	}
+	conn.SetPingHandler(func(data string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(cfg.IdleTimeout))
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(streamWriteTimeout))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+
+	settings, err := h.readStreamStart(c, conn, cfg, sessionID)
+	if err != nil {
+		_ = writeJSON(stream.Event{Type: stream.EventError, SessionID: sessionID, Code: string(coreerrors.ErrCodeValidation), Error: err.Error()})
+		closeWith(websocket.ClosePolicyViolation, "invalid start message")
+		return
+	}
+
+	session, err := stream.NewSession(c.Request.Context(), settings, cfg, stream.NewProcessorBackend(h.audioProcessor, h.audioProcessingService), func(e stream.Event) error {
+		return writeJSON(e)
+	}, h.logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start stream session")
+		_ = writeJSON(stream.Event{Type: stream.EventError, SessionID: sessionID, Code: string(coreerrors.ErrCodeInternal), Error: err.Error()})
+		closeWith(websocket.CloseInternalServerErr, "session start failed")
+		return
+	}
+	// 客户端断开或超时：放弃尚未完成的语句
+	abort := func() {
+		session.Cancel()
+		_ = session.Close()
+	}
+
+	h.metrics.RecordCounter("api.stream.sessions", 1, map[string]string{"user_id": userIdentity.ID})
+	logger.WithField(logging.FieldAudioFormat, settings.AudioFormat).Info("Stream session started")
+	if err := writeJSON(stream.Event{Type: stream.EventReady, SessionID: sessionID, SampleRate: session.SampleRate()}); err != nil {
+		abort()
+		return
+	}
+
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(cfg.IdleTimeout))
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.WithError(err).Debug("Stream connection closed")
+			}
+			abort()
+			return
+		}
+
+		switch msgType {
+		case websocket.BinaryMessage:
+			if err := session.WriteAudio(data); err != nil {
+				logger.WithError(err).Warn("Stream audio decoding failed")
+				_ = writeJSON(stream.Event{Type: stream.EventError, SessionID: sessionID, Code: string(coreerrors.ErrCodeValidation), Error: err.Error()})
+				abort()
+				closeWith(websocket.CloseUnsupportedData, "audio decoding failed")
+				return
+			}
+		case websocket.TextMessage:
+			var ctrl streamControlMessage
+			if err := json.Unmarshal(data, &ctrl); err != nil {
+				_ = writeJSON(stream.Event{Type: stream.EventError, SessionID: sessionID, Code: string(coreerrors.ErrCodeValidation), Error: "invalid control message"})
+				continue
+			}
+			switch ctrl.Type {
+			case "flush":
+				session.Flush()
+			case "stop":
+				if err := session.Close(); err != nil {
+					logger.WithError(err).Warn("Stream session closed with error")
+				}
+				closeWith(websocket.CloseNormalClosure, "")
+				logger.Info("Stream session finished")
+				return
+			default:
+				_ = writeJSON(stream.Event{Type: stream.EventError, SessionID: sessionID, Code: string(coreerrors.ErrCodeValidation), Error: fmt.Sprintf("unknown message type: %q", ctrl.Type)})
+			}
+		}
+	}
+}
+
+// readStreamStart reads and validates the start message.
+func (h *Handler) readStreamStart(c *gin.Context, conn *websocket.Conn, cfg config.StreamConfig, sessionID string) (stream.Settings, error) {
+	_ = conn.SetReadDeadline(time.Now().Add(cfg.IdleTimeout))
+	msgType, data, err := conn.ReadMessage()
+	if err != nil {
+		return stream.Settings{}, fmt.Errorf("read start message: %w", err)
+	}
+	if msgType != websocket.TextMessage {
+		return stream.Settings{}, errors.New("the first message must be a JSON start message")
+	}
+	var start streamStartMessage
+	if err := json.Unmarshal(data, &start); err != nil {
+		return stream.Settings{}, fmt.Errorf("invalid start message: %w", err)
+	}
+	if start.Type != "start" {
+		return stream.Settings{}, fmt.Errorf("expected a start message, got %q", start.Type)
+	}
+
+	template := audio.ProcessRequest{
+		Task:            start.Task,
+		SourceLanguage:  start.SourceLanguage,
+		TargetLanguages: start.TargetLanguages,
+		UserDictionary:  start.UserDictionary,
+		Options:         start.Options,
+	}
+	limits := h.audioLimits(c)
+	h.annotateAudioRequest(c, &template, limits)
+
+	return stream.PrepareSettings(h.audioProcessor, stream.Settings{
+		ID:          sessionID,
+		AudioFormat: start.AudioFormat,
+		SampleRate:  start.SampleRate,
+		Channels:    start.Channels,
+		Request:     template,
+		Partials:    start.PartialResults == nil || *start.PartialResults,
+	}, limits)
+}
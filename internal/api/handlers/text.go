@@ -27,6 +27,10 @@ func (h *Handler) ProcessText(c *gin.Context) {
 		return req, nil
 	}
 
+	if wantsEventStream(c) {
+		h.processTextStream(c, decoder)
+		return
+	}
 	handleProcessingRequest(c, h, h.textProcessingService, h.textProcessor, decoder)
 }
 
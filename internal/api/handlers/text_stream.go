@@ -0,0 +1,193 @@
+// text_stream.go contains the Server-Sent Events variant of process_text.
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/text"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// SSE event names for process_text.
+const (
+	textEventCorrectedText = "corrected_text"
+	textEventTranslation   = "translation"
+	textEventFinal         = "final"
+	textEventError         = "error"
+)
+
+type textStreamCorrectedText struct {
+	CorrectedText string `json:"corrected_text"`
+}
+
+type textStreamTranslation struct {
+	Language string `json:"language"`
+	Text     string `json:"text"`
+}
+
+// wantsEventStream reports whether the client asked for Server-Sent Events.
+func wantsEventStream(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
+
+// processTextStream runs process_text and streams the corrected text and each translation as
+// soon as it is available, followed by a "final" event with the full ProcessResponse.
+// Errors before the first event are returned as regular JSON errors.
+func (h *Handler) processTextStream(c *gin.Context, decoder func(*gin.Context) (text.ProcessRequest, error)) {
+	requestIDStr, ok := logging.RequestIDFromContext(c.Request.Context())
+	if !ok {
+		requestID, _ := c.Get("request_id")
+		requestIDStr, _ = requestID.(string)
+	}
+	setStatus := func(status string, progress int, message string) {
+		if h.statusStore != nil && requestIDStr != "" {
+			_ = h.statusStore.Set(requestIDStr, &processing.ProcessingStatus{
+				Status:   status,
+				Progress: progress,
+				Message:  message,
+			})
+		}
+	}
+	setStatus("processing", 0, "Processing started")
+
+	identity, exists := c.Get("identity")
+	if !exists {
+		setStatus("failed", 0, "authentication required")
+		respondError(c, http.StatusUnauthorized, coreerrors.NewAuthError("authentication required", nil))
+		return
+	}
+	userIdentity := identity.(*auth.Identity)
+
+	req, err := decoder(c)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to decode request")
+		setStatus("failed", 0, err.Error())
+		respondError(c, http.StatusBadRequest, coreerrors.NewValidationError(err.Error(), err))
+		return
+	}
+
+	type outcome struct {
+		resp *text.ProcessResponse
+		err  error
+	}
+	partials := make(chan tool.PartialResult, 16)
+	done := make(chan outcome, 1)
+	// 监听器在 Process 返回前全部完成，因此收到 done 后排空 partials 即可
+	ctx := tool.WithPartialListener(c.Request.Context(), func(r tool.PartialResult) {
+		partials <- r
+	})
+	go func() {
+		resp, err := h.textProcessingService.Process(ctx, req, h.textProcessor)
+		done <- outcome{resp: resp, err: err}
+	}()
+
+	started := false
+	sentCorrected := false
+	// 记录每种语言最后推送的译文：术语校验重译后的修正译文需要再次推送
+	sentTranslations := make(map[string]string)
+	send := func(event string, data interface{}) {
+		if !started {
+			started = true
+			c.Header("Cache-Control", "no-cache")
+			c.Header("Connection", "keep-alive")
+			c.Header("X-Accel-Buffering", "no")
+			c.Status(http.StatusOK)
+		}
+		c.SSEvent(event, data)
+		c.Writer.Flush()
+	}
+	sendPartial := func(r tool.PartialResult) {
+		switch r.Kind {
+		case tool.PartialCorrectedText:
+			if !sentCorrected {
+				sentCorrected = true
+				send(textEventCorrectedText, textStreamCorrectedText{CorrectedText: r.Text})
+			}
+		case tool.PartialTranslation:
+			if sent, ok := sentTranslations[r.Language]; !ok || sent != r.Text {
+				sentTranslations[r.Language] = r.Text
+				send(textEventTranslation, textStreamTranslation{Language: r.Language, Text: r.Text})
+			}
+		}
+	}
+
+	var result outcome
+wait:
+	for {
+		select {
+		case r := <-partials:
+			sendPartial(r)
+		case result = <-done:
+			for {
+				select {
+				case r := <-partials:
+					sendPartial(r)
+				default:
+					break wait
+				}
+			}
+		}
+	}
+
+	if result.err != nil {
+		h.logger.WithError(result.err).Error("Processing failed")
+		setStatus("failed", 100, result.err.Error())
+		if !started {
+			respondError(c, 0, result.err)
+			return
+		}
+		payload := ErrorResponse{Error: result.err.Error()}
+		var appErr *coreerrors.AppError
+		if errors.As(result.err, &appErr) {
+			payload = ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)}
+		}
+		send(textEventError, payload)
+		return
+	}
+
+	resp := result.resp
+	defer resp.Release()
+	if requestIDStr != "" {
+		resp.SetRequestID(requestIDStr)
+	}
+
+	// 缓存命中或单次调用的流程可能未推送中间结果，在 final 之前补齐
+	if resp.CorrectedText != "" {
+		sendPartial(tool.PartialResult{Kind: tool.PartialCorrectedText, Text: resp.CorrectedText})
+	}
+	for _, code := range translationOrder(req.TargetLanguages, resp.Translations) {
+		sendPartial(tool.PartialResult{Kind: tool.PartialTranslation, Language: code, Text: resp.Translations[code]})
+	}
+
+	h.metrics.RecordCounter("api.process.success", 1, map[string]string{"user_id": userIdentity.ID})
+	setStatus("completed", 100, "Processing completed")
+	send(textEventFinal, resp)
+}
+
+// translationOrder lists the translated languages, requested ones first in request order.
+func translationOrder(requested []string, translations map[string]string) []string {
+	order := make([]string, 0, len(translations))
+	seen := make(map[string]bool, len(translations))
+	for _, code := range requested {
+		if _, ok := translations[code]; ok && !seen[code] {
+			seen[code] = true
+			order = append(order, code)
+		}
+	}
+	rest := make([]string, 0)
+	for code := range translations {
+		if !seen[code] {
+			rest = append(rest, code)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
+}
@@ -0,0 +1,33 @@
+// tts.go contains the download endpoint for synthesized speech.
+package handlers
+
+import (
+	"net/http"
+
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
+	"github.com/gin-gonic/gin"
+)
+
+// WithTTSStore serves speech returned with delivery=url from store.
+func (h *Handler) WithTTSStore(store *tts.Store) *Handler {
+	h.ttsStore = store
+	return h
+}
+
+// GetSpeech 下载 delivery=url 返回的语音；链接 ID 不可猜测，过期后返回 404
+func (h *Handler) GetSpeech(c *gin.Context) {
+	if h.ttsStore == nil {
+		respondError(c, http.StatusNotFound, coreerrors.NewValidationError("tts is not configured", nil))
+		return
+	}
+
+	clip, ok := h.ttsStore.Get(c.Param("id"))
+	if !ok {
+		respondError(c, http.StatusNotFound, coreerrors.NewValidationError("speech not found or expired", nil))
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=0, no-store")
+	c.Data(http.StatusOK, clip.ContentType, clip.Audio)
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -9,8 +10,11 @@ import (
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -68,7 +72,7 @@ func RequestID() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
 		if requestID == "" {
-			requestID = generateRequestID()
+			requestID = GenerateRequestID()
 		}
 		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
 		c.Header("X-Request-ID", requestID)
@@ -77,6 +81,38 @@ func RequestID() gin.HandlerFunc {
 	})
 }
 
+// Tracing 链路追踪中间件：延续上游 traceparent，为每个请求创建 server span
+func Tracing() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx := tracing.Extract(c.Request.Context(), c.Request.Header)
+		ctx, span := tracing.Start(ctx, c.Request.Method+" "+route, trace.SpanKindServer,
+			attribute.String("http.request.method", c.Request.Method),
+			attribute.String("http.route", route),
+		)
+		if requestID, exists := c.Get("request_id"); exists {
+			span.SetAttributes(attribute.String(logging.FieldRequestID, fmt.Sprint(requestID)))
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		var err error
+		if status >= 500 {
+			err = fmt.Errorf("HTTP %d", status)
+			if len(c.Errors) > 0 {
+				err = fmt.Errorf("HTTP %d: %s", status, c.Errors.String())
+			}
+		}
+		tracing.End(span, err)
+	})
+}
+
 // Logging 日志中间件
 func Logging(logger *logrus.Logger) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
@@ -95,7 +131,7 @@ func Logging(logger *logrus.Logger) gin.HandlerFunc {
 		bodySize := c.Writer.Size()
 
 		if raw != "" {
-			path = path + "?" + raw
+			path = path + "?" + redactQuery(raw)
 		}
 
 		entry := logger.WithFields(logrus.Fields{
@@ -269,18 +305,81 @@ func Recovery(logger *logrus.Logger) gin.HandlerFunc {
 
 // 辅助函数
 
+// WebSocket subprotocols. Browsers cannot set headers on the handshake, so clients offer
+// WebSocketProtocol plus one credential entry (WebSocketKeyProtocolPrefix + API key, or
+// WebSocketTokenProtocolPrefix + JWT); the server selects WebSocketProtocol.
+const (
+	WebSocketProtocol            = "lingualink.v1"
+	WebSocketKeyProtocolPrefix   = "lingualink.key."
+	WebSocketTokenProtocolPrefix = "lingualink.jwt."
+)
+
+// credentialQueryParams are the query parameters that may carry credentials on a WebSocket handshake.
+var credentialQueryParams = []string{"api_key", "access_token"}
+
+// redactQuery masks credential query parameters so they never reach the access log.
+func redactQuery(raw string) string {
+	parts := strings.Split(raw, "&")
+	for i, part := range parts {
+		key, _, hasValue := strings.Cut(part, "=")
+		if !hasValue {
+			continue
+		}
+		if name, err := url.QueryUnescape(key); err == nil {
+			for _, param := range credentialQueryParams {
+				if strings.EqualFold(name, param) {
+					parts[i] = key + "=REDACTED"
+				}
+			}
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
 // extractCredentials 提取认证凭据
 func extractCredentials(c *gin.Context) auth.Credentials {
+	credentials := CredentialsFromHeaders(c.GetHeader("X-API-Key"), c.GetHeader("Authorization"))
+
+	// 浏览器无法为 WebSocket 握手设置请求头：优先从 Sec-WebSocket-Protocol 读取凭据，
+	// 其次兼容查询参数（URL 可能被代理记录，不推荐）
+	if credentials.APIKey == "" && credentials.Token == "" && isWebSocketUpgrade(c) {
+		for _, protocol := range websocketProtocols(c) {
+			if apiKey, ok := strings.CutPrefix(protocol, WebSocketKeyProtocolPrefix); ok && apiKey != "" {
+				credentials.APIKey = apiKey
+				credentials.Type = "api_key"
+				return credentials
+			}
+			if token, ok := strings.CutPrefix(protocol, WebSocketTokenProtocolPrefix); ok && looksLikeJWT(token) {
+				credentials.Token = "Bearer " + token
+				credentials.Type = "jwt"
+				return credentials
+			}
+		}
+		if apiKey := c.Query("api_key"); apiKey != "" {
+			credentials.APIKey = apiKey
+			credentials.Type = "api_key"
+		} else if token := c.Query("access_token"); token != "" && looksLikeJWT(token) {
+			credentials.Token = "Bearer " + token
+			credentials.Type = "jwt"
+		}
+	}
+
+	return credentials
+}
+
+// CredentialsFromHeaders builds credentials from the X-API-Key and Authorization values.
+// gRPC metadata uses the same keys, so both transports share this parsing.
+func CredentialsFromHeaders(apiKeyHeader, authHeader string) auth.Credentials {
 	credentials := auth.Credentials{}
 
 	// 从Header提取API Key
-	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
-		credentials.APIKey = apiKey
+	if apiKeyHeader != "" {
+		credentials.APIKey = apiKeyHeader
 		credentials.Type = "api_key"
 	}
 
 	// 从Authorization Header提取Token
-	if authHeader := c.GetHeader("Authorization"); authHeader != "" && credentials.APIKey == "" {
+	if authHeader != "" && credentials.APIKey == "" {
 		if strings.HasPrefix(authHeader, "Bearer ") {
 			bearer := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
 			if bearer != "" && looksLikeJWT(bearer) {
@@ -300,15 +399,59 @@ func extractCredentials(c *gin.Context) auth.Credentials {
 	return credentials
 }
 
+// websocketProtocols lists the subprotocols offered in Sec-WebSocket-Protocol.
+func websocketProtocols(c *gin.Context) []string {
+	var protocols []string
+	for _, header := range c.Request.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+	return protocols
+}
+
+func isWebSocketUpgrade(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(c.GetHeader("Connection")), "upgrade")
+}
+
+// RateLimitRejection describes a request refused by the per-identity rate limit.
+type RateLimitRejection struct {
+	Code       string
+	Message    string
+	RetryAfter time.Duration
+}
+
 func allowRequestByRateLimit(identity *auth.Identity, now time.Time, c *gin.Context) bool {
-	if identity == nil || identity.RateLimits == nil {
+	rejection := CheckRateLimit(identity, now)
+	if rejection == nil {
 		return true
 	}
 
+	if rejection.RetryAfter > 0 {
+		c.Header("Retry-After", fmt.Sprintf("%.0f", rejection.RetryAfter.Seconds()))
+	}
+	c.JSON(429, gin.H{
+		"error":   rejection.Code,
+		"message": rejection.Message,
+	})
+	c.Abort()
+	return false
+}
+
+// CheckRateLimit counts one request against the identity's window and returns a rejection
+// when the limit is exhausted. It is shared by the HTTP middleware and the gRPC interceptors.
+func CheckRateLimit(identity *auth.Identity, now time.Time) *RateLimitRejection {
+	if identity == nil || identity.RateLimits == nil {
+		return nil
+	}
+
 	windowSize := identity.RateLimits.WindowSize
 	limit := identity.RateLimits.RequestsPerMinute
 	if windowSize <= 0 || limit <= 0 {
-		return true
+		return nil
 	}
 
 	key := buildRateLimitKey(identity)
@@ -322,7 +465,7 @@ func allowRequestByRateLimit(identity *auth.Identity, now time.Time, c *gin.Cont
 			windowStartedAt: now,
 			count:           1,
 		}
-		return true
+		return nil
 	}
 
 	if state.count >= limit {
@@ -331,26 +474,20 @@ func allowRequestByRateLimit(identity *auth.Identity, now time.Time, c *gin.Cont
 			retryAfter = 0
 		}
 
-		errorCode := errCodeRateLimitExceeded
-		errorMessage := "request rate limit exceeded, please retry later"
-		if isFreeQuotaIdentity(identity) {
-			errorCode = errCodeFreeTrialQuotaExhausted
-			errorMessage = "free trial quota exhausted, please subscribe to continue"
+		rejection := &RateLimitRejection{
+			Code:       errCodeRateLimitExceeded,
+			Message:    "request rate limit exceeded, please retry later",
+			RetryAfter: retryAfter,
 		}
-
-		if retryAfter > 0 {
-			c.Header("Retry-After", fmt.Sprintf("%.0f", retryAfter.Seconds()))
+		if isFreeQuotaIdentity(identity) {
+			rejection.Code = errCodeFreeTrialQuotaExhausted
+			rejection.Message = "free trial quota exhausted, please subscribe to continue"
 		}
-		c.JSON(429, gin.H{
-			"error":   errorCode,
-			"message": errorMessage,
-		})
-		c.Abort()
-		return false
+		return rejection
 	}
 
 	state.count++
-	return true
+	return nil
 }
 
 func buildRateLimitKey(identity *auth.Identity) string {
@@ -442,8 +579,8 @@ func looksLikeJWT(token string) bool {
 	return true
 }
 
-// generateRequestID 生成请求ID
-func generateRequestID() string {
+// GenerateRequestID 生成请求ID（HTTP 与 gRPC 共用）
+func GenerateRequestID() string {
 	return "req_" + time.Now().Format("20060102150405") + "_" + randomString(6)
 }
 
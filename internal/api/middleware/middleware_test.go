@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -15,8 +16,16 @@ import (
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/auth"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func newTestAuthenticator(t *testing.T, logger *logrus.Logger) *auth.MultiAuthenticator {
@@ -292,6 +301,66 @@ func TestLogging_IncludesRequestID(t *testing.T) {
 	}
 }
 
+func TestLogging_RedactsCredentialQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	logger := testutil.NewTestLogger()
+	hook := &captureHook{}
+	logger.AddHook(hook)
+
+	r := gin.New()
+	r.Use(Logging(logger))
+	r.GET("/ws/stream", func(c *gin.Context) { c.Status(200) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ws/stream?lang=en&api_key=user-key&access_token=eyJ.a.b", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.entries) == 0 {
+		t.Fatalf("expected at least one log entry")
+	}
+	for _, entry := range hook.entries {
+		line, _ := entry.String()
+		if strings.Contains(line, "user-key") || strings.Contains(line, "eyJ.a.b") {
+			t.Fatalf("credential leaked into log: %s", line)
+		}
+	}
+	path := hook.entries[len(hook.entries)-1].Data["path"]
+	if path != "/ws/stream?lang=en&api_key=REDACTED&access_token=REDACTED" {
+		t.Fatalf("path=%v", path)
+	}
+}
+
+func TestAuth_WebSocketSubprotocolCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	logger := testutil.NewTestLogger()
+	authenticator := newTestAuthenticator(t, logger)
+
+	r := gin.New()
+	r.Use(Auth(authenticator))
+	r.GET("/ws", func(c *gin.Context) { c.Status(200) })
+
+	for protocols, want := range map[string]int{
+		WebSocketProtocol + ", " + WebSocketKeyProtocolPrefix + "user-key": http.StatusOK,
+		WebSocketProtocol + ", " + WebSocketKeyProtocolPrefix + "bad-key":  http.StatusUnauthorized,
+		WebSocketProtocol: http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Sec-WebSocket-Protocol", protocols)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != want {
+			t.Errorf("protocols %q: status=%d want %d", protocols, rr.Code, want)
+		}
+	}
+}
+
 type mockCollector struct {
 	mu           sync.Mutex
 	latencyCalls int
@@ -371,3 +440,56 @@ func TestRecovery_CatchesPanic(t *testing.T) {
 }
 
 var _ metrics.MetricsCollector = (*mockCollector)(nil)
+
+func TestTracing_ContinuesUpstreamTrace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	var forwarded string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("traceparent")
+	}))
+	t.Cleanup(upstream.Close)
+
+	r := gin.New()
+	r.Use(RequestID(), Tracing())
+	r.GET("/items/:id", func(c *gin.Context) {
+		req, _ := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, upstream.URL, nil)
+		tracing.Inject(c.Request.Context(), req.Header)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		c.Status(http.StatusBadGateway)
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans=%d want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /items/:id" || span.SpanKind() != trace.SpanKindServer {
+		t.Fatalf("span name=%q kind=%v", span.Name(), span.SpanKind())
+	}
+	if got := span.SpanContext().TraceID().String(); got != traceID {
+		t.Fatalf("trace id=%s want %s", got, traceID)
+	}
+	if span.Status().Code != codes.Error {
+		t.Fatalf("status=%v want error for 502", span.Status().Code)
+	}
+	if want := "00-" + traceID + "-" + span.SpanContext().SpanID().String() + "-01"; forwarded != want {
+		t.Fatalf("forwarded traceparent=%q want %q", forwarded, want)
+	}
+}
@@ -21,6 +21,8 @@ func RegisterRoutes(router *gin.Engine, handler *handlers.Handler, authenticator
 		public.GET("/health/deep", handler.DeepHealthCheck)
 		public.GET("/capabilities", handler.GetCapabilities)
 		public.GET("/languages", handler.ListSupportedLanguages)
+		// 语音下载链接可直接交给播放器，ID 本身即凭据
+		public.GET("/tts/:id", handler.GetSpeech)
 	}
 
 	// 需要认证的路由
@@ -29,8 +31,8 @@ func RegisterRoutes(router *gin.Engine, handler *handlers.Handler, authenticator
 	{
 		protected.GET("/quota", handler.GetQuotaStatus)
 
-		// 音频处理 - 重命名为 process_audio
-		protected.POST("/process_audio", handler.ProcessAudioJSON)
+		// 音频处理 - 支持 JSON(base64) / multipart / 原始二进制上传
+		protected.POST("/process_audio", handler.ProcessAudio)
 
 		// 文本处理 - 新增 process_text 端点
 		protected.POST("/process_text", handler.ProcessText)
@@ -38,6 +40,7 @@ func RegisterRoutes(router *gin.Engine, handler *handlers.Handler, authenticator
 
 		// 异步处理状态查询
 		protected.GET("/status/:request_id", handler.GetProcessingStatus)
+		protected.GET("/status/:request_id/events", handler.WatchProcessingStatus)
 	}
 
 	// 管理员路由（需要服务级别认证）
@@ -47,9 +50,10 @@ func RegisterRoutes(router *gin.Engine, handler *handlers.Handler, authenticator
 		admin.GET("/metrics", handler.GetMetrics)
 	}
 
-	// WebSocket路由（预留）
-	// ws := router.Group("/ws")
-	// {
-	// 	ws.GET("/stream", handler.HandleWebSocket)
-	// }
+	// WebSocket 路由：在升级前完成认证
+	ws := router.Group("/ws")
+	ws.Use(middleware.Auth(authenticator))
+	{
+		ws.GET("/stream", handler.HandleWebSocket)
+	}
 }
@@ -9,6 +9,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.port", 8080)
 	v.SetDefault("server.host", "0.0.0.0")
 
+	// gRPC 默认关闭；消息上限需大于 audio.max_size_bytes
+	v.SetDefault("grpc.enabled", false)
+	v.SetDefault("grpc.port", 9090)
+	v.SetDefault("grpc.max_message_bytes", 40*1024*1024)
+
 	// 认证默认配置
 	v.SetDefault("auth.strategies", []map[string]interface{}{
 		{
@@ -42,6 +47,45 @@ func setDefaults(v *viper.Viper) {
 		},
 	})
 
+	v.SetDefault("asr.cache.enabled", true)
+	v.SetDefault("asr.cache.ttl", "10m")
+	v.SetDefault("asr.cache.max_entries", 500)
+
+	// TTS 默认配置（未配置 providers 时不启用）
+	v.SetDefault("tts.default_voice", "alloy")
+	v.SetDefault("tts.format", "mp3")
+	v.SetDefault("tts.url_ttl", "5m")
+	v.SetDefault("tts.max_entries", 256)
+
+	// 音频输入与转换默认配置
+	v.SetDefault("audio.max_size_bytes", 32*1024*1024)
+	v.SetDefault("audio.max_duration", "0s")
+	v.SetDefault("audio.allowed_formats", []string{"wav", "mp3", "m4a", "aac", "opus", "ogg", "flac", "webm", "amr", "pcm_s16le", "pcm_f32le"})
+	v.SetDefault("audio.converter_concurrency", 4)
+	v.SetDefault("audio.queue_timeout", "30s")
+	v.SetDefault("audio.output_sample_rate", 16000)
+
+	// 数据归档默认配置（默认关闭，且仅对选择加入的身份/请求生效）
+	v.SetDefault("archive.enabled", false)
+	v.SetDefault("archive.layout", "directory")
+	v.SetDefault("archive.path", "data/archive")
+	v.SetDefault("archive.max_age", "720h")
+	v.SetDefault("archive.max_bytes", int64(10*1024*1024*1024))
+	v.SetDefault("archive.max_records", 0)
+	v.SetDefault("archive.shard_max_bytes", int64(256*1024*1024))
+	v.SetDefault("archive.queue_size", 64)
+	v.SetDefault("archive.prune_interval", "10m")
+
+	v.SetDefault("stream.silence_threshold_db", -40.0)
+	v.SetDefault("stream.end_silence", "600ms")
+	v.SetDefault("stream.min_speech", "250ms")
+	v.SetDefault("stream.max_utterance", "15s")
+	v.SetDefault("stream.pre_roll", "200ms")
+	v.SetDefault("stream.partial_interval", "1s")
+	v.SetDefault("stream.max_frame_bytes", 64*1024)
+	v.SetDefault("stream.idle_timeout", "60s")
+	v.SetDefault("stream.queue_size", 4)
+
 	// 纠错默认配置
 	v.SetDefault("correction.enabled", true)
 	v.SetDefault("correction.merge_with_translation", true)
@@ -50,6 +94,9 @@ func setDefaults(v *viper.Viper) {
 	// Pipeline 默认配置
 	v.SetDefault("pipeline.tool_calling.enabled", true)
 	v.SetDefault("pipeline.tool_calling.allow_thinking", false)
+	v.SetDefault("pipeline.translation.max_parallel", 4)
+	v.SetDefault("pipeline.moderation.mask_char", "*")
+	v.SetDefault("pipeline.moderation.classifier.action", "flag")
 
 	// 提示词默认配置
 	v.SetDefault("prompt.defaults.task", "translate")
@@ -59,4 +106,11 @@ func setDefaults(v *viper.Viper) {
 	// 日志默认配置
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.format", "json")
+
+	// 链路追踪默认关闭；开启后默认导出到本地 collector
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.endpoint", "localhost:4318")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.service_name", "lingualink-core")
+	v.SetDefault("tracing.sample_ratio", 1.0)
 }
@@ -1,8 +1,60 @@
 package config
 
+import "time"
+
 // PipelineConfig controls pipeline execution behavior.
 type PipelineConfig struct {
 	ToolCalling ToolCallingConfig `mapstructure:"tool_calling"`
+	// Definitions declares custom pipelines by name (names are lower-cased by the config loader).
+	// They are checked against the processor's tool registry at startup.
+	Definitions map[string]PipelineDefinition `mapstructure:"definitions"`
+	// Tasks overrides the pipeline chosen for a task; unset tasks keep the built-in selection.
+	Tasks PipelineTaskMapping `mapstructure:"tasks"`
+	// MaxParallelSteps caps how many independent steps of one pipeline run at once (0 = default).
+	MaxParallelSteps int `mapstructure:"max_parallel_steps"`
+	// Translation controls how translate tools spread target languages over LLM calls.
+	Translation TranslationConfig `mapstructure:"translation"`
+	// Moderation configures the moderate tool.
+	Moderation ModerationConfig `mapstructure:"moderation"`
+}
+
+// Moderation actions.
+const (
+	ModerationFlag  = "flag"  // 仅记录命中
+	ModerationMask  = "mask"  // 用掩码字符替换命中内容
+	ModerationBlock = "block" // 清空整段文本
+)
+
+// ModerationConfig configures the moderate tool: word / regex rules and an optional LLM classifier.
+type ModerationConfig struct {
+	Rules      []ModerationRule           `mapstructure:"rules"`
+	MaskChar   string                     `mapstructure:"mask_char"` // 默认 "*"
+	Classifier ModerationClassifierConfig `mapstructure:"classifier"`
+}
+
+// ModerationRule matches words and regular expressions and applies Action to the matches.
+type ModerationRule struct {
+	Name      string   `mapstructure:"name"`
+	Languages []string `mapstructure:"languages"` // 适用的语言代码，空表示所有语言
+	Words     []string `mapstructure:"words"`     // 不区分大小写；以字母或数字开头/结尾的词按词边界匹配
+	Patterns  []string `mapstructure:"patterns"`  // Go 正则表达式
+	Action    string   `mapstructure:"action"`    // flag / mask / block
+}
+
+// ModerationClassifierConfig enables an LLM call that labels texts with harmful-content categories.
+type ModerationClassifierConfig struct {
+	Enabled    bool     `mapstructure:"enabled"`
+	Categories []string `mapstructure:"categories"` // 分类标签，空使用内置默认值
+	Action     string   `mapstructure:"action"`     // flag / block，默认 flag
+}
+
+// TranslationConfig controls fan-out translation.
+type TranslationConfig struct {
+	FanOut       bool       `mapstructure:"fan_out"`       // 按语言分组并发翻译
+	GroupSize    int        `mapstructure:"group_size"`    // 每组语言数，0 使用默认值
+	Groups       [][]string `mapstructure:"groups"`        // 显式分组，未覆盖的语言按 group_size 分组
+	RetryMissing int        `mapstructure:"retry_missing"` // 对回复中缺失的语言重新请求的轮数
+	MaxParallel  int        `mapstructure:"max_parallel"`  // 单次翻译同时进行的 LLM 请求上限，0 使用默认值
 }
 
 // ToolCallingConfig enables OpenAI-compatible tool calling for structured outputs.
@@ -10,3 +62,29 @@ type ToolCallingConfig struct {
 	Enabled       bool `mapstructure:"enabled"`
 	AllowThinking bool `mapstructure:"allow_thinking"`
 }
+
+// PipelineDefinition is a custom pipeline: an ordered list of tool steps.
+type PipelineDefinition struct {
+	Type  string               `mapstructure:"type"` // audio / text
+	Steps []PipelineStepConfig `mapstructure:"steps"`
+}
+
+// PipelineStepConfig is one step of a custom pipeline.
+type PipelineStepConfig struct {
+	Tool         string            `mapstructure:"tool"`
+	InputMapping map[string]string `mapstructure:"input_mapping"` // 工具输入字段 -> 取值表达式，如 request.text、asr_result.text
+	OutputKey    string            `mapstructure:"output_key"`
+	DependsOn    []string          `mapstructure:"depends_on"` // 显式依赖的前序步骤 output_key
+	When         string            `mapstructure:"when"`       // 执行条件，为假时跳过并透传输入
+	Timeout      time.Duration     `mapstructure:"timeout"`    // 单次执行超时，0 表示不限
+	Retries      int               `mapstructure:"retries"`    // 失败后的重试次数
+	Backoff      time.Duration     `mapstructure:"backoff"`    // 首次重试前的等待时间，之后逐次翻倍
+	OnError      string            `mapstructure:"on_error"`   // fail / skip / fallback
+	Fallback     string            `mapstructure:"fallback"`   // on_error 为 fallback 时执行的工具
+}
+
+// PipelineTaskMapping maps task names (translate / transcribe) to pipeline names per processor.
+type PipelineTaskMapping struct {
+	Audio map[string]string `mapstructure:"audio"`
+	Text  map[string]string `mapstructure:"text"`
+}
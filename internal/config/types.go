@@ -1,15 +1,23 @@
 package config
 
+import "time"
+
 // Config defines the full runtime configuration for Lingualink Core.
 type Config struct {
 	Server     ServerConfig     `mapstructure:"server"`
+	GRPC       GRPCConfig       `mapstructure:"grpc"`
 	Auth       AuthConfig       `mapstructure:"auth"`
 	ASR        ASRConfig        `mapstructure:"asr"`
+	TTS        TTSConfig        `mapstructure:"tts"`
+	Audio      AudioConfig      `mapstructure:"audio"`
+	Archive    ArchiveConfig    `mapstructure:"archive"`
+	Stream     StreamConfig     `mapstructure:"stream"`
 	Correction CorrectionConfig `mapstructure:"correction"`
 	Pipeline   PipelineConfig   `mapstructure:"pipeline"`
 	Backends   BackendsConfig   `mapstructure:"backends"`
 	Prompt     PromptConfig     `mapstructure:"prompt"`
 	Logging    LoggingConfig    `mapstructure:"logging"`
+	Tracing    TracingConfig    `mapstructure:"tracing"`
 }
 
 // ServerConfig controls the HTTP server.
@@ -19,6 +27,13 @@ type ServerConfig struct {
 	Host string `mapstructure:"host"`
 }
 
+// GRPCConfig controls the optional gRPC server, which listens on server.host next to the HTTP server.
+type GRPCConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	Port            int  `mapstructure:"port"`
+	MaxMessageBytes int  `mapstructure:"max_message_bytes"` // 单条消息上限，需容纳 ProcessAudio 的整段音频
+}
+
 // AuthConfig configures authentication strategies.
 type AuthConfig struct {
 	Strategies []AuthStrategy `mapstructure:"strategies"`
@@ -34,7 +49,65 @@ type AuthStrategy struct {
 
 // ASRConfig configures ASR providers.
 type ASRConfig struct {
-	Providers []ASRProvider `mapstructure:"providers"`
+	Providers []ASRProvider  `mapstructure:"providers"`
+	Cache     ASRCacheConfig `mapstructure:"cache"`
+}
+
+// ASRCacheConfig configures caching of transcription results by audio content hash.
+type ASRCacheConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	TTL        time.Duration `mapstructure:"ttl"`
+	MaxEntries int           `mapstructure:"max_entries"`
+}
+
+// AudioConfig controls audio input limits and conversion to the ASR-ready WAV format.
+type AudioConfig struct {
+	MaxSizeBytes         int           `mapstructure:"max_size_bytes"`
+	MaxDuration          time.Duration `mapstructure:"max_duration"` // 0 表示不限制
+	AllowedFormats       []string      `mapstructure:"allowed_formats"`
+	ConverterConcurrency int           `mapstructure:"converter_concurrency"`
+	QueueTimeout         time.Duration `mapstructure:"queue_timeout"`
+	OutputSampleRate     int           `mapstructure:"output_sample_rate"`
+	// DefaultProfile names the preprocessing profile applied when a request does not pick one.
+	DefaultProfile string                  `mapstructure:"default_profile"`
+	Profiles       map[string]AudioProfile `mapstructure:"profiles"`
+}
+
+// AudioProfile describes an ffmpeg preprocessing filter chain applied before ASR.
+type AudioProfile struct {
+	HighpassHz         int     `mapstructure:"highpass_hz"`          // 高通截止频率，0 关闭
+	NoiseReductionDB   float64 `mapstructure:"noise_reduction_db"`   // afftdn 降噪量（dB），0 关闭
+	SilenceRemove      bool    `mapstructure:"silence_remove"`       // 去除静音段
+	SilenceThresholdDB float64 `mapstructure:"silence_threshold_db"` // 静音阈值，默认 -50dB
+	Loudnorm           bool    `mapstructure:"loudnorm"`             // EBU R128 响度归一化
+}
+
+// ArchiveConfig configures opt-in archival of audio and results for dataset building.
+// Records are only written for identities or requests that opted in.
+type ArchiveConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Layout        string        `mapstructure:"layout"` // directory / tar
+	Path          string        `mapstructure:"path"`
+	MaxAge        time.Duration `mapstructure:"max_age"`         // 0 表示不按时间清理
+	MaxBytes      int64         `mapstructure:"max_bytes"`       // 0 表示不限制总大小
+	MaxRecords    int           `mapstructure:"max_records"`     // 0 表示不限制条数
+	ShardMaxBytes int64         `mapstructure:"shard_max_bytes"` // tar 分片大小上限
+	QueueSize     int           `mapstructure:"queue_size"`      // 异步写入队列长度，满时丢弃
+	PruneInterval time.Duration `mapstructure:"prune_interval"`  // 定期执行保留策略的间隔，0 表示仅在写入时清理
+}
+
+// StreamConfig controls WebSocket streaming sessions and utterance segmentation.
+// Zero values fall back to the built-in defaults.
+type StreamConfig struct {
+	SilenceThresholdDB float64       `mapstructure:"silence_threshold_db"` // 低于该能量（dBFS）的帧视为静音
+	EndSilence         time.Duration `mapstructure:"end_silence"`          // 连续静音达到该时长即结束当前语句
+	MinSpeech          time.Duration `mapstructure:"min_speech"`           // 有声部分短于该时长的语句被丢弃
+	MaxUtterance       time.Duration `mapstructure:"max_utterance"`        // 单句最长时长，超过后强制切分
+	PreRoll            time.Duration `mapstructure:"pre_roll"`             // 语句开始前保留的音频
+	PartialInterval    time.Duration `mapstructure:"partial_interval"`     // 中间结果间隔，0 使用默认值，负值关闭
+	MaxFrameBytes      int           `mapstructure:"max_frame_bytes"`      // 单条 WebSocket 消息上限
+	IdleTimeout        time.Duration `mapstructure:"idle_timeout"`         // 无消息超时
+	QueueSize          int           `mapstructure:"queue_size"`           // 等待处理的语句数上限
 }
 
 // ASRProvider configures an ASR backend provider.
@@ -47,6 +120,28 @@ type ASRProvider struct {
 	Parameters map[string]interface{} `mapstructure:"parameters"`
 }
 
+// TTSConfig configures text-to-speech providers and the delivery of synthesized audio.
+// TTS is disabled when no providers are configured.
+type TTSConfig struct {
+	Providers     []TTSProvider     `mapstructure:"providers"`
+	DefaultVoice  string            `mapstructure:"default_voice"`
+	Voices        map[string]string `mapstructure:"voices"`          // 语言代码 -> 音色，未配置的语言使用 default_voice
+	Format        string            `mapstructure:"format"`          // mp3 / opus / aac / flac / wav / pcm
+	URLTTL        time.Duration     `mapstructure:"url_ttl"`         // 下载链接有效期
+	MaxEntries    int               `mapstructure:"max_entries"`     // 暂存待下载音频的条数上限
+	PublicBaseURL string            `mapstructure:"public_base_url"` // 下载链接前缀，留空时返回相对路径
+}
+
+// TTSProvider configures a TTS backend provider.
+type TTSProvider struct {
+	Name       string                 `mapstructure:"name"`
+	Type       string                 `mapstructure:"type"` // openai / custom
+	URL        string                 `mapstructure:"url"`
+	Model      string                 `mapstructure:"model"`
+	APIKey     string                 `mapstructure:"api_key"`
+	Parameters map[string]interface{} `mapstructure:"parameters"`
+}
+
 // CorrectionConfig configures the optional correction stage.
 type CorrectionConfig struct {
 	Enabled              bool             `mapstructure:"enabled"`
@@ -55,9 +150,12 @@ type CorrectionConfig struct {
 }
 
 // DictionaryTerm represents a terminology mapping used during correction.
+// Translations optionally maps language codes to the required translation of the term,
+// which the glossary_check step enforces.
 type DictionaryTerm struct {
-	Term    string   `mapstructure:"term"`
-	Aliases []string `mapstructure:"aliases"`
+	Term         string            `mapstructure:"term"`
+	Aliases      []string          `mapstructure:"aliases"`
+	Translations map[string]string `mapstructure:"translations"`
 }
 
 // BackendsConfig configures LLM backend providers and load balancing.
@@ -80,6 +178,14 @@ type BackendProvider struct {
 	Model      string                 `mapstructure:"model"`
 	APIKey     string                 `mapstructure:"api_key"`
 	Parameters LLMParameters          `mapstructure:"parameters"`
+	Audio      BackendAudioConfig     `mapstructure:"audio"`
+}
+
+// BackendAudioConfig declares audio input support for chat models that accept input_audio parts.
+type BackendAudioConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Formats []string `mapstructure:"formats"`
+	MaxSize int64    `mapstructure:"max_size"`
 }
 
 // LLMParameters configures per-request/default model parameters.
@@ -137,3 +243,13 @@ type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
 }
+
+// TracingConfig controls OpenTelemetry tracing and span export over OTLP/HTTP.
+type TracingConfig struct {
+	Enabled     bool              `mapstructure:"enabled"`
+	Endpoint    string            `mapstructure:"endpoint"` // collector host:port，如 localhost:4318
+	Insecure    bool              `mapstructure:"insecure"` // 使用 http 而非 https
+	Headers     map[string]string `mapstructure:"headers"`  // 附加到导出请求的头部（如鉴权）
+	ServiceName string            `mapstructure:"service_name"`
+	SampleRatio float64           `mapstructure:"sample_ratio"` // 根 span 采样比例，0-1
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 )
 
@@ -14,6 +15,17 @@ func (c *Config) Validate() error {
 		errs = append(errs, fmt.Errorf("invalid server port: %d", c.Server.Port))
 	}
 
+	if c.GRPC.Enabled {
+		if c.GRPC.Port < 1 || c.GRPC.Port > 65535 {
+			errs = append(errs, fmt.Errorf("invalid grpc port: %d", c.GRPC.Port))
+		} else if c.GRPC.Port == c.Server.Port {
+			errs = append(errs, fmt.Errorf("grpc port must differ from server port"))
+		}
+		if c.GRPC.MaxMessageBytes < 0 {
+			errs = append(errs, fmt.Errorf("grpc: max_message_bytes must be non-negative"))
+		}
+	}
+
 	if len(c.ASR.Providers) == 0 {
 		errs = append(errs, fmt.Errorf("no asr providers configured"))
 	}
@@ -37,6 +49,189 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, provider := range c.TTS.Providers {
+		if provider.Name == "" {
+			errs = append(errs, fmt.Errorf("tts: missing name"))
+		}
+		switch provider.Type {
+		case "openai", "custom":
+		default:
+			errs = append(errs, fmt.Errorf("tts %s: unsupported type %q (openai or custom)", provider.Name, provider.Type))
+		}
+		if provider.URL == "" {
+			errs = append(errs, fmt.Errorf("tts %s: missing URL", provider.Name))
+			continue
+		}
+		if _, err := url.ParseRequestURI(provider.URL); err != nil {
+			errs = append(errs, fmt.Errorf("tts %s: invalid URL: %v", provider.Name, err))
+		}
+		if provider.Model == "" {
+			errs = append(errs, fmt.Errorf("tts %s: missing model", provider.Name))
+		}
+	}
+	if len(c.TTS.Providers) > 0 {
+		switch c.TTS.Format {
+		case "", "mp3", "opus", "aac", "flac", "wav", "pcm":
+		default:
+			errs = append(errs, fmt.Errorf("tts: unsupported format %q", c.TTS.Format))
+		}
+		if c.TTS.URLTTL < 0 || c.TTS.MaxEntries < 0 {
+			errs = append(errs, fmt.Errorf("tts: url_ttl and max_entries must be non-negative"))
+		}
+	}
+
+	if c.ASR.Cache.Enabled {
+		if c.ASR.Cache.TTL <= 0 {
+			errs = append(errs, fmt.Errorf("asr cache: ttl must be positive"))
+		}
+		if c.ASR.Cache.MaxEntries < 0 {
+			errs = append(errs, fmt.Errorf("asr cache: max_entries must be non-negative"))
+		}
+	}
+
+	// audio 段的零值表示使用默认值
+	if c.Audio.MaxSizeBytes < 0 {
+		errs = append(errs, fmt.Errorf("audio: max_size_bytes must be non-negative"))
+	}
+	if c.Audio.MaxDuration < 0 {
+		errs = append(errs, fmt.Errorf("audio: max_duration must be non-negative"))
+	}
+	if c.Audio.ConverterConcurrency < 0 {
+		errs = append(errs, fmt.Errorf("audio: converter_concurrency must be non-negative"))
+	}
+	if c.Audio.QueueTimeout < 0 {
+		errs = append(errs, fmt.Errorf("audio: queue_timeout must be non-negative"))
+	}
+	if rate := c.Audio.OutputSampleRate; rate != 0 && (rate < 8000 || rate > 48000) {
+		errs = append(errs, fmt.Errorf("audio: output_sample_rate must be between 8000 and 48000"))
+	}
+	for name, profile := range c.Audio.Profiles {
+		if profile.HighpassHz < 0 {
+			errs = append(errs, fmt.Errorf("audio profile %s: highpass_hz must be non-negative", name))
+		}
+		if profile.NoiseReductionDB < 0 || profile.NoiseReductionDB > 97 {
+			errs = append(errs, fmt.Errorf("audio profile %s: noise_reduction_db must be between 0 and 97", name))
+		}
+		if profile.SilenceThresholdDB > 0 {
+			errs = append(errs, fmt.Errorf("audio profile %s: silence_threshold_db must not be positive", name))
+		}
+	}
+
+	if c.Archive.Enabled {
+		switch c.Archive.Layout {
+		case "", "directory", "tar":
+		default:
+			errs = append(errs, fmt.Errorf("archive: unsupported layout %q (directory or tar)", c.Archive.Layout))
+		}
+		if strings.TrimSpace(c.Archive.Path) == "" {
+			errs = append(errs, fmt.Errorf("archive: path is required"))
+		}
+		if c.Archive.MaxAge < 0 || c.Archive.MaxBytes < 0 || c.Archive.MaxRecords < 0 {
+			errs = append(errs, fmt.Errorf("archive: retention limits must be non-negative"))
+		}
+		if c.Archive.ShardMaxBytes < 0 || c.Archive.QueueSize < 0 || c.Archive.PruneInterval < 0 {
+			errs = append(errs, fmt.Errorf("archive: shard_max_bytes, queue_size and prune_interval must be non-negative"))
+		}
+	}
+
+	if c.Tracing.Enabled {
+		if strings.TrimSpace(c.Tracing.Endpoint) == "" {
+			errs = append(errs, fmt.Errorf("tracing: endpoint is required"))
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			errs = append(errs, fmt.Errorf("tracing: sample_ratio must be between 0 and 1"))
+		}
+	}
+
+	// stream 段的零值同样表示使用默认值
+	if c.Stream.SilenceThresholdDB > 0 {
+		errs = append(errs, fmt.Errorf("stream: silence_threshold_db must not be positive"))
+	}
+	if c.Stream.EndSilence < 0 || c.Stream.MinSpeech < 0 || c.Stream.MaxUtterance < 0 || c.Stream.PreRoll < 0 || c.Stream.IdleTimeout < 0 {
+		errs = append(errs, fmt.Errorf("stream: durations must be non-negative"))
+	}
+	if c.Stream.MaxUtterance > 0 && c.Stream.MinSpeech > c.Stream.MaxUtterance {
+		errs = append(errs, fmt.Errorf("stream: min_speech must not exceed max_utterance"))
+	}
+	if c.Stream.MaxFrameBytes < 0 || c.Stream.QueueSize < 0 {
+		errs = append(errs, fmt.Errorf("stream: max_frame_bytes and queue_size must be non-negative"))
+	}
+
+	for i, rule := range c.Pipeline.Moderation.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		switch rule.Action {
+		case ModerationFlag, ModerationMask, ModerationBlock:
+		default:
+			errs = append(errs, fmt.Errorf("pipeline.moderation rule %s: unsupported action %q (flag, mask or block)", name, rule.Action))
+		}
+		for _, pattern := range rule.Patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Errorf("pipeline.moderation rule %s: invalid pattern %q: %w", name, pattern, err))
+			}
+		}
+	}
+	switch c.Pipeline.Moderation.Classifier.Action {
+	case "", ModerationFlag, ModerationBlock:
+	default:
+		errs = append(errs, fmt.Errorf("pipeline.moderation.classifier: unsupported action %q (flag or block)", c.Pipeline.Moderation.Classifier.Action))
+	}
+
+	if c.Pipeline.MaxParallelSteps < 0 {
+		errs = append(errs, fmt.Errorf("pipeline: max_parallel_steps must be non-negative"))
+	}
+	if c.Pipeline.Translation.GroupSize < 0 || c.Pipeline.Translation.RetryMissing < 0 || c.Pipeline.Translation.MaxParallel < 0 {
+		errs = append(errs, fmt.Errorf("pipeline translation: group_size, retry_missing and max_parallel must be non-negative"))
+	}
+	// 自定义 pipeline 的工具与输入映射在处理器初始化时对照 tool.Registry 校验
+	for name, def := range c.Pipeline.Definitions {
+		switch def.Type {
+		case "audio", "text":
+		default:
+			errs = append(errs, fmt.Errorf("pipeline %s: type must be audio or text, got %q", name, def.Type))
+		}
+		if len(def.Steps) == 0 {
+			errs = append(errs, fmt.Errorf("pipeline %s: at least one step is required", name))
+		}
+		outputKeys := make(map[string]bool, len(def.Steps))
+		for i, step := range def.Steps {
+			if strings.TrimSpace(step.Tool) == "" {
+				errs = append(errs, fmt.Errorf("pipeline %s step %d: missing tool", name, i+1))
+			}
+			key := strings.TrimSpace(step.OutputKey)
+			if key == "" || key == "request" {
+				errs = append(errs, fmt.Errorf("pipeline %s step %d: output_key is required and must not be \"request\"", name, i+1))
+			} else if outputKeys[key] {
+				errs = append(errs, fmt.Errorf("pipeline %s step %d: duplicate output_key %q", name, i+1, key))
+			}
+			outputKeys[key] = true
+			switch strings.ToLower(strings.TrimSpace(step.OnError)) {
+			case "", "fail", "skip":
+			case "fallback":
+				if strings.TrimSpace(step.Fallback) == "" {
+					errs = append(errs, fmt.Errorf("pipeline %s step %d: on_error fallback requires a fallback tool", name, i+1))
+				}
+			default:
+				errs = append(errs, fmt.Errorf("pipeline %s step %d: on_error must be fail, skip or fallback, got %q", name, i+1, step.OnError))
+			}
+			if step.Timeout < 0 || step.Retries < 0 || step.Backoff < 0 {
+				errs = append(errs, fmt.Errorf("pipeline %s step %d: timeout, retries and backoff must be non-negative", name, i+1))
+			}
+		}
+	}
+	for kind, mapping := range map[string]map[string]string{"audio": c.Pipeline.Tasks.Audio, "text": c.Pipeline.Tasks.Text} {
+		for task, name := range mapping {
+			if task != "translate" && task != "transcribe" {
+				errs = append(errs, fmt.Errorf("pipeline tasks.%s: unknown task %q", kind, task))
+			}
+			if strings.TrimSpace(name) == "" {
+				errs = append(errs, fmt.Errorf("pipeline tasks.%s.%s: pipeline name is required", kind, task))
+			}
+		}
+	}
+
 	if len(c.Backends.Providers) == 0 {
 		errs = append(errs, fmt.Errorf("no backend providers configured"))
 	}
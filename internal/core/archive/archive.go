@@ -0,0 +1,147 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+// Record is one archived request: the audio as received, the normalized WAV fed to ASR and the results.
+type Record struct {
+	RequestID       string                 `json:"request_id"`
+	CallerID        string                 `json:"caller_id,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	Task            string                 `json:"task"`
+	SourceLanguage  string                 `json:"source_language,omitempty"`
+	TargetLanguages []string               `json:"target_languages,omitempty"`
+	OriginalFormat  string                 `json:"original_format"`
+	Transcription   string                 `json:"transcription"`
+	ASRLanguage     string                 `json:"asr_language,omitempty"`
+	CorrectedText   string                 `json:"corrected_text,omitempty"`
+	Translations    map[string]string      `json:"translations,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+
+	OriginalAudio []byte `json:"-"`
+	NormalizedWAV []byte `json:"-"`
+}
+
+// Sink persists archive records.
+type Sink interface {
+	Write(ctx context.Context, rec *Record) error
+	Close() error
+}
+
+// NewSink builds the sink selected by cfg.Layout ("directory" by default, or "tar").
+func NewSink(cfg config.ArchiveConfig) (Sink, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Layout)) {
+	case "", "directory":
+		return NewDirectorySink(cfg.Path, retentionFromConfig(cfg))
+	case "tar":
+		return NewTarSink(cfg.Path, cfg.ShardMaxBytes, retentionFromConfig(cfg))
+	default:
+		return nil, fmt.Errorf("unsupported archive layout: %s", cfg.Layout)
+	}
+}
+
+// Pruner is implemented by sinks that can apply retention outside of Write.
+type Pruner interface {
+	Prune(now time.Time) error
+}
+
+// Archiver writes records to a Sink on a background goroutine so archival never delays responses.
+type Archiver struct {
+	sink          Sink
+	queue         chan *Record
+	pruneInterval time.Duration
+	logger        *logrus.Logger
+
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+}
+
+// NewArchiver starts a worker draining a queue of queueSize records (default 64) into sink.
+// When sink implements Pruner, the worker also applies retention every pruneInterval so an
+// idle server still expires old records; pruneInterval <= 0 limits pruning to writes.
+func NewArchiver(sink Sink, queueSize int, pruneInterval time.Duration, logger *logrus.Logger) *Archiver {
+	if queueSize <= 0 {
+		queueSize = 64
+	}
+	a := &Archiver{
+		sink:          sink,
+		queue:         make(chan *Record, queueSize),
+		pruneInterval: pruneInterval,
+		logger:        logger,
+	}
+	a.wg.Add(1)
+	go a.run()
+	return a
+}
+
+func (a *Archiver) run() {
+	defer a.wg.Done()
+
+	var tick <-chan time.Time
+	pruner, canPrune := a.sink.(Pruner)
+	if canPrune && a.pruneInterval > 0 {
+		ticker := time.NewTicker(a.pruneInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case rec, ok := <-a.queue:
+			if !ok {
+				return
+			}
+			if err := a.sink.Write(context.Background(), rec); err != nil {
+				a.logger.WithError(err).WithField("request_id", rec.RequestID).Warn("Failed to archive record")
+			}
+		case now := <-tick:
+			if err := pruner.Prune(now); err != nil {
+				a.logger.WithError(err).Warn("Failed to prune archive")
+			}
+		}
+	}
+}
+
+// Submit enqueues rec without blocking. It returns false when the queue is full or the archiver is closed.
+func (a *Archiver) Submit(rec *Record) bool {
+	if a == nil || rec == nil {
+		return false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return false
+	}
+	select {
+	case a.queue <- rec:
+		return true
+	default:
+		a.logger.WithField("request_id", rec.RequestID).Warn("Archive queue full, dropping record")
+		return false
+	}
+}
+
+// Close drains queued records and closes the sink.
+func (a *Archiver) Close() error {
+	var err error
+	a.closeOnce.Do(func() {
+		a.mu.Lock()
+		a.closed = true
+		close(a.queue)
+		a.mu.Unlock()
+
+		a.wg.Wait()
+		err = a.sink.Close()
+	})
+	return err
+}
@@ -0,0 +1,333 @@
+package archive
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+)
+
+func newTestRecord(id string, createdAt time.Time) *Record {
+	return &Record{
+		RequestID:      id,
+		CallerID:       "user-1",
+		CreatedAt:      createdAt,
+		Task:           "translate",
+		OriginalFormat: "opus",
+		Transcription:  "你好",
+		Translations:   map[string]string{"en": "Hello"},
+		Metadata:       map[string]interface{}{"pipeline": "translate_merged"},
+		OriginalAudio:  []byte("OggS original"),
+		NormalizedWAV:  []byte("RIFF normalized"),
+	}
+}
+
+func TestDirectorySink_WritesRecordAndManifest(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	sink, err := NewDirectorySink(root, Retention{})
+	if err != nil {
+		t.Fatalf("NewDirectorySink: %v", err)
+	}
+
+	createdAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	if err := sink.Write(context.Background(), newTestRecord("req/1", createdAt)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	entries := sink.Entries()
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Location, "2026-10-18/req_1_") {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	dir := filepath.Join(root, filepath.FromSlash(entries[0].Location))
+	for _, name := range []string{"original.opus", "normalized.wav", "result.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("missing %s: %v", name, err)
+		}
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "result.json"))
+	if err != nil {
+		t.Fatalf("read result.json: %v", err)
+	}
+	var result Record
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("unmarshal result.json: %v", err)
+	}
+	if result.Transcription != "你好" || result.Translations["en"] != "Hello" {
+		t.Fatalf("unexpected result.json: %s", raw)
+	}
+
+	reloaded, err := loadManifest(root)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+	if len(reloaded.entries) != 1 || reloaded.entries[0].Location != entries[0].Location ||
+		reloaded.entries[0].RequestID != "req/1" || len(reloaded.entries[0].Files) != 3 {
+		t.Fatalf("unexpected manifest: %+v", reloaded.entries)
+	}
+}
+
+func TestDirectorySink_Retention(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	sink, err := NewDirectorySink(root, Retention{MaxAge: 24 * time.Hour, MaxRecords: 2})
+	if err != nil {
+		t.Fatalf("NewDirectorySink: %v", err)
+	}
+
+	now := time.Now().UTC()
+	records := []*Record{
+		newTestRecord("expired", now.Add(-48*time.Hour)),
+		newTestRecord("a", now.Add(-3*time.Minute)),
+		newTestRecord("b", now.Add(-2*time.Minute)),
+		newTestRecord("c", now.Add(-1*time.Minute)),
+	}
+	for _, rec := range records {
+		if err := sink.Write(context.Background(), rec); err != nil {
+			t.Fatalf("Write(%s): %v", rec.RequestID, err)
+		}
+	}
+
+	var kept []string
+	for _, e := range sink.Entries() {
+		kept = append(kept, e.RequestID)
+	}
+	if len(kept) != 2 || kept[0] != "b" || kept[1] != "c" {
+		t.Fatalf("kept=%v want [b c]", kept)
+	}
+	if dirs, _ := filepath.Glob(filepath.Join(root, now.Add(-48*time.Hour).Format("2006-01-02"), "expired_*")); len(dirs) != 0 {
+		t.Fatalf("expected expired record to be removed, found %v", dirs)
+	}
+
+	reloaded, err := loadManifest(root)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+	if len(reloaded.entries) != 2 {
+		t.Fatalf("manifest has %d entries want 2", len(reloaded.entries))
+	}
+}
+
+func TestTarSink_ShardsAndRetention(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	// Tiny shards force a rotation after every record.
+	sink, err := NewTarSink(root, 1, Retention{MaxRecords: 2})
+	if err != nil {
+		t.Fatalf("NewTarSink: %v", err)
+	}
+
+	now := time.Now().UTC()
+	for i, id := range []string{"a", "b", "c"} {
+		if err := sink.Write(context.Background(), newTestRecord(id, now.Add(time.Duration(i)*time.Second))); err != nil {
+			t.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	entries := sink.Entries()
+	if len(entries) != 2 || entries[0].RequestID != "b" || entries[1].RequestID != "c" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	shards, _ := filepath.Glob(filepath.Join(root, "shard-*.tar"))
+	if len(shards) != 2 {
+		t.Fatalf("shards=%v want 2", shards)
+	}
+
+	f, err := os.Open(filepath.Join(root, entries[1].Location))
+	if err != nil {
+		t.Fatalf("open shard: %v", err)
+	}
+	defer f.Close()
+	var names []string
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read shard: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+	sort.Strings(names)
+	want := []string{"normalized.wav", "original.opus", "result.json"}
+	if len(names) != len(want) {
+		t.Fatalf("shard entries=%v want %v", names, want)
+	}
+	for i, name := range names {
+		dir, file := path.Split(name)
+		if !strings.HasPrefix(dir, "c_") || file != want[i] {
+			t.Fatalf("shard entries=%v want c_<suffix>/%v", names, want)
+		}
+	}
+}
+
+func TestTarSink_PruneExpiresIdleActiveShard(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	sink, err := NewTarSink(root, 0, Retention{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("NewTarSink: %v", err)
+	}
+	now := time.Now().UTC()
+	// The record lands in the open shard, which Write never removes.
+	if err := sink.Write(context.Background(), newTestRecord("old", now.Add(-2*time.Hour))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(sink.Entries()) != 1 {
+		t.Fatalf("entries=%+v want the record kept by Write", sink.Entries())
+	}
+
+	if err := sink.Prune(now); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if len(sink.Entries()) != 0 {
+		t.Fatalf("entries=%+v want expired active shard pruned", sink.Entries())
+	}
+	if shards, _ := filepath.Glob(filepath.Join(root, "shard-*.tar")); len(shards) != 0 {
+		t.Fatalf("shards=%v want none", shards)
+	}
+
+	// The next write opens a new shard; a shard with a live record survives Prune.
+	if err := sink.Write(context.Background(), newTestRecord("new", now)); err != nil {
+		t.Fatalf("Write after prune: %v", err)
+	}
+	if err := sink.Prune(now); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if entries := sink.Entries(); len(entries) != 1 || entries[0].RequestID != "new" {
+		t.Fatalf("entries=%+v want [new]", entries)
+	}
+	if shards, _ := filepath.Glob(filepath.Join(root, "shard-*.tar")); len(shards) != 1 {
+		t.Fatalf("shards=%v want 1", shards)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestDirectorySink_ReusedRequestID(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	sink, err := NewDirectorySink(root, Retention{})
+	if err != nil {
+		t.Fatalf("NewDirectorySink: %v", err)
+	}
+
+	createdAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	first := newTestRecord("dup", createdAt)
+	second := newTestRecord("dup", createdAt)
+	second.Transcription = "second"
+	for _, rec := range []*Record{first, second} {
+		if err := sink.Write(context.Background(), rec); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	entries := sink.Entries()
+	if len(entries) != 2 || entries[0].Location == entries[1].Location {
+		t.Fatalf("expected two distinct locations, got %+v", entries)
+	}
+	for i, want := range []string{"你好", "second"} {
+		if entries[i].RequestID != "dup" {
+			t.Fatalf("entry %d request_id=%q want dup", i, entries[i].RequestID)
+		}
+		raw, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(entries[i].Location), "result.json"))
+		if err != nil {
+			t.Fatalf("read result.json: %v", err)
+		}
+		var result Record
+		if err := json.Unmarshal(raw, &result); err != nil {
+			t.Fatalf("unmarshal result.json: %v", err)
+		}
+		if result.Transcription != want {
+			t.Fatalf("entry %d transcription=%q want %q", i, result.Transcription, want)
+		}
+	}
+}
+
+func TestArchiver_PrunesWhileIdle(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	sink, err := NewDirectorySink(root, Retention{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("NewDirectorySink: %v", err)
+	}
+	// Written just inside the limit, so Write keeps it and only a later prune can expire it.
+	if err := sink.Write(context.Background(), newTestRecord("old", time.Now().UTC().Add(-time.Hour+50*time.Millisecond))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(sink.Entries()) != 1 {
+		t.Fatalf("record should survive the write-time prune")
+	}
+
+	a := NewArchiver(sink, 4, 10*time.Millisecond, testutil.NewTestLogger())
+	defer a.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(sink.Entries()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expired record was not pruned: %+v", sink.Entries())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	m, err := loadManifest(root)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+	if len(m.entries) != 0 {
+		t.Fatalf("manifest still has %d entries", len(m.entries))
+	}
+}
+
+func TestArchiver_DrainsOnClose(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	sink, err := NewSink(config.ArchiveConfig{Layout: "directory", Path: root})
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	a := NewArchiver(sink, 4, 0, testutil.NewTestLogger())
+
+	if !a.Submit(newTestRecord("queued", time.Now().UTC())) {
+		t.Fatalf("Submit returned false")
+	}
+	if err := a.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if a.Submit(newTestRecord("late", time.Now().UTC())) {
+		t.Fatalf("Submit after Close should fail")
+	}
+
+	m, err := loadManifest(root)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+	if len(m.entries) != 1 || m.entries[0].RequestID != "queued" {
+		t.Fatalf("unexpected manifest entries: %+v", m.entries)
+	}
+}
@@ -0,0 +1,141 @@
+package archive
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type recordFile struct {
+	name string
+	data []byte
+}
+
+// recordFiles lays out a record as original.<format>, normalized.wav and result.json.
+func recordFiles(rec *Record) ([]recordFile, error) {
+	result, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode result: %w", err)
+	}
+
+	var files []recordFile
+	if len(rec.OriginalAudio) > 0 {
+		ext := safeName(rec.OriginalFormat)
+		if rec.OriginalFormat == "" {
+			ext = "bin"
+		}
+		files = append(files, recordFile{name: "original." + ext, data: rec.OriginalAudio})
+	}
+	if len(rec.NormalizedWAV) > 0 {
+		files = append(files, recordFile{name: "normalized.wav", data: rec.NormalizedWAV})
+	}
+	files = append(files, recordFile{name: "result.json", data: result})
+	return files, nil
+}
+
+func newManifestEntry(rec *Record, location string, files []recordFile) ManifestEntry {
+	entry := ManifestEntry{
+		RequestID: rec.RequestID,
+		CallerID:  rec.CallerID,
+		Task:      rec.Task,
+		CreatedAt: rec.CreatedAt,
+		Location:  location,
+	}
+	for _, f := range files {
+		entry.Files = append(entry.Files, f.name)
+		entry.Bytes += int64(len(f.data))
+	}
+	return entry
+}
+
+// recordID names a record's directory (or tar prefix). Request IDs come from clients and may repeat,
+// so a random suffix keeps records apart; the original request ID is kept in the manifest.
+func recordID(rec *Record) string {
+	base := "rec"
+	if rec.RequestID != "" {
+		base = safeName(rec.RequestID)
+	}
+	var raw [4]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return fmt.Sprintf("%s_%d", base, time.Now().UnixNano())
+	}
+	return base + "_" + hex.EncodeToString(raw[:])
+}
+
+// DirectorySink writes each record to <root>/<YYYY-MM-DD>/<request_id>_<suffix>/.
+type DirectorySink struct {
+	root      string
+	retention Retention
+
+	mu       sync.Mutex
+	manifest *manifest
+}
+
+// NewDirectorySink opens (or creates) a directory archive rooted at root.
+func NewDirectorySink(root string, retention Retention) (*DirectorySink, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create archive root: %w", err)
+	}
+	m, err := loadManifest(root)
+	if err != nil {
+		return nil, err
+	}
+	return &DirectorySink{root: root, retention: retention, manifest: m}, nil
+}
+
+func (s *DirectorySink) Write(_ context.Context, rec *Record) error {
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = time.Now().UTC()
+	}
+	files, err := recordFiles(rec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	location := path.Join(rec.CreatedAt.UTC().Format("2006-01-02"), recordID(rec))
+	dir := filepath.Join(s.root, filepath.FromSlash(location))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create record dir: %w", err)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.name), f.data, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", f.name, err)
+		}
+	}
+	if err := s.manifest.append(newManifestEntry(rec, location, files)); err != nil {
+		return err
+	}
+	return s.manifest.prune(s.retention, time.Now(), location, s.removeLocation)
+}
+
+// Prune applies the retention limits without writing a record.
+func (s *DirectorySink) Prune(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.manifest.prune(s.retention, now, "", s.removeLocation)
+}
+
+func (s *DirectorySink) removeLocation(loc string) error {
+	return os.RemoveAll(filepath.Join(s.root, filepath.FromSlash(loc)))
+}
+
+// Entries returns a copy of the manifest index.
+func (s *DirectorySink) Entries() []ManifestEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ManifestEntry(nil), s.manifest.entries...)
+}
+
+func (s *DirectorySink) Close() error {
+	return nil
+}
@@ -0,0 +1,6 @@
+// Package archive stores opted-in audio requests and their results for dataset building.
+//
+// Records are written either as one directory per request or appended to rotating tar shards.
+// Both layouts keep a manifest.jsonl index at the archive root and enforce age, size and
+// record-count retention limits.
+package archive
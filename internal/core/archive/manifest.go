@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+)
+
+const manifestFileName = "manifest.jsonl"
+
+// ManifestEntry indexes one archived record.
+type ManifestEntry struct {
+	RequestID string    `json:"request_id"`
+	CallerID  string    `json:"caller_id,omitempty"`
+	Task      string    `json:"task"`
+	CreatedAt time.Time `json:"created_at"`
+	// Location is the record directory (directory layout) or shard file (tar layout), relative to the root.
+	Location string   `json:"location"`
+	Files    []string `json:"files"`
+	Bytes    int64    `json:"bytes"`
+}
+
+// Retention bounds the archive; zero values disable the corresponding limit.
+type Retention struct {
+	MaxAge     time.Duration
+	MaxBytes   int64
+	MaxRecords int
+}
+
+func retentionFromConfig(cfg config.ArchiveConfig) Retention {
+	return Retention{MaxAge: cfg.MaxAge, MaxBytes: cfg.MaxBytes, MaxRecords: cfg.MaxRecords}
+}
+
+// manifest is the in-memory copy of manifest.jsonl. Callers serialize access.
+type manifest struct {
+	path    string
+	entries []ManifestEntry
+}
+
+func loadManifest(root string) (*manifest, error) {
+	m := &manifest{path: filepath.Join(root, manifestFileName)}
+	f, err := os.Open(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry ManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// Skip a torn trailing line from an interrupted write.
+			continue
+		}
+		m.entries = append(m.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	return m, nil
+}
+
+func (m *manifest) append(entry ManifestEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("append manifest: %w", err)
+	}
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+// rewrite atomically replaces manifest.jsonl with the current entries.
+func (m *manifest) rewrite() error {
+	tmp := m.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("create manifest: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, entry := range m.entries {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.path)
+}
+
+// enforce removes whole locations, oldest first, until the retention limits hold.
+// Locations are removed as a unit (a record directory or a tar shard); active is never removed.
+// remove deletes a location from disk. It reports whether the manifest changed.
+func (m *manifest) enforce(r Retention, now time.Time, active string, remove func(location string) error) (bool, error) {
+	type unit struct {
+		location string
+		newest   time.Time
+		bytes    int64
+		records  int
+	}
+	var order []string
+	units := make(map[string]*unit)
+	var totalBytes int64
+	for _, e := range m.entries {
+		u, ok := units[e.Location]
+		if !ok {
+			u = &unit{location: e.Location}
+			units[e.Location] = u
+			order = append(order, e.Location)
+		}
+		if e.CreatedAt.After(u.newest) {
+			u.newest = e.CreatedAt
+		}
+		u.bytes += e.Bytes
+		u.records++
+		totalBytes += e.Bytes
+	}
+
+	totalRecords := len(m.entries)
+	removed := make(map[string]bool)
+	for _, loc := range order {
+		u := units[loc]
+		if loc == active {
+			continue
+		}
+		expired := r.MaxAge > 0 && now.Sub(u.newest) > r.MaxAge
+		overBytes := r.MaxBytes > 0 && totalBytes > r.MaxBytes
+		overRecords := r.MaxRecords > 0 && totalRecords > r.MaxRecords
+		if !expired && !overBytes && !overRecords {
+			continue
+		}
+		if err := remove(loc); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return len(removed) > 0, fmt.Errorf("remove %s: %w", loc, err)
+		}
+		removed[loc] = true
+		totalBytes -= u.bytes
+		totalRecords -= u.records
+	}
+	if len(removed) == 0 {
+		return false, nil
+	}
+
+	kept := m.entries[:0]
+	for _, e := range m.entries {
+		if !removed[e.Location] {
+			kept = append(kept, e)
+		}
+	}
+	m.entries = kept
+	return true, nil
+}
+
+// prune enforces r and rewrites manifest.jsonl when entries were removed.
+func (m *manifest) prune(r Retention, now time.Time, active string, remove func(location string) error) error {
+	changed, err := m.enforce(r, now, active, remove)
+	if changed {
+		if rewriteErr := m.rewrite(); rewriteErr != nil && err == nil {
+			err = rewriteErr
+		}
+	}
+	return err
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// safeName makes s usable as a single path element.
+func safeName(s string) string {
+	s = unsafeNameChars.ReplaceAllString(s, "_")
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+	return s
+}
@@ -0,0 +1,171 @@
+package archive
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const defaultShardMaxBytes = 256 * 1024 * 1024
+
+// TarSink appends records to rotating tar shards (<root>/shard-<timestamp>-<seq>.tar).
+// Each record is stored under <request_id>_<suffix>/ inside the shard. A new shard is started
+// on open and whenever the current one exceeds shardMaxBytes; retention removes whole shards.
+type TarSink struct {
+	root          string
+	shardMaxBytes int64
+	retention     Retention
+
+	mu         sync.Mutex
+	manifest   *manifest
+	file       *os.File
+	writer     *tar.Writer
+	shardName  string
+	shardBytes int64
+	seq        int
+}
+
+// NewTarSink opens (or creates) a tar-sharded archive rooted at root.
+func NewTarSink(root string, shardMaxBytes int64, retention Retention) (*TarSink, error) {
+	if shardMaxBytes <= 0 {
+		shardMaxBytes = defaultShardMaxBytes
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create archive root: %w", err)
+	}
+	m, err := loadManifest(root)
+	if err != nil {
+		return nil, err
+	}
+	return &TarSink{root: root, shardMaxBytes: shardMaxBytes, retention: retention, manifest: m}, nil
+}
+
+func (s *TarSink) Write(_ context.Context, rec *Record) error {
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = time.Now().UTC()
+	}
+	files, err := recordFiles(rec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer == nil || s.shardBytes >= s.shardMaxBytes {
+		if err := s.rotateLocked(); err != nil {
+			return err
+		}
+	}
+
+	prefix := recordID(rec)
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    path.Join(prefix, f.name),
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: rec.CreatedAt,
+		}
+		if err := s.writer.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("write tar header: %w", err)
+		}
+		if _, err := s.writer.Write(f.data); err != nil {
+			return fmt.Errorf("write tar entry: %w", err)
+		}
+		s.shardBytes += int64(len(f.data))
+	}
+	// Flush pads the entry without writing the end-of-archive trailer, so the shard stays appendable.
+	if err := s.writer.Flush(); err != nil {
+		return fmt.Errorf("flush tar shard: %w", err)
+	}
+
+	if err := s.manifest.append(newManifestEntry(rec, s.shardName, files)); err != nil {
+		return err
+	}
+	return s.manifest.prune(s.retention, time.Now(), s.shardName, s.removeShard)
+}
+
+// Prune applies the retention limits without writing a record. The open shard is kept unless
+// every record in it has expired; it is then closed so it can be removed and the next write
+// starts a new shard.
+func (s *TarSink) Prune(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writer != nil && s.activeShardExpiredLocked(now) {
+		if err := s.closeShardLocked(); err != nil {
+			return err
+		}
+		s.shardName = ""
+	}
+	return s.manifest.prune(s.retention, now, s.shardName, s.removeShard)
+}
+
+func (s *TarSink) activeShardExpiredLocked(now time.Time) bool {
+	if s.retention.MaxAge <= 0 {
+		return false
+	}
+	found := false
+	for _, e := range s.manifest.entries {
+		if e.Location != s.shardName {
+			continue
+		}
+		if now.Sub(e.CreatedAt) <= s.retention.MaxAge {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+func (s *TarSink) removeShard(loc string) error {
+	return os.Remove(filepath.Join(s.root, loc))
+}
+
+func (s *TarSink) rotateLocked() error {
+	if err := s.closeShardLocked(); err != nil {
+		return err
+	}
+	s.seq++
+	name := fmt.Sprintf("shard-%s-%04d.tar", time.Now().UTC().Format("20060102T150405.000000000"), s.seq)
+	f, err := os.OpenFile(filepath.Join(s.root, name), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("create tar shard: %w", err)
+	}
+	s.file = f
+	s.writer = tar.NewWriter(f)
+	s.shardName = name
+	s.shardBytes = 0
+	return nil
+}
+
+func (s *TarSink) closeShardLocked() error {
+	if s.writer == nil {
+		return nil
+	}
+	err := s.writer.Close()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	s.writer = nil
+	s.file = nil
+	return err
+}
+
+// Entries returns a copy of the manifest index.
+func (s *TarSink) Entries() []ManifestEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ManifestEntry(nil), s.manifest.entries...)
+}
+
+// Close finalizes the current shard.
+func (s *TarSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closeShardLocked()
+}
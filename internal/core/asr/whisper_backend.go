@@ -15,7 +15,10 @@ import (
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // WhisperBackend implements an OpenAI Whisper compatible API:
@@ -77,7 +80,21 @@ func (w *WhisperBackend) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// Transcribe sends req to the transcriptions endpoint inside a client span.
 func (w *WhisperBackend) Transcribe(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	ctx, span := tracing.Start(ctx, "asr.transcribe", trace.SpanKindClient,
+		attribute.String("asr.backend", w.name),
+		attribute.String("asr.model", w.model),
+	)
+	if req != nil {
+		span.SetAttributes(attribute.Int("asr.audio_bytes", len(req.Audio)))
+	}
+	resp, err := w.transcribe(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (w *WhisperBackend) transcribe(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
 	if req == nil {
 		return nil, fmt.Errorf("nil request")
 	}
@@ -143,6 +160,7 @@ func (w *WhisperBackend) Transcribe(ctx context.Context, req *ASRRequest) (*ASRR
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	tracing.Inject(ctx, httpReq.Header)
 	if w.apiKey != "" {
 		httpReq.Header.Set("Authorization", "Bearer "+w.apiKey)
 	}
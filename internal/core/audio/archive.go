@@ -0,0 +1,75 @@
+// archive.go hands opted-in requests and their results to the archival sink.
+package audio
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/archive"
+)
+
+// MetadataArchiveOptIn is the identity metadata key that opts a caller into archival.
+const MetadataArchiveOptIn = "archive_opt_in"
+
+// ArchiveOptedIn reports whether identity metadata opts the caller into archival.
+func ArchiveOptedIn(metadata map[string]interface{}) bool {
+	return truthy(metadata[MetadataArchiveOptIn])
+}
+
+func truthy(v interface{}) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		return strings.EqualFold(strings.TrimSpace(b), "true")
+	default:
+		return false
+	}
+}
+
+// WithArchiver enables archival of requests from opted-in callers or with options.archive=true.
+func (p *Processor) WithArchiver(a *archive.Archiver) *Processor {
+	p.archiver = a
+	return p
+}
+
+func (p *Processor) shouldArchive(req ProcessRequest) bool {
+	return p.archiver != nil && (req.ArchiveOptIn || truthy(req.Options["archive"]))
+}
+
+// archiveResult submits the request and response to the archiver. original must be a copy
+// taken before conversion, since the request buffer returns to the pool afterwards.
+func (p *Processor) archiveResult(requestID string, req ProcessRequest, original, normalized []byte, resp *ProcessResponse) {
+	if requestID == "" {
+		requestID = resp.RequestID
+	}
+	rec := &archive.Record{
+		RequestID:       requestID,
+		CallerID:        req.CallerID,
+		CreatedAt:       time.Now().UTC(),
+		Task:            string(req.Task),
+		SourceLanguage:  req.SourceLanguage,
+		TargetLanguages: append([]string(nil), req.TargetLanguages...),
+		OriginalFormat:  req.AudioFormat,
+		Transcription:   resp.Transcription,
+		CorrectedText:   resp.CorrectedText,
+		Translations:    make(map[string]string, len(resp.Translations)),
+		Metadata:        make(map[string]interface{}, len(resp.Metadata)),
+		OriginalAudio:   original,
+		NormalizedWAV:   bytes.Clone(normalized),
+	}
+	// The response is pooled and released after it is written, so copy what the sink needs.
+	for k, v := range resp.Translations {
+		rec.Translations[k] = v
+	}
+	for k, v := range resp.Metadata {
+		rec.Metadata[k] = v
+	}
+	rec.ASRLanguage, _ = resp.Metadata["asr_language"].(string)
+
+	if !p.archiver.Submit(rec) {
+		return
+	}
+	resp.Metadata["archived"] = true
+}
@@ -1,6 +1,10 @@
 package audio
 
-import "sync"
+import (
+	"errors"
+	"io"
+	"sync"
+)
 
 const (
 	defaultAudioBufferCap = 1024 * 1024      // 1MB
@@ -8,6 +12,9 @@ const (
 	maxAudioSizeBytes     = 32 * 1024 * 1024 // 32MB (request limit)
 )
 
+// ErrAudioTooLarge is returned when streamed audio exceeds the allowed size.
+var ErrAudioTooLarge = errors.New("audio size exceeds maximum allowed size")
+
 var audioBufferPool = sync.Pool{
 	New: func() any {
 		buf := make([]byte, 0, defaultAudioBufferCap)
@@ -44,3 +51,57 @@ func ReleaseAudioBuffer(buf []byte) {
 	}
 	audioBufferPool.Put(buf[:0])
 }
+
+// ReadAudioBuffer streams r into a pooled buffer, failing as soon as more than maxSize bytes arrive.
+// sizeHint (e.g. Content-Length, -1 if unknown) is used to presize the buffer and to reject oversized
+// uploads before reading. maxSize <= 0 falls back to the default request limit.
+// On success the caller owns the buffer and should return it with ReleaseAudioBuffer.
+func ReadAudioBuffer(r io.Reader, sizeHint int64, maxSize int) ([]byte, error) {
+	if maxSize <= 0 {
+		maxSize = maxAudioSizeBytes
+	}
+	if sizeHint > int64(maxSize) {
+		return nil, ErrAudioTooLarge
+	}
+
+	initial := defaultAudioBufferCap
+	if sizeHint > 0 {
+		initial = int(sizeHint)
+	}
+	if initial > maxSize {
+		initial = maxSize
+	}
+
+	// Read at most one byte past the limit so oversize input is detected without draining it.
+	limited := io.LimitReader(r, int64(maxSize)+1)
+	buf := AcquireAudioBuffer(initial)[:0]
+	for {
+		if len(buf) == cap(buf) {
+			newCap := cap(buf) * 2
+			if newCap == 0 {
+				newCap = defaultAudioBufferCap
+			}
+			if newCap > maxSize+1 {
+				newCap = maxSize + 1
+			}
+			grown := AcquireAudioBuffer(newCap)[:len(buf)]
+			copy(grown, buf)
+			ReleaseAudioBuffer(buf)
+			buf = grown
+		}
+
+		n, err := limited.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if len(buf) > maxSize {
+			ReleaseAudioBuffer(buf)
+			return nil, ErrAudioTooLarge
+		}
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			ReleaseAudioBuffer(buf)
+			return nil, err
+		}
+	}
+}
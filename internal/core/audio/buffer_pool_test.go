@@ -0,0 +1,52 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadAudioBuffer_ReadsWithinLimit(t *testing.T) {
+	t.Parallel()
+
+	input := bytes.Repeat([]byte{0xAB}, 3*defaultAudioBufferCap+17)
+	buf, err := ReadAudioBuffer(bytes.NewReader(input), -1, len(input))
+	if err != nil {
+		t.Fatalf("ReadAudioBuffer: %v", err)
+	}
+	defer ReleaseAudioBuffer(buf)
+
+	if !bytes.Equal(buf, input) {
+		t.Fatalf("read %d bytes, want %d identical bytes", len(buf), len(input))
+	}
+}
+
+func TestReadAudioBuffer_EnforcesLimitWhileStreaming(t *testing.T) {
+	t.Parallel()
+
+	// Unknown length: the limit must trip during the read.
+	if _, err := ReadAudioBuffer(strings.NewReader(strings.Repeat("x", 101)), -1, 100); !errors.Is(err, ErrAudioTooLarge) {
+		t.Fatalf("err=%v want ErrAudioTooLarge", err)
+	}
+
+	// Declared length over the limit is rejected before reading.
+	r := &countingReader{r: strings.NewReader("small")}
+	if _, err := ReadAudioBuffer(r, 1000, 100); !errors.Is(err, ErrAudioTooLarge) {
+		t.Fatalf("err=%v want ErrAudioTooLarge", err)
+	}
+	if r.n != 0 {
+		t.Fatalf("read %d bytes, want 0 for oversized Content-Length", r.n)
+	}
+}
+
+type countingReader struct {
+	r *strings.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
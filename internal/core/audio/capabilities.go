@@ -1,6 +1,8 @@
 // capabilities.go exposes supported formats, languages, and feature flags.
 package audio
 
+import "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/cache"
+
 // GetSupportedFormats 获取支持的音频格式
 func (p *Processor) GetSupportedFormats() []string {
 	return p.audioConverter.GetSupportedFormats()
@@ -49,8 +51,15 @@ func (p *Processor) GetCapabilities() map[string]interface{} {
 
 	capabilities := map[string]interface{}{
 		"supported_formats":   p.GetSupportedFormats(),
-		"max_audio_size":      32 * 1024 * 1024, // 32MB
+		"max_audio_size":      p.limits.MaxSizeBytes,
+		"max_audio_duration":  p.limits.MaxDuration.Seconds(),
+		"allowed_formats":     p.limits.AllowedFormats,
+		"output_sample_rate":  p.audioConverter.OutputSampleRate(),
+		"audio_profiles":      p.ProfileNames(),
+		"default_profile":     p.defaultProfile,
+		"archive_enabled":     p.archiver != nil,
 		"supported_tasks":     []string{"translate", "transcribe"},
+		"pipelines":           p.PipelineNames(),
 		"supported_languages": languageCodes,
 		"audio_conversion":    p.audioConverter.IsFFmpegAvailable(),
 	}
@@ -59,5 +68,11 @@ func (p *Processor) GetCapabilities() map[string]interface{} {
 	converterMetrics := p.audioConverter.GetMetrics()
 	capabilities["conversion_metrics"] = converterMetrics
 
+	if statsProvider, ok := p.asrCache.(interface {
+		Stats() cache.TranscriptionCacheStats
+	}); ok {
+		capabilities["asr_cache"] = statsProvider.Stats()
+	}
+
 	return capabilities
 }
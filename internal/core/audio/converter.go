@@ -1,11 +1,14 @@
 package audio
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +17,10 @@ type AudioConverter struct {
 	logger            *logrus.Logger
 	concurrencyLimit  int              // 并发转换限制
 	conversionTimeout time.Duration    // 转换超时时间
+	queueTimeout      time.Duration    // 等待转换槽位的超时时间
+	outputSampleRate  int              // 输出 WAV 的采样率
+	maxSizeBytes      int              // 输入音频大小上限
+	maxOutputSamples  int              // 纯 Go 转换输出的采样数上限
 	semaphore         chan struct{}    // 并发控制信号量
 	ffmpegAvailable   bool             // ffmpeg可用性缓存
 	ffmpegCheckOnce   sync.Once        // 只检查一次ffmpeg
@@ -40,44 +47,113 @@ type ConversionMetrics struct {
 	Format         string        `json:"format"`
 }
 
-// NewAudioConverter creates a new AudioConverter.
+// ErrConversionCanceled matches (via errors.Is) conversions aborted because the request context ended.
+var ErrConversionCanceled = errors.New("audio conversion canceled")
+
+// ConversionCanceledError reports a conversion aborted by request cancellation,
+// as opposed to a failed conversion. It matches both ErrConversionCanceled and the context error.
+type ConversionCanceledError struct {
+	Stage string // "queue" (waiting for a slot) or "ffmpeg"
+	Cause error  // ctx.Err()
+}
+
+func (e *ConversionCanceledError) Error() string {
+	return fmt.Sprintf("audio conversion canceled while %s: %v", e.stageDescription(), e.Cause)
+}
+
+func (e *ConversionCanceledError) Unwrap() []error {
+	return []error{ErrConversionCanceled, e.Cause}
+}
+
+func (e *ConversionCanceledError) stageDescription() string {
+	if e.Stage == "queue" {
+		return "waiting for a conversion slot"
+	}
+	return "running " + e.Stage
+}
+
+// NewAudioConverter creates a new AudioConverter with the default audio settings.
 func NewAudioConverter(logger *logrus.Logger) *AudioConverter {
-	// 根据系统资源设置合理的并发限制
-	concurrencyLimit := 4 // 默认4个并发转换
-	if limit := getConcurrencyLimit(); limit > 0 {
-		concurrencyLimit = limit
+	return NewAudioConverterWithConfig(logger, config.AudioConfig{})
+}
+
+// NewAudioConverterWithConfig creates an AudioConverter using the audio config section.
+// Zero values fall back to the defaults (4 concurrent conversions, 30s queue timeout, 16kHz output, 32MB input).
+func NewAudioConverterWithConfig(logger *logrus.Logger, cfg config.AudioConfig) *AudioConverter {
+	concurrencyLimit := cfg.ConverterConcurrency
+	if concurrencyLimit <= 0 {
+		concurrencyLimit = 4
+	}
+	queueTimeout := cfg.QueueTimeout
+	if queueTimeout <= 0 {
+		queueTimeout = 30 * time.Second
+	}
+	outputSampleRate := cfg.OutputSampleRate
+	if outputSampleRate <= 0 {
+		outputSampleRate = asrSampleRate
+	}
+	maxSizeBytes := cfg.MaxSizeBytes
+	if maxSizeBytes <= 0 {
+		maxSizeBytes = maxAudioSizeBytes
 	}
 
 	return &AudioConverter{
 		logger:            logger,
 		concurrencyLimit:  concurrencyLimit,
 		conversionTimeout: 60 * time.Second, // 增加到60秒
+		queueTimeout:      queueTimeout,
+		outputSampleRate:  outputSampleRate,
+		maxSizeBytes:      maxSizeBytes,
+		maxOutputSamples:  maxOutputSamples(maxSizeBytes, cfg.MaxDuration, outputSampleRate),
 		semaphore:         make(chan struct{}, concurrencyLimit),
 		conversionStats:   &ConversionStats{},
 	}
 }
 
-// getConcurrencyLimit returns a conversion concurrency limit.
-func getConcurrencyLimit() int {
-	// 可以根据CPU核心数、内存等来动态设置
-	// 这里简化为固定值，实际部署时可以通过环境变量配置
-	return 4
+// OutputSampleRate returns the sample rate of converted WAV output.
+func (c *AudioConverter) OutputSampleRate() int {
+	return c.outputSampleRate
+}
+
+// ConvertToWAV converts audio to mono PCM16 WAV at the configured output sample rate.
+// WAV input is normalized in pure Go; other formats require ffmpeg.
+// Cancelling ctx stops waiting for a conversion slot and kills a running ffmpeg;
+// the returned error is then a *ConversionCanceledError.
+func (c *AudioConverter) ConvertToWAV(ctx context.Context, inputData []byte, inputFormat string) ([]byte, error) {
+	return c.ConvertToWAVWithFilters(ctx, inputData, inputFormat, "")
 }
 
-// ConvertToWAV converts audio to WAV (16kHz, mono) via ffmpeg when needed.
-func (c *AudioConverter) ConvertToWAV(inputData []byte, inputFormat string) ([]byte, error) {
+// ConvertToWAVWithFilters is ConvertToWAV with an ffmpeg -af filter chain (see AudioProfile)
+// applied during conversion. A non-empty chain always goes through ffmpeg, WAV included.
+func (c *AudioConverter) ConvertToWAVWithFilters(ctx context.Context, inputData []byte, inputFormat, filters string) ([]byte, error) {
 	startTime := time.Now()
 
-	// 如果已经是WAV格式，直接返回
-	if strings.ToLower(inputFormat) == "wav" {
-		c.recordConversion(len(inputData), len(inputData), time.Since(startTime), true, inputFormat)
-		return inputData, nil
+	// WAV 直接在 Go 中重采样/降为单声道，无需 ffmpeg
+	if strings.ToLower(inputFormat) == "wav" && filters == "" {
+		result, _, err := normalizeWAV(inputData, c.outputSampleRate, c.maxOutputSamples)
+		if err == nil {
+			c.recordConversion(len(inputData), len(result), time.Since(startTime), true, inputFormat)
+			return result, nil
+		}
+		// 采样率/声道越界的 WAV 同样不交给 ffmpeg，以免放大成超大输出
+		if errors.Is(err, errWAVLayout) || !c.IsFFmpegAvailable() {
+			c.recordConversion(len(inputData), 0, time.Since(startTime), false, inputFormat)
+			return nil, fmt.Errorf("normalize wav: %w", err)
+		}
+		// 不支持的 WAV 编码（如 ADPCM、μ-law）交给 ffmpeg 处理
+		c.logger.WithError(err).Debug("Pure-Go WAV normalization failed, falling back to ffmpeg")
+	}
+
+	if IsRawPCMFormat(inputFormat) {
+		c.recordConversion(len(inputData), 0, time.Since(startTime), false, inputFormat)
+		return nil, fmt.Errorf("raw %s audio requires sample_rate and channels", inputFormat)
 	}
 
 	c.logger.WithFields(logrus.Fields{
 		"input_format": inputFormat,
 		"input_size":   len(inputData),
 		"queue_length": len(c.semaphore),
+		"filters":      filters,
 	}).Info("Starting audio conversion")
 
 	// 检查ffmpeg是否可用
@@ -90,13 +166,19 @@ func (c *AudioConverter) ConvertToWAV(inputData []byte, inputFormat string) ([]b
 	select {
 	case c.semaphore <- struct{}{}:
 		defer func() { <-c.semaphore }()
-	case <-time.After(30 * time.Second):
+	case <-time.After(c.queueTimeout):
 		c.recordConversion(len(inputData), 0, time.Since(startTime), false, inputFormat)
 		return nil, fmt.Errorf("conversion queue timeout, too many concurrent conversions")
+	case <-ctx.Done():
+		// 请求已取消，不再占用排队位置；取消不计入失败统计
+		return nil, &ConversionCanceledError{Stage: "queue", Cause: ctx.Err()}
 	}
 
 	// 执行转换
-	result, err := c.convertWithFFmpegOptimized(inputData, inputFormat)
+	result, err := c.convertWithFFmpegOptimized(ctx, inputData, inputFormat, filters)
+	if errors.Is(err, ErrConversionCanceled) {
+		return nil, err
+	}
 
 	// 记录统计信息
 	success := err == nil
@@ -109,24 +191,49 @@ func (c *AudioConverter) ConvertToWAV(inputData []byte, inputFormat string) ([]b
 	return result, err
 }
 
+// ConvertPCMToWAV wraps headerless PCM into a mono PCM16 WAV at the output sample rate without ffmpeg.
+func (c *AudioConverter) ConvertPCMToWAV(inputData []byte, inputFormat string, sampleRate, channels int) ([]byte, error) {
+	startTime := time.Now()
+
+	result, err := pcmToWAV(inputData, strings.ToLower(inputFormat), sampleRate, channels, c.outputSampleRate, c.maxOutputSamples)
+	outputSize := 0
+	if result != nil {
+		outputSize = len(result)
+	}
+	c.recordConversion(len(inputData), outputSize, time.Since(startTime), err == nil, inputFormat)
+	if err != nil {
+		return nil, fmt.Errorf("convert raw pcm: %w", err)
+	}
+	return result, nil
+}
+
 // GetSupportedFormats returns formats the converter can validate/handle.
 func (c *AudioConverter) GetSupportedFormats() []string {
-	baseFormats := []string{"wav", "mp3", "m4a", "flac"}
+	baseFormats := []string{"wav", "mp3", "m4a", "flac", FormatPCMS16LE, FormatPCMF32LE}
 
 	if c.IsFFmpegAvailable() {
 		// ffmpeg可用时支持更多格式
 		return []string{
 			"wav", "mp3", "m4a", "flac", "opus",
-			"aac", "wma", "ogg", "amr", "3gp",
+			"aac", "wma", "ogg", "amr", "3gp", "webm",
+			FormatPCMS16LE, FormatPCMF32LE,
 		}
 	}
 
 	return baseFormats
 }
 
-// IsConversionNeeded returns whether input must be converted to WAV.
-func (c *AudioConverter) IsConversionNeeded(format string) bool {
-	return strings.ToLower(format) != "wav"
+// IsConversionNeeded returns whether input must be converted to mono PCM16 WAV at the output sample rate.
+func (c *AudioConverter) IsConversionNeeded(data []byte, format string) bool {
+	if strings.ToLower(format) != "wav" {
+		return true
+	}
+	info, err := parseWAV(data)
+	if err != nil {
+		// Let ConvertToWAV decide whether ffmpeg can handle it.
+		return true
+	}
+	return !info.isASRReady(c.outputSampleRate)
 }
 
 // UpdateConcurrencyLimit updates the conversion concurrency limit at runtime.
@@ -5,10 +5,14 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // IsFFmpegAvailable checks ffmpeg availability (cached).
@@ -30,10 +34,17 @@ func (c *AudioConverter) IsFFmpegAvailable() bool {
 	return c.ffmpegAvailable
 }
 
-// convertWithFFmpegOptimized converts audio to WAV using ffmpeg.
-func (c *AudioConverter) convertWithFFmpegOptimized(inputData []byte, inputFormat string) ([]byte, error) {
-	// 创建带超时的上下文
-	ctx, cancel := context.WithTimeout(context.Background(), c.conversionTimeout)
+// convertWithFFmpegOptimized converts audio to WAV using ffmpeg, applying the optional -af filter chain.
+// ffmpeg is killed when reqCtx is cancelled or the conversion timeout elapses.
+func (c *AudioConverter) convertWithFFmpegOptimized(reqCtx context.Context, inputData []byte, inputFormat, filters string) (output []byte, err error) {
+	reqCtx, span := tracing.Start(reqCtx, "audio.ffmpeg_convert", trace.SpanKindInternal,
+		attribute.String("audio.input_format", inputFormat),
+		attribute.Int("audio.input_bytes", len(inputData)),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	// 在请求上下文上叠加转换超时
+	ctx, cancel := context.WithTimeout(reqCtx, c.conversionTimeout)
 	defer cancel()
 
 	// 构建优化的ffmpeg命令
@@ -43,15 +54,22 @@ func (c *AudioConverter) convertWithFFmpegOptimized(inputData []byte, inputForma
 		"-loglevel", "error", // 只显示错误
 		"-f", inputFormatArg,
 		"-i", "pipe:0", // 从stdin读取
+	}
+	if filters != "" {
+		args = append(args, "-af", filters) // 预处理滤镜链（响度归一化、高通、降噪、去静音）
+	}
+	args = append(args,
 		"-f", "wav",
-		"-ar", "16000", // 采样率16kHz（适合语音识别）
+		"-ar", strconv.Itoa(c.outputSampleRate), // 采样率（默认16kHz，适合语音识别）
 		"-ac", "1", // 单声道（减少数据量）
 		"-acodec", "pcm_s16le", // 16位PCM编码
 		"-compression_level", "6", // 适中的压缩级别
 		"pipe:1", // 输出到stdout
-	}
+	)
 
 	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	// 进程被杀死后不再无限等待 stdin/stdout 的拷贝完成
+	cmd.WaitDelay = time.Second
 
 	// 使用更高效的输入方式
 	cmd.Stdin = bytes.NewReader(inputData)
@@ -62,9 +80,12 @@ func (c *AudioConverter) convertWithFFmpegOptimized(inputData []byte, inputForma
 	cmd.Stderr = &stderr
 
 	// 执行命令
-	err := cmd.Run()
+	err = cmd.Run()
 
-	// 检查上下文是否被取消（超时）
+	// 请求被取消（客户端断开或请求超时）与转换自身超时分开报告
+	if reqCtx.Err() != nil {
+		return nil, &ConversionCanceledError{Stage: "ffmpeg", Cause: reqCtx.Err()}
+	}
 	if ctx.Err() == context.DeadlineExceeded {
 		return nil, fmt.Errorf("audio conversion timeout after %v", c.conversionTimeout)
 	}
@@ -116,6 +137,8 @@ func (c *AudioConverter) getFFmpegInputFormat(format string) string {
 		return "wav"
 	case "ogg":
 		return "ogg"
+	case "webm":
+		return "matroska"
 	case "wma":
 		return "asf"
 	case "amr":
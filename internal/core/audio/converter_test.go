@@ -2,8 +2,15 @@ package audio
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
 )
 
@@ -11,8 +18,11 @@ func TestAudioConverter_ConvertToWAV_WAVPassthrough(t *testing.T) {
 	logger := testutil.NewTestLogger()
 	converter := NewAudioConverter(logger)
 
-	input := testutil.LoadTestAudio(t, "test.wav")
-	out, err := converter.ConvertToWAV(input, "wav")
+	input := encodeWAVPCM16(make([]float32, 1600), asrSampleRate)
+	if converter.IsConversionNeeded(input, "wav") {
+		t.Fatalf("expected 16kHz mono PCM16 wav to need no conversion")
+	}
+	out, err := converter.ConvertToWAV(context.Background(), input, "wav")
 	if err != nil {
 		t.Fatalf("ConvertToWAV: %v", err)
 	}
@@ -21,6 +31,52 @@ func TestAudioConverter_ConvertToWAV_WAVPassthrough(t *testing.T) {
 	}
 }
 
+func TestAudioConverter_ConvertToWAV_NormalizesWAVWithoutFFmpeg(t *testing.T) {
+	t.Setenv("PATH", "")
+
+	logger := testutil.NewTestLogger()
+	converter := NewAudioConverter(logger)
+
+	// test.wav is 48kHz stereo PCM16.
+	input := testutil.LoadTestAudio(t, "test.wav")
+	if !converter.IsConversionNeeded(input, "wav") {
+		t.Fatalf("expected 48kHz stereo wav to need conversion")
+	}
+	out, err := converter.ConvertToWAV(context.Background(), input, "wav")
+	if err != nil {
+		t.Fatalf("ConvertToWAV: %v", err)
+	}
+
+	info, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV(output): %v", err)
+	}
+	if !info.isASRReady(asrSampleRate) {
+		t.Fatalf("expected 16kHz mono PCM16 output, got rate=%d channels=%d bits=%d", info.sampleRate, info.channels, info.bitsPerSample)
+	}
+}
+
+func TestAudioConverter_ConvertPCMToWAV_ConfiguredSampleRate(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	converter := NewAudioConverterWithConfig(logger, config.AudioConfig{OutputSampleRate: 8000})
+
+	// 0.1s of 16kHz mono s16le.
+	out, err := converter.ConvertPCMToWAV(make([]byte, 3200), FormatPCMS16LE, 16000, 1)
+	if err != nil {
+		t.Fatalf("ConvertPCMToWAV: %v", err)
+	}
+	info, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV(output): %v", err)
+	}
+	if !info.isASRReady(8000) || len(info.data) != 1600 {
+		t.Fatalf("expected 8kHz mono PCM16 output, got rate=%d channels=%d bytes=%d", info.sampleRate, info.channels, len(info.data))
+	}
+	if converter.IsConversionNeeded(out, "wav") {
+		t.Fatalf("expected 8kHz output to need no further conversion")
+	}
+}
+
 func TestAudioConverter_ValidateAudioData(t *testing.T) {
 	logger := testutil.NewTestLogger()
 	converter := NewAudioConverter(logger)
@@ -42,6 +98,12 @@ func TestAudioConverter_ValidateAudioData(t *testing.T) {
 	if err := converter.ValidateAudioData(goodWav, "wav"); err != nil {
 		t.Fatalf("expected valid wav, got: %v", err)
 	}
+
+	// 大小上限由请求的生效限制（可能按身份放宽）校验，这里不再按全局配置报错
+	small := NewAudioConverterWithConfig(logger, config.AudioConfig{MaxSizeBytes: len(goodWav) / 2})
+	if err := small.ValidateAudioData(goodWav, "wav"); err != nil {
+		t.Fatalf("expected no size error from converter, got: %v", err)
+	}
 }
 
 func TestAudioConverter_ConvertToWAV_OpusToWAV(t *testing.T) {
@@ -53,7 +115,7 @@ func TestAudioConverter_ConvertToWAV_OpusToWAV(t *testing.T) {
 	}
 
 	input := testutil.LoadTestAudio(t, "test.opus")
-	out, err := converter.ConvertToWAV(input, "opus")
+	out, err := converter.ConvertToWAV(context.Background(), input, "opus")
 	if err != nil {
 		t.Fatalf("ConvertToWAV: %v", err)
 	}
@@ -68,12 +130,78 @@ func TestAudioConverter_ConvertToWAV_FFmpegUnavailable(t *testing.T) {
 	logger := testutil.NewTestLogger()
 	converter := NewAudioConverter(logger)
 
-	_, err := converter.ConvertToWAV([]byte("not wav"), "mp3")
+	_, err := converter.ConvertToWAV(context.Background(), []byte("not wav"), "mp3")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
 }
 
+// installFakeFFmpeg puts a fake ffmpeg on PATH that reports a version and otherwise runs body.
+func installFakeFFmpeg(t *testing.T, body string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$1\" = \"-version\" ]; then exit 0; fi\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "ffmpeg"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake ffmpeg: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// installSlowFFmpeg puts a fake ffmpeg on PATH that hangs until killed.
+func installSlowFFmpeg(t *testing.T) {
+	t.Helper()
+	installFakeFFmpeg(t, "exec sleep 30")
+}
+
+func TestAudioConverter_ConvertToWAV_CancelKillsFFmpeg(t *testing.T) {
+	installSlowFFmpeg(t)
+
+	logger := testutil.NewTestLogger()
+	converter := NewAudioConverter(logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := converter.ConvertToWAV(ctx, []byte("not really mp3"), "mp3")
+	if !errors.Is(err, ErrConversionCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err=%v want ErrConversionCanceled wrapping context.DeadlineExceeded", err)
+	}
+	var canceled *ConversionCanceledError
+	if !errors.As(err, &canceled) || canceled.Stage != "ffmpeg" {
+		t.Fatalf("expected ffmpeg-stage ConversionCanceledError, got %#v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("conversion returned after %v, ffmpeg was not killed", elapsed)
+	}
+	if n := len(converter.semaphore); n != 0 {
+		t.Fatalf("semaphore holds %d slots after cancellation", n)
+	}
+	if stats := converter.GetStats(); stats.FailedConversions != 0 {
+		t.Fatalf("cancellation counted as failed conversion: failed=%d", stats.FailedConversions)
+	}
+}
+
+func TestAudioConverter_ConvertToWAV_CancelWhileQueued(t *testing.T) {
+	installSlowFFmpeg(t)
+
+	logger := testutil.NewTestLogger()
+	converter := NewAudioConverterWithConfig(logger, config.AudioConfig{ConverterConcurrency: 1, QueueTimeout: time.Minute})
+	converter.semaphore <- struct{}{} // occupy the only slot
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := converter.ConvertToWAV(ctx, []byte("not really mp3"), "mp3")
+	var canceled *ConversionCanceledError
+	if !errors.As(err, &canceled) || canceled.Stage != "queue" || !errors.Is(err, context.Canceled) {
+		t.Fatalf("err=%v want queue-stage ConversionCanceledError", err)
+	}
+}
+
 func minInt(a, b int) int {
 	if a < b {
 		return a
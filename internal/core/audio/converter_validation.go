@@ -6,18 +6,13 @@ import (
 	"strings"
 )
 
-// ValidateAudioData validates input audio data size and performs basic format checks.
+// ValidateAudioData performs basic format checks on input audio data. The size limit is
+// enforced by Processor.Validate against the request's effective limits.
 func (c *AudioConverter) ValidateAudioData(data []byte, format string) error {
 	if len(data) == 0 {
 		return fmt.Errorf("empty audio data")
 	}
 
-	// 检查文件大小限制（32MB）
-	maxSize := 32 * 1024 * 1024
-	if len(data) > maxSize {
-		return fmt.Errorf("audio file too large: %d bytes (max: %d bytes)", len(data), maxSize)
-	}
-
 	// 最小文件大小检查
 	minSize := 100 // 至少100字节
 	if len(data) < minSize {
@@ -36,6 +31,8 @@ func (c *AudioConverter) ValidateAudioData(data []byte, format string) error {
 		return c.validateFLAC(data)
 	case "m4a", "aac":
 		return c.validateM4A(data)
+	case FormatPCMS16LE, FormatPCMF32LE:
+		return c.validateRawPCM(data, strings.ToLower(format))
 	default:
 		// 对于其他格式，只做基本检查
 		c.logger.Debugf("Basic validation for format: %s", format)
@@ -130,3 +127,11 @@ func (c *AudioConverter) validateM4A(data []byte) error {
 
 	return fmt.Errorf("invalid M4A file: missing valid box header")
 }
+
+// validateRawPCM checks that headerless PCM contains whole samples.
+func (c *AudioConverter) validateRawPCM(data []byte, format string) error {
+	if size := rawPCMSampleSize(format); len(data)%size != 0 {
+		return fmt.Errorf("invalid %s data: length %d is not a multiple of %d bytes", format, len(data), size)
+	}
+	return nil
+}
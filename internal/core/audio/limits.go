@@ -0,0 +1,148 @@
+// limits.go resolves the audio size/duration/format limits applied to a request.
+package audio
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+)
+
+// Identity metadata keys that override the configured audio limits for one caller.
+const (
+	MetadataAudioMaxSizeBytes   = "audio_max_size_bytes"
+	MetadataAudioMaxDuration    = "audio_max_duration"
+	MetadataAudioAllowedFormats = "audio_allowed_formats"
+)
+
+var defaultAllowedFormats = []string{"wav", "mp3", "m4a", "aac", "opus", "ogg", "flac", "webm", "amr", FormatPCMS16LE, FormatPCMF32LE}
+
+// Limits bounds the audio accepted for a single request.
+type Limits struct {
+	MaxSizeBytes   int
+	MaxDuration    time.Duration // 0 表示不限制
+	AllowedFormats []string
+}
+
+// LimitsFromConfig builds Limits from the audio config section, filling unset values with defaults.
+func LimitsFromConfig(cfg config.AudioConfig) Limits {
+	limits := Limits{
+		MaxSizeBytes:   cfg.MaxSizeBytes,
+		MaxDuration:    cfg.MaxDuration,
+		AllowedFormats: normalizeFormats(cfg.AllowedFormats),
+	}
+	if limits.MaxSizeBytes <= 0 {
+		limits.MaxSizeBytes = maxAudioSizeBytes
+	}
+	if limits.MaxDuration < 0 {
+		limits.MaxDuration = 0
+	}
+	if len(limits.AllowedFormats) == 0 {
+		limits.AllowedFormats = append([]string(nil), defaultAllowedFormats...)
+	}
+	return limits
+}
+
+// WithOverrides applies per-identity overrides from auth metadata
+// (audio_max_size_bytes, audio_max_duration, audio_allowed_formats).
+// Values that cannot be parsed are ignored.
+func (l Limits) WithOverrides(metadata map[string]interface{}) Limits {
+	if len(metadata) == 0 {
+		return l
+	}
+	out := l
+	out.AllowedFormats = append([]string(nil), l.AllowedFormats...)
+
+	if v, ok := metadataInt(metadata[MetadataAudioMaxSizeBytes]); ok && v > 0 {
+		out.MaxSizeBytes = v
+	}
+	if v, ok := metadataDuration(metadata[MetadataAudioMaxDuration]); ok && v >= 0 {
+		out.MaxDuration = v
+	}
+	if formats := normalizeFormats(metadataStrings(metadata[MetadataAudioAllowedFormats])); len(formats) > 0 {
+		out.AllowedFormats = formats
+	}
+	return out
+}
+
+// AllowsFormat reports whether format is in the allowed list.
+func (l Limits) AllowsFormat(format string) bool {
+	format = strings.ToLower(strings.TrimSpace(format))
+	for _, allowed := range l.AllowedFormats {
+		if allowed == format {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeFormats(formats []string) []string {
+	out := make([]string, 0, len(formats))
+	for _, f := range formats {
+		if f = strings.ToLower(strings.TrimSpace(f)); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func metadataInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case string:
+		parsed, err := strconv.Atoi(strings.TrimSpace(n))
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// metadataDuration accepts a Go duration string ("90s", "5m") or a number of seconds.
+func metadataDuration(v interface{}) (time.Duration, bool) {
+	switch d := v.(type) {
+	case time.Duration:
+		return d, true
+	case string:
+		if parsed, err := time.ParseDuration(strings.TrimSpace(d)); err == nil {
+			return parsed, true
+		}
+		if seconds, err := strconv.ParseFloat(strings.TrimSpace(d), 64); err == nil {
+			return time.Duration(seconds * float64(time.Second)), true
+		}
+		return 0, false
+	default:
+		if seconds, ok := metadataInt(v); ok {
+			if f, isFloat := v.(float64); isFloat {
+				return time.Duration(f * float64(time.Second)), true
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+		return 0, false
+	}
+}
+
+// metadataStrings accepts a string slice, a JSON-decoded array or a comma-separated string.
+func metadataStrings(v interface{}) []string {
+	switch s := v.(type) {
+	case []string:
+		return s
+	case []interface{}:
+		out := make([]string, 0, len(s))
+		for _, item := range s {
+			if str, ok := item.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	case string:
+		return strings.Split(s, ",")
+	default:
+		return nil
+	}
+}
@@ -20,6 +20,8 @@ import (
 func (p *Processor) BuildLLMRequest(ctx context.Context, req ProcessRequest) (*llm.LLMRequest, error) {
 	requestID, _ := logging.RequestIDFromContext(ctx)
 
+	req = withResolvedFormat(req)
+
 	// 1. 验证音频数据
 	if err := p.audioConverter.ValidateAudioData(req.Audio, req.AudioFormat); err != nil {
 		entry := p.logger.WithError(err)
@@ -33,8 +35,11 @@ func (p *Processor) BuildLLMRequest(ctx context.Context, req ProcessRequest) (*l
 	audioData := req.Audio
 	audioFormat := req.AudioFormat
 
-	if p.audioConverter.IsConversionNeeded(req.AudioFormat) {
-		convertedData, err := p.audioConverter.ConvertToWAV(req.Audio, req.AudioFormat)
+	if p.needsConversion(req) {
+		convertedData, _, err := p.convertAudio(ctx, req)
+		if errors.Is(err, ErrConversionCanceled) {
+			return nil, err
+		}
 		if err != nil {
 			p.logger.WithError(err).Warn("Audio conversion failed, using original format")
 		} else {
@@ -123,6 +128,8 @@ func (p *Processor) BuildLLMRequest(ctx context.Context, req ProcessRequest) (*l
 			"audio_original_format":  req.AudioFormat,
 			"audio_processed_format": audioFormat,
 			"conversion_applied":     audioFormat != req.AudioFormat,
+			"audio_declared_format":  req.declaredFormat,
+			"audio_detected_format":  req.detectedFormat,
 		},
 	}
 
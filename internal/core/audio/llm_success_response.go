@@ -20,7 +20,7 @@ func (p *Processor) BuildSuccessResponse(llmResp *llm.LLMResponse, parsedResp *p
 	response.Metadata["prompt_tokens"] = llmResp.PromptTokens
 	response.Metadata["total_tokens"] = llmResp.TotalTokens
 	response.Metadata["backend"] = llmResp.Metadata["backend"]
-	response.Metadata["original_format"] = req.AudioFormat
+	response.Metadata["original_format"] = originalFormat(withResolvedFormat(req))
 	response.Transcription = ""
 	response.CorrectedText = ""
 
@@ -58,6 +58,10 @@ func (p *Processor) BuildSuccessResponse(llmResp *llm.LLMResponse, parsedResp *p
 				if v, ok := ctxMap["conversion_applied"]; ok {
 					response.Metadata["conversion_applied"] = v
 				}
+				if detected, _ := ctxMap["audio_detected_format"].(string); detected != "" {
+					response.Metadata["declared_format"] = ctxMap["audio_declared_format"]
+					response.Metadata["detected_format"] = detected
+				}
 			}
 		}
 	}
@@ -3,9 +3,11 @@ package audio
 
 import (
 	"fmt"
+	"strings"
 
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/pipeline"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
 )
@@ -17,7 +19,11 @@ func (p *Processor) ensurePipelineInitialized() error {
 
 	reg := tool.NewRegistry()
 
-	if err := reg.Register(tool.NewASRTool(p.asrManager)); err != nil {
+	asrTool := tool.NewASRToolWithCache(p.asrManager, p.asrCache, p.asrCacheTTL)
+	if p.promptEngine != nil {
+		asrTool.WithLanguageNormalizer(p.promptEngine)
+	}
+	if err := reg.Register(asrTool); err != nil {
 		return err
 	}
 
@@ -27,19 +33,86 @@ func (p *Processor) ensurePipelineInitialized() error {
 	if err := reg.Register(tool.NewCorrectTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)); err != nil {
 		return err
 	}
-	if err := reg.Register(tool.NewTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)); err != nil {
+	translate := tool.NewTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking).
+		WithTranslationConfig(p.pipelineConfig.Translation)
+	if err := reg.Register(translate); err != nil {
+		return err
+	}
+	if err := reg.Register(tool.NewGlossaryCheckTool(translate)); err != nil {
+		return err
+	}
+	correctTranslate := tool.NewCorrectTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking).
+		WithTranslationConfig(p.pipelineConfig.Translation)
+	if err := reg.Register(correctTranslate); err != nil {
+		return err
+	}
+	if err := reg.Register(tool.NewAudioLLMTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)); err != nil {
 		return err
 	}
-	if err := reg.Register(tool.NewCorrectTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)); err != nil {
+	moderate, err := tool.NewModerateTool(p.pipelineConfig.Moderation, p.llmManager, p.promptEngine, toolCallingEnabled)
+	if err != nil {
+		return coreerrors.NewInternalError("invalid moderation configuration", err)
+	}
+	if err := reg.Register(moderate); err != nil {
 		return err
 	}
+	if p.ttsManager != nil {
+		if err := reg.Register(tool.NewTTSTool(p.ttsManager, p.ttsStore, p.ttsConfig)); err != nil {
+			return err
+		}
+	}
+
+	catalog, err := pipeline.NewCatalog(pipeline.AudioBuiltins(), p.pipelineConfig.Definitions, pipeline.TypeAudio, reg)
+	if err != nil {
+		return coreerrors.NewInternalError("invalid audio pipeline configuration", err)
+	}
+	if err := catalog.CheckTasks(p.pipelineConfig.Tasks.Audio); err != nil {
+		return coreerrors.NewInternalError("invalid audio pipeline configuration", err)
+	}
 
 	p.toolRegistry = reg
-	p.pipelineExec = pipeline.NewExecutor(reg)
+	p.pipelines = catalog
+	p.pipelineExec = pipeline.NewExecutor(reg).WithMaxParallel(p.pipelineConfig.MaxParallelSteps)
+	if p.statusStore != nil {
+		p.pipelineExec.WithProgressHook(processing.PipelineProgress(p.statusStore))
+	}
 	return nil
 }
 
-func (p *Processor) selectPipeline(task prompt.TaskType) (pipeline.Pipeline, error) {
+// ValidatePipelines builds the tool registry and checks the configured pipelines against it.
+func (p *Processor) ValidatePipelines() error {
+	return p.ensurePipelineInitialized()
+}
+
+// PipelineNames lists the pipelines that requests may select with options.pipeline.
+func (p *Processor) PipelineNames() []string {
+	if err := p.ensurePipelineInitialized(); err != nil {
+		return nil
+	}
+	return p.pipelines.Names()
+}
+
+// selectPipeline picks the pipeline for req: options.pipeline first, then the configured task
+// mapping, then the built-in choice based on the correction settings.
+// requested reports whether the request named the pipeline explicitly.
+func (p *Processor) selectPipeline(req ProcessRequest) (selected pipeline.Pipeline, requested bool, err error) {
+	if name := pipeline.RequestedName(req.Options); name != "" {
+		selected, ok := p.pipelines.Lookup(name)
+		if !ok {
+			return pipeline.Pipeline{}, true, coreerrors.NewValidationError(fmt.Sprintf("unknown pipeline: %s", name), nil)
+		}
+		return selected, true, nil
+	}
+	if name, ok := p.pipelineConfig.Tasks.Audio[string(req.Task)]; ok {
+		// 启动时已校验映射的 pipeline 存在
+		selected, _ := p.pipelines.Lookup(name)
+		return selected, false, nil
+	}
+	selected, err = p.defaultPipeline(req.Task)
+	return selected, false, err
+}
+
+func (p *Processor) defaultPipeline(task prompt.TaskType) (pipeline.Pipeline, error) {
 	switch task {
 	case prompt.TaskTranscribe:
 		if p.correction.Enabled {
@@ -58,3 +131,23 @@ func (p *Processor) selectPipeline(task prompt.TaskType) (pipeline.Pipeline, err
 		return pipeline.Pipeline{}, coreerrors.NewValidationError(fmt.Sprintf("unsupported task type: %s", task), nil)
 	}
 }
+
+// useDirectAudio reports whether the request opted into the audio_direct pipeline
+// and an LLM backend advertises support for the (possibly converted) audio.
+func (p *Processor) useDirectAudio(req ProcessRequest, audioFormat string, audioSize int) bool {
+	if !directAudioRequested(req.Options) || p.llmManager == nil {
+		return false
+	}
+	return p.llmManager.SupportsAudioInput(audioFormat, int64(audioSize))
+}
+
+func directAudioRequested(options map[string]interface{}) bool {
+	switch v := options["direct_audio"].(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(strings.TrimSpace(v), "true")
+	default:
+		return false
+	}
+}
@@ -3,13 +3,20 @@ package audio
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/archive"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/cache"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/pipeline"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
 	"github.com/sirupsen/logrus"
 )
@@ -26,6 +33,17 @@ type Processor struct {
 	pipelineConfig config.PipelineConfig
 	toolRegistry   *tool.Registry
 	pipelineExec   *pipeline.Executor
+	pipelines      pipeline.Catalog
+	asrCache       cache.TranscriptionCache
+	asrCacheTTL    time.Duration
+	limits         Limits
+	archiver       *archive.Archiver
+	statusStore    processing.StatusStore
+	profiles       map[string]config.AudioProfile
+	defaultProfile string
+	ttsManager     *tts.Manager
+	ttsStore       *tts.Store
+	ttsConfig      config.TTSConfig
 	logger         *logrus.Logger
 }
 
@@ -44,6 +62,8 @@ func NewProcessor(
 		llmManager:     llmManager,
 		promptEngine:   promptEngine,
 		audioConverter: NewAudioConverter(logger),
+		limits:         LimitsFromConfig(config.AudioConfig{}),
+		profiles:       buildProfiles(nil),
 		metrics:        metricsCollector,
 		config:         promptCfg,
 		correction:     correctionCfg,
@@ -66,6 +86,64 @@ func (p *Processor) WithPipelineConfig(cfg config.PipelineConfig) *Processor {
 	return p
 }
 
+// WithStatusStore records per-step pipeline progress under the request ID.
+func (p *Processor) WithStatusStore(store processing.StatusStore) *Processor {
+	p.statusStore = store
+	p.toolRegistry = nil
+	p.pipelineExec = nil
+	return p
+}
+
+// WithASRCache enables reuse of transcription results for identical audio.
+func (p *Processor) WithASRCache(c cache.TranscriptionCache, ttl time.Duration) *Processor {
+	p.asrCache = c
+	p.asrCacheTTL = ttl
+	p.toolRegistry = nil
+	p.pipelineExec = nil
+	return p
+}
+
+// WithTTS enables the tts tool; requests ask for speech with options.tts.
+func (p *Processor) WithTTS(manager *tts.Manager, store *tts.Store, cfg config.TTSConfig) *Processor {
+	p.ttsManager = manager
+	p.ttsStore = store
+	p.ttsConfig = cfg
+	p.toolRegistry = nil
+	p.pipelineExec = nil
+	return p
+}
+
+// WithAudioConfig applies the audio config section to request limits and the converter.
+func (p *Processor) WithAudioConfig(cfg config.AudioConfig) *Processor {
+	p.limits = LimitsFromConfig(cfg)
+	p.audioConverter = NewAudioConverterWithConfig(p.logger, cfg)
+	p.profiles = buildProfiles(cfg.Profiles)
+	p.defaultProfile = strings.ToLower(strings.TrimSpace(cfg.DefaultProfile))
+	if _, ok := p.profiles[p.defaultProfile]; p.defaultProfile != "" && !ok {
+		p.logger.WithField("profile", cfg.DefaultProfile).Warn("Unknown audio.default_profile, preprocessing disabled")
+		p.defaultProfile = ""
+	}
+	return p
+}
+
+// Limits returns the configured audio limits.
+func (p *Processor) Limits() Limits {
+	return p.limits
+}
+
+// LimitsFor returns the configured audio limits with per-identity overrides applied.
+func (p *Processor) LimitsFor(metadata map[string]interface{}) Limits {
+	return p.limits.WithOverrides(metadata)
+}
+
+// effectiveLimits returns the limits that apply to req.
+func (p *Processor) effectiveLimits(req ProcessRequest) Limits {
+	if req.Limits != nil {
+		return *req.Limits
+	}
+	return p.limits
+}
+
 // ProcessDirect optionally handles requests without going through ProcessingService's single-LLM-call flow.
 func (p *Processor) ProcessDirect(ctx context.Context, req ProcessRequest) (*ProcessResponse, bool, error) {
 	resp, err := p.processWithPipeline(ctx, req)
@@ -74,3 +152,54 @@ func (p *Processor) ProcessDirect(ctx context.Context, req ProcessRequest) (*Pro
 	}
 	return resp, true, nil
 }
+
+// needsConversion reports whether req's audio has to go through convertAudio:
+// either it is not ASR-ready WAV yet or a preprocessing profile with filters is selected.
+func (p *Processor) needsConversion(req ProcessRequest) bool {
+	if p.audioConverter.IsConversionNeeded(req.Audio, req.AudioFormat) {
+		return true
+	}
+	_, filters, _ := p.selectAudioProfile(req)
+	return filters != ""
+}
+
+// convertAudio converts the request audio into mono PCM16 WAV at the configured output sample rate,
+// using the request's sample_rate/channels for headerless PCM input, and runs the selected
+// preprocessing profile. appliedProfile is "" when no profile filters ran (none selected,
+// ffmpeg unavailable or filtering failed); the audio is then converted unfiltered.
+func (p *Processor) convertAudio(ctx context.Context, req ProcessRequest) (out []byte, appliedProfile string, err error) {
+	profileName, filters, _ := p.selectAudioProfile(req)
+	if filters != "" && !p.audioConverter.IsFFmpegAvailable() {
+		p.logger.WithField("audio_profile", profileName).Warn("ffmpeg not available, skipping audio preprocessing profile")
+		filters = ""
+	}
+
+	data, format := req.Audio, req.AudioFormat
+	if IsRawPCMFormat(format) {
+		channels := req.Channels
+		if channels == 0 {
+			channels = 1
+		}
+		wav, err := p.audioConverter.ConvertPCMToWAV(data, format, req.SampleRate, channels)
+		if err != nil || filters == "" {
+			return wav, "", err
+		}
+		data, format = wav, "wav"
+	}
+
+	if filters == "" {
+		out, err = p.audioConverter.ConvertToWAV(ctx, data, format)
+		return out, "", err
+	}
+
+	out, err = p.audioConverter.ConvertToWAVWithFilters(ctx, data, format, filters)
+	if err == nil {
+		return out, profileName, nil
+	}
+	if errors.Is(err, ErrConversionCanceled) {
+		return nil, "", err
+	}
+	p.logger.WithError(err).WithField("audio_profile", profileName).Warn("Audio preprocessing failed, converting without filters")
+	out, err = p.audioConverter.ConvertToWAV(ctx, data, format)
+	return out, "", err
+}
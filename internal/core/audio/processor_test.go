@@ -7,15 +7,21 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/archive"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
 )
 
@@ -56,6 +62,16 @@ func newTestPromptConfig() config.PromptConfig {
 
 func newTestASRManager(t *testing.T, text string) *asr.Manager {
 	t.Helper()
+	return newTestASRManagerWithPayload(t, map[string]any{
+		"language": "zh",
+		"duration": 1.0,
+		"text":     text,
+	})
+}
+
+// newTestASRManagerWithPayload serves payload as the transcription response.
+func newTestASRManagerWithPayload(t *testing.T, payload map[string]any) *asr.Manager {
+	t.Helper()
 
 	asrSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -65,11 +81,7 @@ func newTestASRManager(t *testing.T, text string) *asr.Manager {
 			return
 		case "/v1/audio/transcriptions":
 			w.Header().Set("Content-Type", "application/json")
-			_ = json.NewEncoder(w).Encode(map[string]any{
-				"language": "zh",
-				"duration": 1.0,
-				"text":     text,
-			})
+			_ = json.NewEncoder(w).Encode(payload)
 			return
 		default:
 			http.NotFound(w, r)
@@ -131,6 +143,111 @@ func TestProcessor_ProcessDirect_Transcribe_NoCorrection(t *testing.T) {
 	}
 }
 
+func TestProcessor_ProcessDirect_MislabeledFormatUsesDetected(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.NewTestLogger()
+	promptCfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+
+	p := NewProcessor(newTestASRManager(t, "你好"), nil, engine, promptCfg, config.CorrectionConfig{Enabled: false}, logger, metrics.NewSimpleMetricsCollector(logger))
+
+	req := ProcessRequest{
+		Audio:       testutil.LoadTestAudio(t, "test.wav"),
+		AudioFormat: "mp3",
+		Task:        prompt.TaskTranscribe,
+	}
+	if err := p.Validate(req); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	resp, _, err := p.ProcessDirect(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ProcessDirect: %v", err)
+	}
+	if resp.Metadata["declared_format"] != "mp3" || resp.Metadata["detected_format"] != "wav" || resp.Metadata["format_mismatch"] != true {
+		t.Fatalf("unexpected format metadata: %v", resp.Metadata)
+	}
+	if resp.Metadata["original_format"] != "mp3" || resp.Metadata["processed_format"] != "wav" {
+		t.Fatalf("original_format=%v processed_format=%v want declared mp3 and effective wav", resp.Metadata["original_format"], resp.Metadata["processed_format"])
+	}
+
+	auto := req
+	auto.AudioFormat = FormatAuto
+	if err := p.Validate(auto); err != nil {
+		t.Fatalf("Validate(auto): %v", err)
+	}
+	autoResp, _, err := p.ProcessDirect(context.Background(), auto)
+	if err != nil {
+		t.Fatalf("ProcessDirect(auto): %v", err)
+	}
+	if autoResp.Metadata["original_format"] != "wav" || autoResp.Metadata["declared_format"] != FormatAuto {
+		t.Fatalf("unexpected auto format metadata: %v", autoResp.Metadata)
+	}
+	undetectable := ProcessRequest{Audio: make([]byte, 200), AudioFormat: FormatAuto, Task: prompt.TaskTranscribe}
+	if err := p.Validate(undetectable); err == nil {
+		t.Fatalf("expected error when format cannot be detected")
+	}
+}
+
+func TestProcessor_ProcessDirect_ArchivesOptedInRequests(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.NewTestLogger()
+	promptCfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+
+	root := t.TempDir()
+	sink, err := archive.NewDirectorySink(root, archive.Retention{})
+	if err != nil {
+		t.Fatalf("NewDirectorySink: %v", err)
+	}
+	archiver := archive.NewArchiver(sink, 4, 0, logger)
+	p := NewProcessor(newTestASRManager(t, "你好"), nil, engine, promptCfg, config.CorrectionConfig{Enabled: false}, logger, metrics.NewSimpleMetricsCollector(logger)).
+		WithArchiver(archiver)
+
+	audioData := testutil.LoadTestAudio(t, "test.wav")
+	base := ProcessRequest{Audio: audioData, AudioFormat: "wav", Task: prompt.TaskTranscribe, CallerID: "user-1"}
+
+	resp, _, err := p.ProcessDirect(context.Background(), base)
+	if err != nil {
+		t.Fatalf("ProcessDirect: %v", err)
+	}
+	if _, ok := resp.Metadata["archived"]; ok {
+		t.Fatalf("request without opt-in was archived")
+	}
+
+	optedIn := base
+	optedIn.ArchiveOptIn = true
+	resp, _, err = p.ProcessDirect(logging.WithRequestID(context.Background(), "req-archive"), optedIn)
+	if err != nil {
+		t.Fatalf("ProcessDirect(opted in): %v", err)
+	}
+	if resp.Metadata["archived"] != true {
+		t.Fatalf("expected archived=true, metadata=%v", resp.Metadata)
+	}
+
+	if err := archiver.Close(); err != nil {
+		t.Fatalf("archiver.Close: %v", err)
+	}
+	entries := sink.Entries()
+	if len(entries) != 1 || entries[0].RequestID != "req-archive" || entries[0].CallerID != "user-1" {
+		t.Fatalf("unexpected manifest entries: %+v", entries)
+	}
+	original, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(entries[0].Location), "original.wav"))
+	if err != nil {
+		t.Fatalf("read archived audio: %v", err)
+	}
+	if !bytes.Equal(original, audioData) {
+		t.Fatalf("archived original audio differs from request audio")
+	}
+}
+
 func TestProcessor_BuildLLMRequest_Translate_DefaultTargets(t *testing.T) {
 	t.Parallel()
 
@@ -257,6 +374,146 @@ func TestProcessor_ProcessDirect_Translate_Separated(t *testing.T) {
 	}
 }
 
+func TestProcessor_ProcessDirect_SkipsTranslationForDetectedLanguageName(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": "```json\n{\"translations\":{\"en\":\"hello\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	logger := testutil.NewTestLogger()
+	promptCfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: llmSrv.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("llm.NewManager: %v", err)
+	}
+
+	// Qwen ASR 以 "language English<asr_text>" 前缀返回语言名称，而不是语言代码
+	p := NewProcessor(
+		newTestASRManagerWithPayload(t, map[string]any{"text": "language English<asr_text>Hello there."}),
+		llmManager,
+		engine,
+		promptCfg,
+		config.CorrectionConfig{Enabled: false},
+		logger,
+		metrics.NewSimpleMetricsCollector(logger),
+	)
+
+	resp, _, err := p.ProcessDirect(context.Background(), ProcessRequest{
+		Audio:           testutil.LoadTestAudio(t, "test.wav"),
+		AudioFormat:     "wav",
+		Task:            prompt.TaskTranslate,
+		TargetLanguages: []string{"en"},
+	})
+	if err != nil {
+		t.Fatalf("ProcessDirect: %v", err)
+	}
+	defer resp.Release()
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("llm calls=%d want 0 for an English source translated to en", n)
+	}
+	if resp.Metadata["asr_language"] != "en" || resp.Translations["en"] != "Hello there." {
+		t.Fatalf("asr_language=%v translations=%v", resp.Metadata["asr_language"], resp.Translations)
+	}
+	outcomes, _ := resp.Metadata["step_outcomes"].(map[string]tool.StepOutcome)
+	if outcomes["translate_result"].Status != tool.OutcomeSkipped {
+		t.Fatalf("step_outcomes=%v want translate_result skipped", resp.Metadata["step_outcomes"])
+	}
+}
+
+func TestProcessor_ProcessDirect_DirectAudio(t *testing.T) {
+	t.Parallel()
+
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		defer r.Body.Close()
+
+		if !bytes.Contains(body, []byte(`"input_audio"`)) {
+			t.Fatalf("expected input_audio content part, got: %s", string(body))
+		}
+
+		content := "```json\n{\"transcription\":\"你好\",\"translations\":{\"en\":\"hello\"}}\n```"
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": content}},
+			},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	logger := testutil.NewTestLogger()
+	promptCfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{
+				Name:  "audio",
+				Type:  "openai",
+				URL:   llmSrv.URL,
+				Model: "qwen-audio",
+				Audio: config.BackendAudioConfig{Enabled: true, Formats: []string{"wav"}},
+			},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("llm.NewManager: %v", err)
+	}
+
+	p := NewProcessor(
+		newTestASRManager(t, "ASR should not be used"),
+		llmManager,
+		engine,
+		promptCfg,
+		config.CorrectionConfig{Enabled: true, MergeWithTranslation: true},
+		logger,
+		metrics.NewSimpleMetricsCollector(logger),
+	).WithPipelineConfig(config.PipelineConfig{})
+
+	audioData := testutil.LoadTestAudio(t, "test.wav")
+	resp, _, err := p.ProcessDirect(context.Background(), ProcessRequest{
+		Audio:           audioData,
+		AudioFormat:     "wav",
+		Task:            prompt.TaskTranslate,
+		TargetLanguages: []string{"en"},
+		Options:         map[string]interface{}{"direct_audio": true},
+	})
+	if err != nil {
+		t.Fatalf("ProcessDirect: %v", err)
+	}
+	if resp.Metadata["pipeline"] != "audio_direct" {
+		t.Fatalf("pipeline=%v want audio_direct", resp.Metadata["pipeline"])
+	}
+	if resp.Transcription != "你好" {
+		t.Fatalf("Transcription=%q want 你好", resp.Transcription)
+	}
+	if resp.Translations["en"] != "hello" {
+		t.Fatalf("en=%q want hello", resp.Translations["en"])
+	}
+}
+
 func TestProcessor_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -287,6 +544,111 @@ func TestProcessor_Validate(t *testing.T) {
 	}
 }
 
+func TestProcessor_Validate_RawPCM(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.NewTestLogger()
+	promptCfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+
+	p := NewProcessor(nil, nil, engine, promptCfg, config.CorrectionConfig{}, logger, metrics.NewSimpleMetricsCollector(logger))
+
+	req := ProcessRequest{
+		Audio:       make([]byte, 3200),
+		AudioFormat: FormatPCMS16LE,
+		SampleRate:  48000,
+		Channels:    2,
+		Task:        prompt.TaskTranscribe,
+	}
+	if err := p.Validate(req); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	missingRate := req
+	missingRate.SampleRate = 0
+	if err := p.Validate(missingRate); err == nil {
+		t.Fatalf("expected error for missing sample_rate")
+	}
+
+	partialFrame := req
+	partialFrame.Audio = make([]byte, 3202)
+	if err := p.Validate(partialFrame); err == nil {
+		t.Fatalf("expected error for partial frame")
+	}
+
+	oneHertz := ProcessRequest{
+		Audio:       buildTestWAV(wavFormatPCM, 1, 1, 16, make([]byte, 4096)),
+		AudioFormat: "wav",
+		Task:        prompt.TaskTranscribe,
+	}
+	if err := p.Validate(oneHertz); err == nil {
+		t.Fatalf("expected error for a WAV header claiming 1 Hz")
+	}
+}
+
+func TestProcessor_Validate_AudioConfigLimits(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.NewTestLogger()
+	promptCfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+
+	p := NewProcessor(nil, nil, engine, promptCfg, config.CorrectionConfig{}, logger, metrics.NewSimpleMetricsCollector(logger)).
+		WithAudioConfig(config.AudioConfig{
+			MaxSizeBytes:   64000,
+			MaxDuration:    time.Second,
+			AllowedFormats: []string{"wav", FormatPCMS16LE},
+		})
+
+	req := ProcessRequest{
+		Audio:       make([]byte, 32000), // 1s of 16kHz mono s16le
+		AudioFormat: FormatPCMS16LE,
+		SampleRate:  16000,
+		Task:        prompt.TaskTranscribe,
+	}
+	if err := p.Validate(req); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	tooLong := req
+	tooLong.Audio = make([]byte, 48000)
+	if err := p.Validate(tooLong); err == nil {
+		t.Fatalf("expected error for audio longer than max_duration")
+	}
+
+	tooLarge := req
+	tooLarge.Audio = make([]byte, 64002)
+	tooLarge.SampleRate = 96000
+	if err := p.Validate(tooLarge); err == nil {
+		t.Fatalf("expected error for audio larger than max_size_bytes")
+	}
+
+	disallowed := req
+	disallowed.AudioFormat = "mp3"
+	if err := p.Validate(disallowed); err == nil {
+		t.Fatalf("expected error for format outside allowed_formats")
+	}
+
+	overridden := p.LimitsFor(map[string]interface{}{
+		MetadataAudioMaxDuration:    "2s",
+		MetadataAudioAllowedFormats: []interface{}{"mp3"},
+	})
+	tooLong.Limits = &overridden
+	if err := p.Validate(tooLong); err == nil {
+		t.Fatalf("expected error for pcm outside overridden allowed_formats")
+	}
+	overridden.AllowedFormats = append(overridden.AllowedFormats, FormatPCMS16LE)
+	if err := p.Validate(tooLong); err != nil {
+		t.Fatalf("Validate with overridden max_duration: %v", err)
+	}
+}
+
 func TestProcessor_BuildSuccessResponse_UsesContext(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,101 @@
+// profiles.go resolves ffmpeg preprocessing profiles for noisy input audio.
+package audio
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+)
+
+// ProfileNone disables preprocessing, overriding audio.default_profile.
+const ProfileNone = "none"
+
+// builtinProfiles are available without configuration; audio.profiles entries with the same name replace them.
+var builtinProfiles = map[string]config.AudioProfile{
+	ProfileNone: {},
+	// voice: 去除低频隆隆声并统一响度，适合普通麦克风输入
+	"voice": {HighpassHz: 80, Loudnorm: true},
+	// noisy: VRChat 世界中的背景音乐、混响与削波
+	"noisy": {HighpassHz: 100, NoiseReductionDB: 12, SilenceRemove: true, Loudnorm: true},
+}
+
+// defaultSilenceThresholdDB is used when a profile enables silence removal without a threshold.
+const defaultSilenceThresholdDB = -50
+
+// buildProfiles merges configured profiles over the built-in ones.
+func buildProfiles(configured map[string]config.AudioProfile) map[string]config.AudioProfile {
+	profiles := make(map[string]config.AudioProfile, len(builtinProfiles)+len(configured))
+	for name, profile := range builtinProfiles {
+		profiles[name] = profile
+	}
+	for name, profile := range configured {
+		profiles[strings.ToLower(strings.TrimSpace(name))] = profile
+	}
+	return profiles
+}
+
+// profileFilterChain renders a profile as an ffmpeg -af filter chain; "" means no filtering.
+// Filters run high-pass → denoise → silence removal → loudness normalization, so that
+// loudnorm measures the cleaned signal.
+func profileFilterChain(profile config.AudioProfile) string {
+	var filters []string
+	if profile.HighpassHz > 0 {
+		filters = append(filters, "highpass=f="+strconv.Itoa(profile.HighpassHz))
+	}
+	if profile.NoiseReductionDB > 0 {
+		filters = append(filters, "afftdn=nr="+strconv.FormatFloat(profile.NoiseReductionDB, 'g', -1, 64))
+	}
+	if profile.SilenceRemove {
+		threshold := profile.SilenceThresholdDB
+		if threshold == 0 {
+			threshold = defaultSilenceThresholdDB
+		}
+		db := strconv.FormatFloat(threshold, 'g', -1, 64) + "dB"
+		filters = append(filters, fmt.Sprintf(
+			"silenceremove=start_periods=1:start_threshold=%s:stop_periods=-1:stop_duration=0.5:stop_threshold=%s", db, db))
+	}
+	if profile.Loudnorm {
+		filters = append(filters, "loudnorm=I=-16:TP=-1.5:LRA=11")
+	}
+	return strings.Join(filters, ",")
+}
+
+// requestedProfile returns options.audio_profile, if set.
+func requestedProfile(options map[string]interface{}) (string, bool) {
+	v, ok := options["audio_profile"]
+	if !ok || v == nil {
+		return "", false
+	}
+	name, _ := v.(string)
+	return strings.ToLower(strings.TrimSpace(name)), true
+}
+
+// selectAudioProfile returns the profile name and filter chain for req:
+// options.audio_profile wins over audio.default_profile. name is "" when no profile applies.
+func (p *Processor) selectAudioProfile(req ProcessRequest) (name, filters string, err error) {
+	name = p.defaultProfile
+	if requested, ok := requestedProfile(req.Options); ok {
+		name = requested
+	}
+	if name == "" {
+		return "", "", nil
+	}
+	profile, ok := p.profiles[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown audio_profile %q (available: %s)", name, strings.Join(p.ProfileNames(), ", "))
+	}
+	return name, profileFilterChain(profile), nil
+}
+
+// ProfileNames lists the available preprocessing profiles.
+func (p *Processor) ProfileNames() []string {
+	names := make([]string, 0, len(p.profiles))
+	for name := range p.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,108 @@
+package audio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+)
+
+func TestProfileFilterChain(t *testing.T) {
+	t.Parallel()
+
+	if got := profileFilterChain(config.AudioProfile{}); got != "" {
+		t.Fatalf("empty profile chain=%q want empty", got)
+	}
+
+	got := profileFilterChain(config.AudioProfile{HighpassHz: 100, NoiseReductionDB: 12, SilenceRemove: true, Loudnorm: true})
+	want := "highpass=f=100,afftdn=nr=12," +
+		"silenceremove=start_periods=1:start_threshold=-50dB:stop_periods=-1:stop_duration=0.5:stop_threshold=-50dB," +
+		"loudnorm=I=-16:TP=-1.5:LRA=11"
+	if got != want {
+		t.Fatalf("chain=%q\nwant %q", got, want)
+	}
+}
+
+func newProfileTestProcessor(t *testing.T, cfg config.AudioConfig) *Processor {
+	t.Helper()
+
+	logger := testutil.NewTestLogger()
+	promptCfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(promptCfg, logger)
+	if err != nil {
+		t.Fatalf("prompt.NewEngine: %v", err)
+	}
+	return NewProcessor(newTestASRManager(t, "你好"), nil, engine, promptCfg, config.CorrectionConfig{Enabled: false}, logger, metrics.NewSimpleMetricsCollector(logger)).
+		WithAudioConfig(cfg)
+}
+
+func TestProcessor_SelectAudioProfile(t *testing.T) {
+	t.Parallel()
+
+	p := newProfileTestProcessor(t, config.AudioConfig{
+		DefaultProfile: "voice",
+		Profiles:       map[string]config.AudioProfile{"Music": {HighpassHz: 200}},
+	})
+
+	name, filters, err := p.selectAudioProfile(ProcessRequest{})
+	if err != nil || name != "voice" || !strings.Contains(filters, "loudnorm") {
+		t.Fatalf("default profile: name=%q filters=%q err=%v", name, filters, err)
+	}
+
+	name, filters, err = p.selectAudioProfile(ProcessRequest{Options: map[string]interface{}{"audio_profile": "music"}})
+	if err != nil || name != "music" || filters != "highpass=f=200" {
+		t.Fatalf("request profile: name=%q filters=%q err=%v", name, filters, err)
+	}
+
+	name, filters, err = p.selectAudioProfile(ProcessRequest{Options: map[string]interface{}{"audio_profile": ProfileNone}})
+	if err != nil || name != ProfileNone || filters != "" {
+		t.Fatalf("none profile: name=%q filters=%q err=%v", name, filters, err)
+	}
+
+	req := ProcessRequest{
+		Audio:       testutil.LoadTestAudio(t, "test.wav"),
+		AudioFormat: "wav",
+		Task:        prompt.TaskTranscribe,
+		Options:     map[string]interface{}{"audio_profile": "studio"},
+	}
+	if err := p.Validate(req); err == nil {
+		t.Fatalf("expected validation error for unknown audio_profile")
+	}
+}
+
+func TestProcessor_ProcessDirect_AppliesAudioProfile(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	wavFile := filepath.Join(t.TempDir(), "out.wav")
+	if err := os.WriteFile(wavFile, encodeWAVPCM16(make([]float32, 16000), asrSampleRate), 0600); err != nil {
+		t.Fatalf("write wav: %v", err)
+	}
+	installFakeFFmpeg(t, `cat > /dev/null; echo "$@" > `+argsFile+`; cat `+wavFile)
+
+	p := newProfileTestProcessor(t, config.AudioConfig{})
+	resp, _, err := p.ProcessDirect(context.Background(), ProcessRequest{
+		Audio:       testutil.LoadTestAudio(t, "test.wav"),
+		AudioFormat: "wav",
+		Task:        prompt.TaskTranscribe,
+		Options:     map[string]interface{}{"audio_profile": "noisy"},
+	})
+	if err != nil {
+		t.Fatalf("ProcessDirect: %v", err)
+	}
+	if resp.Metadata["audio_profile"] != "noisy" || resp.Metadata["audio_profile_applied"] != true {
+		t.Fatalf("unexpected profile metadata: %v", resp.Metadata)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("ffmpeg was not invoked: %v", err)
+	}
+	if !strings.Contains(string(args), "-af highpass=f=100,afftdn=nr=12,") {
+		t.Fatalf("ffmpeg args missing filter chain: %s", args)
+	}
+}
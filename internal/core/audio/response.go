@@ -2,8 +2,9 @@
 package audio
 
 import (
+	"bytes"
 	"context"
-	"fmt"
+	"errors"
 	"strings"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/correction"
@@ -23,6 +24,21 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 		return nil, err
 	}
 
+	req = withResolvedFormat(req)
+	if req.detectedFormat != "" && req.declaredFormat != req.AudioFormat {
+		fields := logrus.Fields{"declared_format": req.declaredFormat, "detected_format": req.detectedFormat}
+		if requestID != "" {
+			fields[logging.FieldRequestID] = requestID
+		}
+		p.logger.WithFields(fields).Info("Using audio format detected from content")
+	}
+
+	// 归档需要原始音频；请求缓冲区在转换后会归还到池中，因此先复制
+	var archivedOriginal []byte
+	if p.shouldArchive(req) {
+		archivedOriginal = bytes.Clone(req.Audio)
+	}
+
 	// Validate audio data (best-effort).
 	if err := p.audioConverter.ValidateAudioData(req.Audio, req.AudioFormat); err != nil {
 		entry := p.logger.WithError(err)
@@ -36,9 +52,14 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 	audioData := req.Audio
 	audioFormat := req.AudioFormat
 	conversionApplied := false
+	profileName, profileFilters, _ := p.selectAudioProfile(req)
+	appliedProfile := ""
 
-	if p.audioConverter.IsConversionNeeded(req.AudioFormat) {
-		convertedData, err := p.audioConverter.ConvertToWAV(req.Audio, req.AudioFormat)
+	if p.needsConversion(req) {
+		convertedData, applied, err := p.convertAudio(ctx, req)
+		if errors.Is(err, ErrConversionCanceled) {
+			return nil, err
+		}
 		if err != nil {
 			p.logger.WithError(err).Warn("Audio conversion failed, using original format")
 		} else {
@@ -46,6 +67,13 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 			audioData = convertedData
 			audioFormat = "wav"
 			conversionApplied = true
+			appliedProfile = applied
+			// 压缩格式的时长只有在解码后才能得知
+			if duration, ok := audioDuration(audioData, audioFormat, 0, 0); ok {
+				if err := checkDuration(duration, p.effectiveLimits(req)); err != nil {
+					return nil, err
+				}
+			}
 			fields := logrus.Fields{
 				"original_format":  req.AudioFormat,
 				"converted_format": "wav",
@@ -64,10 +92,33 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 
 	dictionary := correction.MergeDictionaries(p.correction.GlobalDictionary, req.UserDictionary)
 
-	selected, err := p.selectPipeline(req.Task)
+	selected, requested, err := p.selectPipeline(req)
 	if err != nil {
 		return nil, err
 	}
+	// 显式指定的 pipeline 优先于 direct_audio
+	if !requested && p.useDirectAudio(req, audioFormat, len(audioData)) {
+		selected = pipeline.AudioDirect()
+	} else if !requested && directAudioRequested(req.Options) {
+		entry := p.logger.WithField("audio_format", audioFormat)
+		if requestID != "" {
+			entry = entry.WithField(logging.FieldRequestID, requestID)
+		}
+		entry.Info("Direct audio requested but no audio-capable backend available, falling back to ASR")
+	}
+	if len(tool.GlossaryTerms(dictionary)) > 0 {
+		selected = pipeline.WithGlossaryCheck(selected)
+	}
+	if pipeline.SpeechRequested(req.Options) {
+		if _, ok := p.toolRegistry.Get("tts"); !ok {
+			return nil, coreerrors.NewValidationError("tts is not configured on this server", nil)
+		}
+		selected = pipeline.WithTTS(selected)
+	}
+	if selected.UsesTool("moderate") {
+		// 中间结果未经审核，含 moderate 步骤的 pipeline 只返回最终结果
+		ctx = tool.WithPartialListener(ctx, nil)
+	}
 
 	pctx := &tool.PipelineContext{
 		RequestID: requestID,
@@ -92,6 +143,18 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 	if err != nil {
 		return nil, err
 	}
+	if profileName != "" {
+		// 记录预处理配置，便于对比不同配置下的识别准确率
+		resp.Metadata["audio_profile"] = profileName
+		resp.Metadata["audio_profile_applied"] = appliedProfile != "" || profileFilters == ""
+	}
+	if archivedOriginal != nil {
+		var normalized []byte
+		if conversionApplied {
+			normalized = audioData
+		}
+		p.archiveResult(requestID, req, archivedOriginal, normalized, resp)
+	}
 
 	sourceLangForMetrics := req.SourceLanguage
 	if sourceLangForMetrics == "" {
@@ -117,7 +180,8 @@ func (p *Processor) buildPipelineResponse(
 	processedFormat string,
 	conversionApplied bool,
 ) (*ProcessResponse, string, error) {
-	asrOut := outCtx.StepOutputs["asr_result"].Data
+	asrKey := transcriptionStepKey(selected)
+	asrOut := outCtx.StepOutputs[asrKey].Data
 	transcription, _ := asrOut["text"].(string)
 	asrLanguage, _ := asrOut["language"].(string)
 
@@ -127,16 +191,23 @@ func (p *Processor) buildPipelineResponse(
 	resp.Transcription = transcription
 	resp.Metadata["pipeline"] = selected.Name
 	resp.Metadata["asr_language"] = asrLanguage
-	resp.Metadata["asr_duration_ms"] = outCtx.Metrics["asr_result"].Milliseconds()
-	resp.Metadata["original_format"] = req.AudioFormat
+	resp.Metadata["asr_duration_ms"] = outCtx.Metrics[asrKey].Milliseconds()
+	resp.Metadata["original_format"] = originalFormat(req)
 	resp.Metadata["processed_format"] = processedFormat
 	resp.Metadata["conversion_applied"] = conversionApplied
+	addFormatMetadata(resp.Metadata, req)
+	if hit, ok := outCtx.StepOutputs[asrKey].Metadata["cache_hit"].(bool); ok {
+		resp.Metadata["asr_cache_hit"] = hit
+	}
 
 	stepDurations := make(map[string]int64)
 	for k, d := range outCtx.Metrics {
 		stepDurations[k] = d.Milliseconds()
 	}
 	resp.Metadata["step_durations_ms"] = stepDurations
+	if len(outCtx.StepOutcomes) > 0 {
+		resp.Metadata["step_outcomes"] = outCtx.StepOutcomes
+	}
 
 	if p.logger != nil {
 		p.logger.WithFields(logrus.Fields{
@@ -214,13 +285,70 @@ func (p *Processor) buildPipelineResponse(
 		for k, v := range translateOut.Metadata {
 			resp.Metadata[k] = v
 		}
+	case pipeline.PipelineAudioDirect:
+		directOut := outCtx.StepOutputs["asr_result"]
+		if v, ok := directOut.Data["raw_response"].(string); ok {
+			resp.RawResponse = v
+		}
+		if translations, ok := directOut.Data["translations"].(map[string]string); ok {
+			for k, v := range translations {
+				resp.Translations[k] = v
+			}
+		}
+		for k, v := range directOut.Metadata {
+			resp.Metadata[k] = v
+		}
 	default:
-		return nil, "", coreerrors.NewInternalError(fmt.Sprintf("unknown pipeline: %s", selected.Name), nil)
+		// 配置定义的 pipeline 按约定字段汇总各步骤输出
+		result := pipeline.Collect(selected, outCtx)
+		resp.CorrectedText = result.CorrectedText
+		resp.RawResponse = result.RawResponse
+		resp.Moderation = result.Moderation
+		resp.Speech = result.Speech
+		if result.Text != nil {
+			resp.Transcription = *result.Text
+		}
+		for k, v := range result.Translations {
+			resp.Translations[k] = v
+		}
+		for k, v := range result.Metadata {
+			resp.Metadata[k] = v
+		}
+	}
+
+	// 术语校验步骤可能替换了部分语言的译文
+	if glossaryOut, ok := outCtx.StepOutputs["glossary_result"]; ok {
+		if translations, ok := glossaryOut.Data["translations"].(map[string]string); ok {
+			for k, v := range translations {
+				resp.Translations[k] = v
+			}
+		}
+		for k, v := range glossaryOut.Metadata {
+			resp.Metadata[k] = v
+		}
+	}
+	if ttsOut, ok := outCtx.StepOutputs["tts_result"]; ok {
+		if speech, ok := ttsOut.Data["speech"].(tool.SpeechResult); ok {
+			resp.Speech = &speech
+		}
+		for k, v := range ttsOut.Metadata {
+			resp.Metadata[k] = v
+		}
 	}
 
 	return resp, asrLanguage, nil
 }
 
+// transcriptionStepKey returns the output key of the step that produces the transcription.
+func transcriptionStepKey(p pipeline.Pipeline) string {
+	for _, step := range p.Steps {
+		if step.ToolName == "asr" || step.ToolName == "audio_llm" {
+			return step.OutputKey
+		}
+	}
+	return "asr_result"
+}
+
 func previewResponseText(s string) string {
 	s = strings.TrimSpace(s)
 	if len(s) <= 240 {
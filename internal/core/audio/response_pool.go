@@ -19,6 +19,8 @@ func acquireProcessResponse() *ProcessResponse {
 	resp.CorrectedText = ""
 	resp.RawResponse = ""
 	resp.ProcessingTime = 0
+	resp.Moderation = nil
+	resp.Speech = nil
 	for k := range resp.Translations {
 		delete(resp.Translations, k)
 	}
@@ -40,6 +42,8 @@ func (r *ProcessResponse) Release() {
 	r.CorrectedText = ""
 	r.RawResponse = ""
 	r.ProcessingTime = 0
+	r.Moderation = nil
+	r.Speech = nil
 	for k := range r.Translations {
 		delete(r.Translations, k)
 	}
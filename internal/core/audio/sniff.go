@@ -0,0 +1,123 @@
+// sniff.go detects audio container formats from magic bytes.
+package audio
+
+import (
+	"bytes"
+	"strings"
+)
+
+// FormatAuto asks the server to detect the audio format from the content.
+const FormatAuto = "auto"
+
+// DetectFormat returns the audio format identified by the leading magic bytes,
+// or "" when the content is not recognised (e.g. headerless PCM).
+// Results are one of: wav, flac, opus, ogg, mp3, aac, m4a, webm, amr.
+func DetectFormat(data []byte) string {
+	format, _ := detectFormat(data)
+	return format
+}
+
+// detectFormat also reports whether the match came from a container signature (strong)
+// rather than a bare two-byte MPEG frame sync, which headerless PCM can hit by chance.
+func detectFormat(data []byte) (format string, strong bool) {
+	switch {
+	case len(data) >= 12 && bytes.HasPrefix(data, []byte("RIFF")) && string(data[8:12]) == "WAVE":
+		return "wav", true
+	case bytes.HasPrefix(data, []byte("fLaC")):
+		return "flac", true
+	case bytes.HasPrefix(data, []byte("OggS")):
+		// The first Ogg page carries the codec identification header at offset 28.
+		if len(data) >= 36 && string(data[28:36]) == "OpusHead" {
+			return "opus", true
+		}
+		return "ogg", true
+	case bytes.HasPrefix(data, []byte("#!AMR")):
+		// Covers both "#!AMR\n" (narrowband) and "#!AMR-WB\n" (wideband).
+		return "amr", true
+	case bytes.HasPrefix(data, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		// EBML header; WebM is the only Matroska flavour accepted as audio here.
+		return "webm", true
+	case len(data) >= 8 && string(data[4:8]) == "ftyp":
+		return "m4a", true
+	case bytes.HasPrefix(data, []byte("ID3")):
+		return "mp3", true
+	case len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		// MPEG frame sync. Layer bits 00 mean ADTS (AAC); anything else is MPEG audio.
+		if data[1]&0x06 == 0 {
+			return "aac", false
+		}
+		return "mp3", false
+	default:
+		return "", false
+	}
+}
+
+// formatFamily groups declared formats that share a container so that e.g.
+// "opus" declared for an Ogg stream is not treated as a mismatch.
+func formatFamily(format string) string {
+	switch format {
+	case "opus", "ogg":
+		return "ogg"
+	case "m4a", "mp4", "aac", "3gp":
+		return "mp4"
+	default:
+		return format
+	}
+}
+
+// resolveAudioFormat picks the effective format for data. An empty or "auto" declared
+// format is replaced by the detected one; when the declared format disagrees with the
+// content, the detected format wins. detected is "" when sniffing found nothing.
+func resolveAudioFormat(data []byte, declared string) (effective, detected string) {
+	declared = strings.ToLower(strings.TrimSpace(declared))
+	detected, strong := detectFormat(data)
+	switch {
+	case detected == "":
+		return declared, ""
+	case IsRawPCMFormat(declared) && !strong:
+		// A frame-sync match is not evidence enough to overrule declared raw PCM.
+		return declared, ""
+	case declared == "" || declared == FormatAuto:
+		return detected, detected
+	case formatFamily(declared) == formatFamily(detected):
+		// ADTS AAC is sniffed as "aac"; keep the more specific declared name within a family.
+		return declared, detected
+	default:
+		return detected, detected
+	}
+}
+
+// withResolvedFormat returns req with AudioFormat replaced by the effective format
+// and the declared/detected formats remembered for response metadata.
+func withResolvedFormat(req ProcessRequest) ProcessRequest {
+	if req.declaredFormat != "" || req.detectedFormat != "" {
+		return req
+	}
+	declared := req.AudioFormat
+	effective, detected := resolveAudioFormat(req.Audio, declared)
+	req.AudioFormat = effective
+	req.declaredFormat = strings.ToLower(strings.TrimSpace(declared))
+	req.detectedFormat = detected
+	return req
+}
+
+// originalFormat returns the format the client declared, or the effective format when
+// it declared none or auto. The sniffed format is reported separately as detected_format.
+func originalFormat(req ProcessRequest) string {
+	if req.declaredFormat != "" && req.declaredFormat != FormatAuto {
+		return req.declaredFormat
+	}
+	return req.AudioFormat
+}
+
+// addFormatMetadata records the declared and detected formats when sniffing was involved.
+func addFormatMetadata(metadata map[string]interface{}, req ProcessRequest) {
+	if req.detectedFormat == "" {
+		return
+	}
+	metadata["declared_format"] = req.declaredFormat
+	metadata["detected_format"] = req.detectedFormat
+	if req.declaredFormat != "" && req.declaredFormat != FormatAuto && req.declaredFormat != req.AudioFormat {
+		metadata["format_mismatch"] = true
+	}
+}
@@ -0,0 +1,72 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+)
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	oggOpus := append([]byte("OggS"), make([]byte, 24)...)
+	oggOpus = append(oggOpus, []byte("OpusHead")...)
+	oggVorbis := append([]byte("OggS"), make([]byte, 24)...)
+	oggVorbis = append(oggVorbis, []byte("\x01vorbis\x00")...)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"wav", encodeWAVPCM16(make([]float32, 16), asrSampleRate), "wav"},
+		{"flac", []byte("fLaC\x00\x00\x00\x22"), "flac"},
+		{"ogg opus", oggOpus, "opus"},
+		{"ogg vorbis", oggVorbis, "ogg"},
+		{"mp3 id3", []byte("ID3\x04\x00\x00\x00\x00\x00\x00"), "mp3"},
+		{"mp3 frame sync", []byte{0xFF, 0xFB, 0x90, 0x64}, "mp3"},
+		{"aac adts", []byte{0xFF, 0xF1, 0x50, 0x80}, "aac"},
+		{"m4a", []byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00"), "m4a"},
+		{"webm", []byte{0x1A, 0x45, 0xDF, 0xA3, 0x9F, 0x42, 0x86, 0x81}, "webm"},
+		{"amr", []byte("#!AMR\n\x3c"), "amr"},
+		{"amr-wb", []byte("#!AMR-WB\n\x04"), "amr"},
+		{"unknown", []byte{0x01, 0x02, 0x03, 0x04}, ""},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.data); got != tt.want {
+			t.Errorf("%s: DetectFormat=%q want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := DetectFormat(testutil.LoadTestAudio(t, "test.opus")); got != "opus" {
+		t.Errorf("test.opus: DetectFormat=%q want opus", got)
+	}
+}
+
+func TestResolveAudioFormat(t *testing.T) {
+	t.Parallel()
+
+	wav := encodeWAVPCM16(make([]float32, 16), asrSampleRate)
+	tests := []struct {
+		name         string
+		data         []byte
+		declared     string
+		wantFormat   string
+		wantDetected string
+	}{
+		{"auto", wav, "auto", "wav", "wav"},
+		{"omitted", wav, "", "wav", "wav"},
+		{"matching", wav, "WAV", "wav", "wav"},
+		{"mismatch uses detected", wav, "mp3", "wav", "wav"},
+		{"same container family keeps declared", []byte{0xFF, 0xF1, 0x50, 0x80}, "m4a", "m4a", "aac"},
+		{"undetectable keeps declared", []byte{0x01, 0x02}, "pcm_s16le", "pcm_s16le", ""},
+		{"frame sync does not overrule raw pcm", []byte{0xFF, 0xFB, 0x00, 0x00}, "pcm_s16le", "pcm_s16le", ""},
+	}
+	for _, tt := range tests {
+		format, detected := resolveAudioFormat(tt.data, tt.declared)
+		if format != tt.wantFormat || detected != tt.wantDetected {
+			t.Errorf("%s: got (%q, %q) want (%q, %q)", tt.name, format, detected, tt.wantFormat, tt.wantDetected)
+		}
+	}
+}
@@ -1,14 +1,23 @@
-// transcribe.go contains a helper ASR transcription method (legacy / diagnostic usage).
+// transcribe.go contains the ASR-only transcription path (interim streaming results, diagnostics).
 package audio
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 )
 
+// Transcribe runs format conversion and ASR only, without correction or translation.
+// It is used for interim results of streaming sessions.
+func (p *Processor) Transcribe(ctx context.Context, req ProcessRequest) (*asr.ASRResponse, error) {
+	req = withResolvedFormat(req)
+	resp, _, _, _, err := p.transcribe(ctx, req)
+	return resp, err
+}
+
 func (p *Processor) transcribe(ctx context.Context, req ProcessRequest) (*asr.ASRResponse, string, bool, time.Duration, error) {
 	if p.asrManager == nil {
 		return nil, "", false, 0, coreerrors.NewInternalError("asr manager not configured", nil)
@@ -18,8 +27,11 @@ func (p *Processor) transcribe(ctx context.Context, req ProcessRequest) (*asr.AS
 	audioFormat := req.AudioFormat
 	conversionApplied := false
 
-	if p.audioConverter.IsConversionNeeded(req.AudioFormat) {
-		convertedData, err := p.audioConverter.ConvertToWAV(req.Audio, req.AudioFormat)
+	if p.needsConversion(req) {
+		convertedData, _, err := p.convertAudio(ctx, req)
+		if errors.Is(err, ErrConversionCanceled) {
+			return nil, "", false, 0, err
+		}
 		if err != nil {
 			p.logger.WithError(err).Warn("Audio conversion failed, using original format")
 		} else {
@@ -8,12 +8,15 @@ import (
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
 )
 
 // ProcessRequest 音频处理请求
 type ProcessRequest struct {
 	Audio           []byte                  `json:"audio"`
 	AudioFormat     string                  `json:"audio_format"`
+	SampleRate      int                     `json:"sample_rate,omitempty"` // 仅 pcm_s16le / pcm_f32le
+	Channels        int                     `json:"channels,omitempty"`    // 仅 pcm_s16le / pcm_f32le，默认 1
 	Task            prompt.TaskType         `json:"task"`
 	SourceLanguage  string                  `json:"source_language,omitempty"`
 	TargetLanguages []string                `json:"target_languages"` // 接收短代码
@@ -21,6 +24,15 @@ type ProcessRequest struct {
 	// 移除Template字段，使用硬编码的默认模板
 	// 移除 UserPrompt，改为服务端控制
 	Options map[string]interface{} `json:"options,omitempty"`
+	// Limits overrides the processor's configured audio limits (e.g. per-identity limits set by the API layer).
+	Limits *Limits `json:"-"`
+	// CallerID identifies the authenticated caller; ArchiveOptIn is set when the caller opted into archival.
+	CallerID     string `json:"-"`
+	ArchiveOptIn bool   `json:"-"`
+
+	// declaredFormat/detectedFormat are filled by withResolvedFormat for response metadata.
+	declaredFormat string
+	detectedFormat string
 
 	cleanup     func()
 	cleanupOnce *sync.Once
@@ -61,6 +73,8 @@ type ProcessResponse struct {
 	RawResponse    string                 `json:"raw_response"`
 	ProcessingTime float64                `json:"processing_time"`
 	Metadata       map[string]interface{} `json:"metadata"`
+	Moderation     *tool.ModerationResult `json:"moderation,omitempty"` // 仅含 moderate 步骤的 pipeline
+	Speech         *tool.SpeechResult     `json:"speech,omitempty"`     // 仅在请求 options.tts 时返回
 }
 
 func (r *ProcessResponse) SetProcessingTime(seconds float64) {
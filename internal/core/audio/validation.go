@@ -2,7 +2,10 @@
 package audio
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
@@ -14,30 +17,66 @@ func (p *Processor) Validate(req ProcessRequest) error {
 		return coreerrors.NewValidationError("audio data is required", nil)
 	}
 
-	if req.AudioFormat == "" {
-		return coreerrors.NewValidationError("audio format is required", nil)
+	// audio_format 可省略或为 auto，此时根据文件头识别
+	req = withResolvedFormat(req)
+	if req.AudioFormat == "" || req.AudioFormat == FormatAuto {
+		return coreerrors.NewValidationError("audio format is required: could not detect the format from the audio content", nil)
 	}
 
-	// 验证音频大小限制（32MB）
-	maxSize := 32 * 1024 * 1024
-	if len(req.Audio) > maxSize {
+	limits := p.effectiveLimits(req)
+
+	// 验证音频大小限制（audio.max_size_bytes）
+	if len(req.Audio) > limits.MaxSizeBytes {
 		return coreerrors.NewValidationError(
-			fmt.Sprintf("audio size (%d bytes) exceeds maximum allowed size (%d bytes)", len(req.Audio), maxSize),
+			fmt.Sprintf("audio size (%d bytes) exceeds maximum allowed size (%d bytes)", len(req.Audio), limits.MaxSizeBytes),
 			nil,
 		)
 	}
 
-	// 验证支持的格式
-	supportedFormats := map[string]bool{
-		"wav":  true,
-		"mp3":  true,
-		"m4a":  true,
-		"opus": true,
-		"flac": true,
+	// 验证允许的格式（audio.allowed_formats）
+	if !limits.AllowsFormat(req.AudioFormat) {
+		return coreerrors.NewValidationError(fmt.Sprintf("unsupported audio format: %s", req.AudioFormat), nil)
 	}
 
-	if !supportedFormats[req.AudioFormat] {
-		return coreerrors.NewValidationError(fmt.Sprintf("unsupported audio format: %s", req.AudioFormat), nil)
+	// 原始 PCM 没有文件头，需要显式给出采样率和声道数
+	if IsRawPCMFormat(req.AudioFormat) {
+		if req.SampleRate < minInputSampleRate || req.SampleRate > maxInputSampleRate {
+			return coreerrors.NewValidationError(fmt.Sprintf("sample_rate must be between %d and %d for %s, got %d", minInputSampleRate, maxInputSampleRate, req.AudioFormat, req.SampleRate), nil)
+		}
+		if req.Channels < 0 || req.Channels > maxInputChannels {
+			return coreerrors.NewValidationError(fmt.Sprintf("channels must be between 1 and %d for %s, got %d", maxInputChannels, req.AudioFormat, req.Channels), nil)
+		}
+		channels := req.Channels
+		if channels == 0 {
+			channels = 1
+		}
+		if frameSize := rawPCMSampleSize(req.AudioFormat) * channels; len(req.Audio)%frameSize != 0 {
+			return coreerrors.NewValidationError(fmt.Sprintf("%s audio length %d is not a multiple of the frame size %d", req.AudioFormat, len(req.Audio), frameSize), nil)
+		}
+	}
+
+	// WAV 头部声明的采样率/声道数与原始 PCM 使用相同的范围
+	if strings.EqualFold(req.AudioFormat, "wav") {
+		if _, err := parseWAV(req.Audio); errors.Is(err, errWAVLayout) {
+			return coreerrors.NewValidationError(err.Error(), err)
+		}
+	}
+
+	// WAV / 原始 PCM 的时长可直接由头部或参数计算；压缩格式在转换后检查
+	if duration, ok := audioDuration(req.Audio, req.AudioFormat, req.SampleRate, req.Channels); ok {
+		if err := checkDuration(duration, limits); err != nil {
+			return err
+		}
+	}
+
+	// 验证预处理配置（options.audio_profile）
+	if v, ok := req.Options["audio_profile"]; ok && v != nil {
+		if _, isString := v.(string); !isString {
+			return coreerrors.NewValidationError("options.audio_profile must be a string", nil)
+		}
+	}
+	if _, _, err := p.selectAudioProfile(req); err != nil {
+		return coreerrors.NewValidationError(err.Error(), err)
 	}
 
 	// 验证任务类型
@@ -54,3 +93,14 @@ func (p *Processor) Validate(req ProcessRequest) error {
 
 	return nil
 }
+
+// checkDuration rejects audio longer than the configured max_duration.
+func checkDuration(duration time.Duration, limits Limits) error {
+	if limits.MaxDuration > 0 && duration > limits.MaxDuration {
+		return coreerrors.NewValidationError(
+			fmt.Sprintf("audio duration (%s) exceeds maximum allowed duration (%s)", duration.Round(time.Millisecond), limits.MaxDuration),
+			nil,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,371 @@
+// wav.go implements a pure-Go WAV reader/writer used to normalize WAV input without ffmpeg.
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// asrSampleRate is the default output sample rate expected by ASR backends.
+	asrSampleRate = 16000
+
+	// FormatPCMS16LE is headerless signed 16-bit little-endian PCM.
+	FormatPCMS16LE = "pcm_s16le"
+	// FormatPCMF32LE is headerless 32-bit float little-endian PCM.
+	FormatPCMF32LE = "pcm_f32le"
+
+	// Sample layouts accepted for WAV and raw PCM input.
+	minInputSampleRate = 8000
+	maxInputSampleRate = 192000
+	maxInputChannels   = 8
+
+	wavFormatPCM        = 0x0001
+	wavFormatIEEEFloat  = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// errWAVLayout marks WAV/PCM input whose sample rate or channel count is out of range,
+// or whose converted output would exceed the output limit. Such input is rejected rather
+// than handed to ffmpeg.
+var errWAVLayout = errors.New("audio layout out of range")
+
+// wavInfo describes the sample layout of a decoded WAV file.
+type wavInfo struct {
+	formatTag     uint16
+	channels      int
+	sampleRate    int
+	bitsPerSample int
+	data          []byte
+}
+
+// parseWAV walks the RIFF chunks and returns the fmt description and raw data chunk.
+func parseWAV(data []byte) (*wavInfo, error) {
+	if len(data) < 12 || !bytes.HasPrefix(data, []byte("RIFF")) || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("invalid WAV file: missing RIFF/WAVE header")
+	}
+
+	info := &wavInfo{}
+	haveFmt := false
+	offset := 12
+	for offset+8 <= len(data) {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+		end := body + chunkSize
+		if end > len(data) {
+			// Streamed WAVs often carry a bogus data size; clamp to the buffer.
+			end = len(data)
+		}
+
+		switch chunkID {
+		case "fmt ":
+			if end-body < 16 {
+				return nil, fmt.Errorf("invalid WAV file: fmt chunk too short")
+			}
+			info.formatTag = binary.LittleEndian.Uint16(data[body : body+2])
+			info.channels = int(binary.LittleEndian.Uint16(data[body+2 : body+4]))
+			info.sampleRate = int(binary.LittleEndian.Uint32(data[body+4 : body+8]))
+			info.bitsPerSample = int(binary.LittleEndian.Uint16(data[body+14 : body+16]))
+			if info.formatTag == wavFormatExtensible && end-body >= 26 {
+				// The first two bytes of the SubFormat GUID carry the actual format tag.
+				info.formatTag = binary.LittleEndian.Uint16(data[body+24 : body+26])
+			}
+			haveFmt = true
+		case "data":
+			if !haveFmt {
+				return nil, fmt.Errorf("invalid WAV file: data chunk before fmt chunk")
+			}
+			info.data = data[body:end]
+			return info, info.validate()
+		}
+
+		// Chunks are padded to an even size.
+		offset = end + (chunkSize & 1)
+	}
+
+	if !haveFmt {
+		return nil, fmt.Errorf("invalid WAV file: missing fmt chunk")
+	}
+	return nil, fmt.Errorf("invalid WAV file: missing data chunk")
+}
+
+func (w *wavInfo) validate() error {
+	if w.channels <= 0 || w.channels > maxInputChannels {
+		return fmt.Errorf("%w: %d channels (must be 1..%d)", errWAVLayout, w.channels, maxInputChannels)
+	}
+	if w.sampleRate < minInputSampleRate || w.sampleRate > maxInputSampleRate {
+		return fmt.Errorf("%w: sample rate %d Hz (must be %d..%d)", errWAVLayout, w.sampleRate, minInputSampleRate, maxInputSampleRate)
+	}
+	switch w.formatTag {
+	case wavFormatPCM:
+		switch w.bitsPerSample {
+		case 8, 16, 24, 32:
+			return nil
+		}
+	case wavFormatIEEEFloat:
+		switch w.bitsPerSample {
+		case 32, 64:
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported WAV encoding: format tag 0x%04x", w.formatTag)
+	}
+	return fmt.Errorf("unsupported WAV encoding: %d-bit (format tag 0x%04x)", w.bitsPerSample, w.formatTag)
+}
+
+// isASRReady reports whether the WAV already is mono PCM16 at the target sample rate.
+func (w *wavInfo) isASRReady(targetRate int) bool {
+	return w.formatTag == wavFormatPCM && w.bitsPerSample == 16 && w.channels == 1 && w.sampleRate == targetRate
+}
+
+// duration returns the playback length of the data chunk.
+func (w *wavInfo) duration() time.Duration {
+	frameSize := w.bitsPerSample / 8 * w.channels
+	if frameSize <= 0 || w.sampleRate <= 0 {
+		return 0
+	}
+	frames := len(w.data) / frameSize
+	return time.Duration(frames) * time.Second / time.Duration(w.sampleRate)
+}
+
+// monoSamples decodes the data chunk into float samples in [-1, 1], averaging all channels.
+func (w *wavInfo) monoSamples() []float32 {
+	bytesPerSample := w.bitsPerSample / 8
+	frameSize := bytesPerSample * w.channels
+	frames := len(w.data) / frameSize
+	out := make([]float32, frames)
+
+	for i := 0; i < frames; i++ {
+		frame := w.data[i*frameSize : (i+1)*frameSize]
+		var sum float64
+		for ch := 0; ch < w.channels; ch++ {
+			sum += w.decodeSample(frame[ch*bytesPerSample : (ch+1)*bytesPerSample])
+		}
+		out[i] = float32(sum / float64(w.channels))
+	}
+	return out
+}
+
+func (w *wavInfo) decodeSample(b []byte) float64 {
+	if w.formatTag == wavFormatIEEEFloat {
+		if w.bitsPerSample == 64 {
+			return math.Float64frombits(binary.LittleEndian.Uint64(b))
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	}
+
+	switch w.bitsPerSample {
+	case 8:
+		// 8-bit PCM is unsigned.
+		return (float64(b[0]) - 128) / 128
+	case 16:
+		return float64(int16(binary.LittleEndian.Uint16(b))) / 32768
+	case 24:
+		v := int32(b[0]) | int32(b[1])<<8 | int32(b[2])<<16
+		if v&0x800000 != 0 {
+			v |= ^0xFFFFFF
+		}
+		return float64(v) / 8388608
+	default:
+		return float64(int32(binary.LittleEndian.Uint32(b))) / 2147483648
+	}
+}
+
+// resampledLen returns the number of samples resampleLinear produces for n input samples.
+func resampledLen(n, fromRate, toRate int) int {
+	if fromRate == toRate {
+		return n
+	}
+	return int(float64(n) * float64(toRate) / float64(fromRate))
+}
+
+// resampleLinear converts samples between sample rates. When downsampling, a moving
+// average over the decimation window is applied first to limit aliasing.
+func resampleLinear(samples []float32, fromRate, toRate int) []float32 {
+	if fromRate == toRate || len(samples) == 0 {
+		return samples
+	}
+
+	ratio := float64(fromRate) / float64(toRate)
+	src := samples
+	if ratio > 1 {
+		src = boxFilter(samples, int(math.Ceil(ratio)))
+	}
+
+	out := make([]float32, resampledLen(len(src), fromRate, toRate))
+	for i := range out {
+		pos := float64(i) * ratio
+		idx := int(pos)
+		frac := float32(pos - float64(idx))
+		if idx+1 < len(src) {
+			out[i] = src[idx]*(1-frac) + src[idx+1]*frac
+		} else {
+			out[i] = src[len(src)-1]
+		}
+	}
+	return out
+}
+
+func boxFilter(samples []float32, width int) []float32 {
+	if width <= 1 {
+		return samples
+	}
+	out := make([]float32, len(samples))
+	var sum float64
+	for i, s := range samples {
+		sum += float64(s)
+		if i >= width {
+			sum -= float64(samples[i-width])
+		}
+		n := width
+		if i+1 < width {
+			n = i + 1
+		}
+		out[i] = float32(sum / float64(n))
+	}
+	return out
+}
+
+// encodeWAVPCM16 writes mono float samples as a canonical 44-byte header PCM16 WAV.
+func encodeWAVPCM16(samples []float32, sampleRate int) []byte {
+	dataSize := len(samples) * 2
+	buf := make([]byte, 44+dataSize)
+
+	copy(buf[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(36+dataSize))
+	copy(buf[8:12], "WAVE")
+	copy(buf[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:20], 16)
+	binary.LittleEndian.PutUint16(buf[20:22], wavFormatPCM)
+	binary.LittleEndian.PutUint16(buf[22:24], 1)
+	binary.LittleEndian.PutUint32(buf[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(buf[28:32], uint32(sampleRate*2))
+	binary.LittleEndian.PutUint16(buf[32:34], 2)
+	binary.LittleEndian.PutUint16(buf[34:36], 16)
+	copy(buf[36:40], "data")
+	binary.LittleEndian.PutUint32(buf[40:44], uint32(dataSize))
+
+	for i, s := range samples {
+		v := float64(s) * 32767
+		if v > 32767 {
+			v = 32767
+		} else if v < -32768 {
+			v = -32768
+		}
+		binary.LittleEndian.PutUint16(buf[44+i*2:], uint16(int16(math.Round(v))))
+	}
+	return buf
+}
+
+// normalizeWAV converts any supported WAV into mono PCM16 at targetRate.
+// The input is returned unchanged (changed=false) when it already matches.
+// maxSamples caps the converted output (0 = unlimited).
+func normalizeWAV(data []byte, targetRate, maxSamples int) ([]byte, bool, error) {
+	info, err := parseWAV(data)
+	if err != nil {
+		return nil, false, err
+	}
+	if info.isASRReady(targetRate) {
+		return data, false, nil
+	}
+	out, err := info.toASRWAV(targetRate, maxSamples)
+	return out, err == nil, err
+}
+
+// pcmToWAV wraps headerless little-endian PCM (pcm_s16le / pcm_f32le) into a mono PCM16 WAV at targetRate.
+// Input that already is mono s16le at targetRate only gets a header prepended.
+// maxSamples caps the converted output (0 = unlimited).
+func pcmToWAV(data []byte, format string, sampleRate, channels, targetRate, maxSamples int) ([]byte, error) {
+	info := &wavInfo{
+		channels:   channels,
+		sampleRate: sampleRate,
+		data:       data,
+	}
+	switch format {
+	case FormatPCMS16LE:
+		info.formatTag, info.bitsPerSample = wavFormatPCM, 16
+	case FormatPCMF32LE:
+		info.formatTag, info.bitsPerSample = wavFormatIEEEFloat, 32
+	default:
+		return nil, fmt.Errorf("unsupported raw PCM format: %s", format)
+	}
+	if err := info.validate(); err != nil {
+		return nil, err
+	}
+
+	if info.isASRReady(targetRate) {
+		frames := len(data) / 2
+		out := encodeWAVPCM16(nil, targetRate)
+		binary.LittleEndian.PutUint32(out[4:8], uint32(36+frames*2))
+		binary.LittleEndian.PutUint32(out[40:44], uint32(frames*2))
+		return append(out, data[:frames*2]...), nil
+	}
+	return info.toASRWAV(targetRate, maxSamples)
+}
+
+func (w *wavInfo) toASRWAV(targetRate, maxSamples int) ([]byte, error) {
+	frames := len(w.data) / (w.bitsPerSample / 8 * w.channels)
+	if n := resampledLen(frames, w.sampleRate, targetRate); maxSamples > 0 && n > maxSamples {
+		return nil, fmt.Errorf("%w: %d output samples exceed the limit of %d", errWAVLayout, n, maxSamples)
+	}
+	samples := resampleLinear(w.monoSamples(), w.sampleRate, targetRate)
+	return encodeWAVPCM16(samples, targetRate), nil
+}
+
+// maxOutputSamples bounds the converted output for the given limits: the configured duration
+// at targetRate, or else the largest allowed input (one byte per frame at the lowest rate).
+func maxOutputSamples(maxSizeBytes int, maxDuration time.Duration, targetRate int) int {
+	if maxDuration > 0 {
+		// 留一秒余量，避免取整导致刚好达到上限的音频被拒绝
+		return int(maxDuration.Seconds()*float64(targetRate)) + targetRate
+	}
+	if maxSizeBytes <= 0 {
+		return 0
+	}
+	return resampledLen(maxSizeBytes, minInputSampleRate, targetRate)
+}
+
+// audioDuration returns the playback length of WAV or raw PCM input.
+// ok is false for compressed formats whose length cannot be derived without decoding.
+func audioDuration(data []byte, format string, sampleRate, channels int) (time.Duration, bool) {
+	switch {
+	case strings.ToLower(format) == "wav":
+		info, err := parseWAV(data)
+		if err != nil {
+			return 0, false
+		}
+		return info.duration(), true
+	case IsRawPCMFormat(format):
+		if channels <= 0 {
+			channels = 1
+		}
+		info := &wavInfo{channels: channels, sampleRate: sampleRate, bitsPerSample: rawPCMSampleSize(format) * 8, data: data}
+		return info.duration(), sampleRate > 0
+	default:
+		return 0, false
+	}
+}
+
+// IsRawPCMFormat reports whether format denotes headerless PCM that needs sample_rate/channels.
+func IsRawPCMFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case FormatPCMS16LE, FormatPCMF32LE:
+		return true
+	default:
+		return false
+	}
+}
+
+// rawPCMSampleSize returns the byte width of one sample for a raw PCM format.
+func rawPCMSampleSize(format string) int {
+	if strings.ToLower(format) == FormatPCMF32LE {
+		return 4
+	}
+	return 2
+}
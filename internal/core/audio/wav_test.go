@@ -0,0 +1,205 @@
+package audio
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+)
+
+// buildTestWAV assembles a WAV with the given fmt fields and interleaved sample bytes.
+func buildTestWAV(formatTag uint16, channels, sampleRate, bitsPerSample int, data []byte) []byte {
+	blockAlign := channels * bitsPerSample / 8
+	buf := make([]byte, 44, 44+len(data))
+	copy(buf[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(36+len(data)))
+	copy(buf[8:12], "WAVE")
+	copy(buf[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:20], 16)
+	binary.LittleEndian.PutUint16(buf[20:22], formatTag)
+	binary.LittleEndian.PutUint16(buf[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(buf[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(buf[28:32], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(buf[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(buf[34:36], uint16(bitsPerSample))
+	copy(buf[36:40], "data")
+	binary.LittleEndian.PutUint32(buf[40:44], uint32(len(data)))
+	return append(buf, data...)
+}
+
+func TestNormalizeWAV_Float32StereoDownmixAndResample(t *testing.T) {
+	t.Parallel()
+
+	// 48kHz stereo float32: left=0.5, right=-0.5 except the second half where both are 0.5.
+	const frames = 4800
+	data := make([]byte, frames*2*4)
+	for i := 0; i < frames; i++ {
+		left, right := float32(0.5), float32(-0.5)
+		if i >= frames/2 {
+			right = 0.5
+		}
+		binary.LittleEndian.PutUint32(data[i*8:], math.Float32bits(left))
+		binary.LittleEndian.PutUint32(data[i*8+4:], math.Float32bits(right))
+	}
+
+	out, changed, err := normalizeWAV(buildTestWAV(wavFormatIEEEFloat, 2, 48000, 32, data), asrSampleRate, 0)
+	if err != nil {
+		t.Fatalf("normalizeWAV: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected conversion")
+	}
+
+	info, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV: %v", err)
+	}
+	if !info.isASRReady(asrSampleRate) {
+		t.Fatalf("expected 16kHz mono PCM16, got rate=%d channels=%d bits=%d", info.sampleRate, info.channels, info.bitsPerSample)
+	}
+	samples := info.monoSamples()
+	if len(samples) != frames/3 {
+		t.Fatalf("samples=%d want %d", len(samples), frames/3)
+	}
+	if v := samples[10]; math.Abs(float64(v)) > 0.01 {
+		t.Fatalf("expected downmixed silence in first half, got %f", v)
+	}
+	if v := samples[len(samples)-10]; math.Abs(float64(v)-0.5) > 0.01 {
+		t.Fatalf("expected 0.5 in second half, got %f", v)
+	}
+}
+
+func TestNormalizeWAV_PCM24(t *testing.T) {
+	t.Parallel()
+
+	// 8kHz mono PCM24 at half scale (upsampled to 16kHz).
+	const frames = 800
+	data := make([]byte, frames*3)
+	half := int32(1 << 22)
+	for i := 0; i < frames; i++ {
+		v := half
+		if i%2 == 1 {
+			v = -half
+		}
+		data[i*3] = byte(v)
+		data[i*3+1] = byte(v >> 8)
+		data[i*3+2] = byte(v >> 16)
+	}
+
+	out, changed, err := normalizeWAV(buildTestWAV(wavFormatPCM, 1, 8000, 24, data), asrSampleRate, 0)
+	if err != nil {
+		t.Fatalf("normalizeWAV: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected conversion")
+	}
+
+	info, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV: %v", err)
+	}
+	samples := info.monoSamples()
+	if len(samples) != frames*2 {
+		t.Fatalf("samples=%d want %d", len(samples), frames*2)
+	}
+	if v := samples[0]; math.Abs(float64(v)-0.5) > 0.01 {
+		t.Fatalf("sample[0]=%f want 0.5", v)
+	}
+	if v := samples[2]; math.Abs(float64(v)+0.5) > 0.01 {
+		t.Fatalf("sample[2]=%f want -0.5", v)
+	}
+}
+
+func TestNormalizeWAV_RejectsUnsupportedEncoding(t *testing.T) {
+	t.Parallel()
+
+	// Format tag 0x0002 is MS ADPCM.
+	if _, _, err := normalizeWAV(buildTestWAV(0x0002, 1, 16000, 4, make([]byte, 100)), asrSampleRate, 0); err == nil {
+		t.Fatalf("expected error for ADPCM wav")
+	}
+}
+
+func TestNormalizeWAV_RejectsOutOfRangeLayout(t *testing.T) {
+	t.Parallel()
+
+	// A 1 Hz header would otherwise be upsampled 16000x.
+	tiny := buildTestWAV(wavFormatPCM, 1, 1, 16, make([]byte, 4096))
+	if _, _, err := normalizeWAV(tiny, asrSampleRate, 0); !errors.Is(err, errWAVLayout) {
+		t.Fatalf("1 Hz header: err=%v want errWAVLayout", err)
+	}
+	if _, _, err := normalizeWAV(buildTestWAV(wavFormatPCM, 16, 16000, 16, make([]byte, 64)), asrSampleRate, 0); !errors.Is(err, errWAVLayout) {
+		t.Fatalf("16 channels: err=%v want errWAVLayout", err)
+	}
+
+	// 1s at 8kHz becomes 16000 samples, above a 10000 sample cap.
+	pcm := make([]byte, 16000)
+	if _, err := pcmToWAV(pcm, FormatPCMS16LE, 8000, 1, asrSampleRate, 10000); !errors.Is(err, errWAVLayout) {
+		t.Fatalf("output cap: err=%v want errWAVLayout", err)
+	}
+	if _, err := pcmToWAV(pcm, FormatPCMS16LE, 8000, 1, asrSampleRate, maxOutputSamples(len(pcm), 0, asrSampleRate)); err != nil {
+		t.Fatalf("within cap: %v", err)
+	}
+
+	c := NewAudioConverter(testutil.NewTestLogger())
+	if _, err := c.ConvertToWAV(context.Background(), tiny, "wav"); !errors.Is(err, errWAVLayout) {
+		t.Fatalf("ConvertToWAV: err=%v want errWAVLayout without ffmpeg fallback", err)
+	}
+}
+
+func TestPCMToWAV_WrapsCanonicalS16LE(t *testing.T) {
+	t.Parallel()
+
+	pcm := make([]byte, 3200)
+	for i := range pcm {
+		pcm[i] = byte(i)
+	}
+
+	out, err := pcmToWAV(pcm, FormatPCMS16LE, 16000, 1, asrSampleRate, 0)
+	if err != nil {
+		t.Fatalf("pcmToWAV: %v", err)
+	}
+	info, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV: %v", err)
+	}
+	if !info.isASRReady(asrSampleRate) {
+		t.Fatalf("expected 16kHz mono PCM16 output")
+	}
+	if string(info.data) != string(pcm) {
+		t.Fatalf("expected PCM payload to be copied verbatim")
+	}
+}
+
+func TestPCMToWAV_NormalizesF32LEStereo(t *testing.T) {
+	t.Parallel()
+
+	// 32kHz stereo float32, 0.25 on both channels.
+	const frames = 3200
+	pcm := make([]byte, frames*2*4)
+	for i := 0; i < frames*2; i++ {
+		binary.LittleEndian.PutUint32(pcm[i*4:], math.Float32bits(0.25))
+	}
+
+	out, err := pcmToWAV(pcm, FormatPCMF32LE, 32000, 2, asrSampleRate, 0)
+	if err != nil {
+		t.Fatalf("pcmToWAV: %v", err)
+	}
+	info, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV: %v", err)
+	}
+	samples := info.monoSamples()
+	if len(samples) != frames/2 {
+		t.Fatalf("samples=%d want %d", len(samples), frames/2)
+	}
+	if v := samples[len(samples)/2]; math.Abs(float64(v)-0.25) > 0.01 {
+		t.Fatalf("sample=%f want 0.25", v)
+	}
+
+	if _, err := pcmToWAV(pcm, FormatPCMF32LE, 0, 2, asrSampleRate, 0); err == nil {
+		t.Fatalf("expected error for missing sample rate")
+	}
+}
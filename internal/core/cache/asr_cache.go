@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+)
+
+// CachedTranscription represents a cached ASR result.
+type CachedTranscription struct {
+	Response *asr.ASRResponse
+	CachedAt time.Time
+}
+
+// TranscriptionCache stores ASR results keyed by an audio content hash.
+type TranscriptionCache interface {
+	Get(key string) (*CachedTranscription, bool)
+	Set(key string, value *CachedTranscription, ttl time.Duration)
+}
+
+// TranscriptionCacheStats is a snapshot of cache effectiveness counters.
+type TranscriptionCacheStats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Entries int     `json:"entries"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+type transcriptionEntry struct {
+	value     *CachedTranscription
+	expiresAt time.Time
+}
+
+// InMemoryTranscriptionCache is an in-memory TranscriptionCache with TTL support and a size bound.
+type InMemoryTranscriptionCache struct {
+	mu      sync.RWMutex
+	store   map[string]transcriptionEntry
+	maxSize int
+	hits    int64
+	misses  int64
+}
+
+// NewInMemoryTranscriptionCache creates an InMemoryTranscriptionCache with an optional size limit.
+func NewInMemoryTranscriptionCache(maxSize int) *InMemoryTranscriptionCache {
+	if maxSize < 0 {
+		maxSize = 0
+	}
+	return &InMemoryTranscriptionCache{
+		store:   make(map[string]transcriptionEntry),
+		maxSize: maxSize,
+	}
+}
+
+func (c *InMemoryTranscriptionCache) Get(key string) (*CachedTranscription, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.store[key]
+	if ok && !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.store, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		metrics.ObserveASRCacheLookup(false)
+		return nil, false
+	}
+
+	c.hits++
+	metrics.ObserveASRCacheLookup(true)
+	return cloneCachedTranscription(entry.value), true
+}
+
+func (c *InMemoryTranscriptionCache) Set(key string, value *CachedTranscription, ttl time.Duration) {
+	if value == nil || value.Response == nil {
+		return
+	}
+
+	expiresAt := time.Time{}
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.store[key]; !exists && c.maxSize > 0 && len(c.store) >= c.maxSize {
+		c.evictLocked()
+	}
+
+	c.store[key] = transcriptionEntry{
+		value:     cloneCachedTranscription(value),
+		expiresAt: expiresAt,
+	}
+}
+
+// Stats returns a snapshot of hit/miss counters.
+func (c *InMemoryTranscriptionCache) Stats() TranscriptionCacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := TranscriptionCacheStats{
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Entries: len(c.store),
+	}
+	if total := c.hits + c.misses; total > 0 {
+		stats.HitRate = float64(c.hits) / float64(total)
+	}
+	return stats
+}
+
+func (c *InMemoryTranscriptionCache) evictLocked() {
+	now := time.Now()
+	for k, v := range c.store {
+		if !v.expiresAt.IsZero() && now.After(v.expiresAt) {
+			delete(c.store, k)
+		}
+	}
+	if c.maxSize <= 0 || len(c.store) < c.maxSize {
+		return
+	}
+
+	// Evict the oldest cached entry so recently transcribed clips survive retries.
+	oldestKey := ""
+	var oldest time.Time
+	for k, v := range c.store {
+		if oldestKey == "" || v.value.CachedAt.Before(oldest) {
+			oldestKey = k
+			oldest = v.value.CachedAt
+		}
+	}
+	delete(c.store, oldestKey)
+}
+
+func cloneCachedTranscription(in *CachedTranscription) *CachedTranscription {
+	if in == nil {
+		return nil
+	}
+
+	out := &CachedTranscription{CachedAt: in.CachedAt}
+	if in.Response != nil {
+		resp := *in.Response
+		if in.Response.Segments != nil {
+			resp.Segments = make([]asr.Segment, len(in.Response.Segments))
+			copy(resp.Segments, in.Response.Segments)
+		}
+		out.Response = &resp
+	}
+	return out
+}
+
+// GenerateTranscriptionCacheKey creates a stable cache key for an ASR request.
+// The audio bytes are hashed together with the format, language hint and provider identity,
+// so the same clip transcribed under different hints or backends is cached separately.
+func GenerateTranscriptionCacheKey(audio []byte, format, language, provider string) string {
+	h := sha256.New()
+	h.Write([]byte(strings.Join([]string{
+		strings.ToLower(strings.TrimSpace(format)),
+		strings.ToLower(strings.TrimSpace(language)),
+		strings.TrimSpace(provider),
+	}, "|")))
+	h.Write([]byte{0})
+	h.Write(audio)
+	return hex.EncodeToString(h.Sum(nil))
+}
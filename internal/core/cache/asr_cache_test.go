@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
+)
+
+func TestGenerateTranscriptionCacheKey_SeparatesHints(t *testing.T) {
+	t.Parallel()
+
+	audio := []byte{0x01, 0x02, 0x03}
+	base := GenerateTranscriptionCacheKey(audio, "wav", "zh", "asr1")
+	if got := GenerateTranscriptionCacheKey(audio, "WAV", " zh ", "asr1"); got != base {
+		t.Fatalf("expected normalized key to match, got %q != %q", got, base)
+	}
+	if GenerateTranscriptionCacheKey(audio, "wav", "en", "asr1") == base {
+		t.Fatalf("expected language hint to change key")
+	}
+	if GenerateTranscriptionCacheKey(audio, "wav", "zh", "asr2") == base {
+		t.Fatalf("expected provider to change key")
+	}
+	if GenerateTranscriptionCacheKey([]byte{0x01, 0x02}, "wav", "zh", "asr1") == base {
+		t.Fatalf("expected audio content to change key")
+	}
+}
+
+func TestInMemoryTranscriptionCache_TTLAndStats(t *testing.T) {
+	c := NewInMemoryTranscriptionCache(10)
+	c.Set("k", &CachedTranscription{
+		Response: &asr.ASRResponse{Text: "hi"},
+		CachedAt: time.Now(),
+	}, 5*time.Millisecond)
+
+	if got, ok := c.Get("k"); !ok || got.Response.Text != "hi" {
+		t.Fatalf("expected cache hit")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if _, ok := c.Get("k"); ok {
+		t.Fatalf("expected cache miss after ttl")
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.HitRate != 0.5 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestInMemoryTranscriptionCache_EvictsOldest(t *testing.T) {
+	t.Parallel()
+
+	c := NewInMemoryTranscriptionCache(2)
+	now := time.Now()
+	c.Set("a", &CachedTranscription{Response: &asr.ASRResponse{Text: "a"}, CachedAt: now.Add(-2 * time.Second)}, time.Minute)
+	c.Set("b", &CachedTranscription{Response: &asr.ASRResponse{Text: "b"}, CachedAt: now.Add(-time.Second)}, time.Minute)
+	c.Set("c", &CachedTranscription{Response: &asr.ASRResponse{Text: "c"}, CachedAt: now}, time.Minute)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected oldest entry to be evicted")
+	}
+	for _, k := range []string{"b", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("expected %q to remain cached", k)
+		}
+	}
+	if stats := c.Stats(); stats.Entries != 2 {
+		t.Fatalf("entries=%d want 2", stats.Entries)
+	}
+}
@@ -42,12 +42,13 @@ func MergeDictionaries(global, request []config.DictionaryTerm) []config.Diction
 				}
 				existing.Aliases = append(existing.Aliases, a)
 			}
+			existing.Translations = mergeTranslations(existing.Translations, term.Translations)
 			out[idx] = existing
 			return
 		}
 
 		seen[t] = len(out)
-		out = append(out, config.DictionaryTerm{Term: t, Aliases: aliases})
+		out = append(out, config.DictionaryTerm{Term: t, Aliases: aliases, Translations: mergeTranslations(nil, term.Translations)})
 	}
 
 	for _, t := range global {
@@ -59,3 +60,24 @@ func MergeDictionaries(global, request []config.DictionaryTerm) []config.Diction
 
 	return out
 }
+
+// mergeTranslations copies the non-empty entries of override over base; language codes are
+// lower-cased because config keys arrive lower-cased while request keys may not.
+func mergeTranslations(base, override map[string]string) map[string]string {
+	if len(override) == 0 {
+		return base
+	}
+	out := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range override {
+		k = strings.ToLower(strings.TrimSpace(k))
+		v = strings.TrimSpace(v)
+		if k == "" || v == "" {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -17,7 +17,7 @@ type OpenAIBackend struct {
 
 // NewOpenAIBackend 创建OpenAI后端
 func NewOpenAIBackend(cfg config.BackendProvider, logger *logrus.Logger) *OpenAIBackend {
-	return &OpenAIBackend{
+	backend := &OpenAIBackend{
 		BaseOpenAICompatibleBackend: NewBaseOpenAICompatibleBackend(
 			cfg.Name,
 			cfg.URL,
@@ -28,6 +28,8 @@ func NewOpenAIBackend(cfg config.BackendProvider, logger *logrus.Logger) *OpenAI
 			logger,
 		),
 	}
+	backend.audio = cfg.Audio
+	return backend
 }
 
 // HealthCheck 健康检查
@@ -55,13 +57,13 @@ func (b *OpenAIBackend) HealthCheck(ctx context.Context) error {
 
 // GetCapabilities 获取能力
 func (b *OpenAIBackend) GetCapabilities() Capabilities {
-	return Capabilities{
+	return b.audioCapabilities(Capabilities{
 		SupportsAudio:      false,
 		SupportedFormats:   []string{},
 		MaxAudioSize:       0,
 		SupportsStreaming:  true,
 		SupportedLanguages: []string{"en", "zh", "ja", "ko", "es", "fr", "de", "it", "pt", "ru"},
-	}
+	})
 }
 
 // VLLMBackend VLLM后端实现
@@ -71,7 +73,7 @@ type VLLMBackend struct {
 
 // NewVLLMBackend 创建VLLM后端
 func NewVLLMBackend(cfg config.BackendProvider, logger *logrus.Logger) *VLLMBackend {
-	return &VLLMBackend{
+	backend := &VLLMBackend{
 		BaseOpenAICompatibleBackend: NewBaseOpenAICompatibleBackend(
 			cfg.Name,
 			cfg.URL,
@@ -82,6 +84,8 @@ func NewVLLMBackend(cfg config.BackendProvider, logger *logrus.Logger) *VLLMBack
 			logger,
 		),
 	}
+	backend.audio = cfg.Audio
+	return backend
 }
 
 // HealthCheck 健康检查
@@ -107,11 +111,11 @@ func (b *VLLMBackend) HealthCheck(ctx context.Context) error {
 
 // GetCapabilities 获取能力
 func (b *VLLMBackend) GetCapabilities() Capabilities {
-	return Capabilities{
+	return b.audioCapabilities(Capabilities{
 		SupportsAudio:      false,
 		SupportedFormats:   []string{},
 		MaxAudioSize:       0,
 		SupportsStreaming:  true,
 		SupportedLanguages: []string{"en", "zh", "ja", "ko", "es", "fr", "de"},
-	}
+	})
 }
@@ -3,6 +3,7 @@ package llm
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,7 +11,10 @@ import (
 	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // BaseOpenAICompatibleBackend 基础OpenAI兼容后端
@@ -22,6 +26,7 @@ type BaseOpenAICompatibleBackend struct {
 	client     *http.Client
 	logger     *logrus.Logger
 	parameters config.LLMParameters
+	audio      config.BackendAudioConfig
 }
 
 // NewBaseOpenAICompatibleBackend 创建基础后端
@@ -39,8 +44,24 @@ func NewBaseOpenAICompatibleBackend(name, baseURL, apiKey, model string, timeout
 	}
 }
 
-// Process 处理请求 - 通用的OpenAI兼容实现
+// Process 处理请求 - 通用的OpenAI兼容实现，整个调用记录为一个 client span
 func (b *BaseOpenAICompatibleBackend) Process(ctx context.Context, req *LLMRequest) (*LLMResponse, error) {
+	ctx, span := tracing.Start(ctx, "llm.chat_completions", trace.SpanKindClient,
+		attribute.String("llm.backend", b.name),
+		attribute.String("llm.model", b.model),
+	)
+	resp, err := b.process(ctx, req)
+	if resp != nil {
+		span.SetAttributes(
+			attribute.Int("llm.prompt_tokens", resp.PromptTokens),
+			attribute.Int("llm.total_tokens", resp.TotalTokens),
+		)
+	}
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (b *BaseOpenAICompatibleBackend) process(ctx context.Context, req *LLMRequest) (*LLMResponse, error) {
 	// 构建消息
 	messages := b.buildMessages(req)
 
@@ -80,6 +101,7 @@ func (b *BaseOpenAICompatibleBackend) Process(ctx context.Context, req *LLMReque
 
 	// 设置请求头
 	b.setRequestHeaders(httpReq)
+	tracing.Inject(ctx, httpReq.Header)
 
 	// 发送请求
 	resp, err := b.client.Do(httpReq)
@@ -141,6 +163,14 @@ func (b *BaseOpenAICompatibleBackend) buildMessages(req *LLMRequest) []map[strin
 	}
 
 	// 添加用户消息
+	if req.Audio != nil {
+		messages = append(messages, map[string]interface{}{
+			"role":    "user",
+			"content": buildAudioContentParts(req.UserPrompt, req.Audio),
+		})
+		return messages
+	}
+
 	messages = append(messages, map[string]interface{}{
 		"role":    "user",
 		"content": req.UserPrompt,
@@ -149,6 +179,36 @@ func (b *BaseOpenAICompatibleBackend) buildMessages(req *LLMRequest) []map[strin
 	return messages
 }
 
+// buildAudioContentParts builds an OpenAI-style multi-part user content with an input_audio part.
+func buildAudioContentParts(text string, audio *AudioInput) []map[string]interface{} {
+	parts := make([]map[string]interface{}, 0, 2)
+	if text != "" {
+		parts = append(parts, map[string]interface{}{
+			"type": "text",
+			"text": text,
+		})
+	}
+	parts = append(parts, map[string]interface{}{
+		"type": "input_audio",
+		"input_audio": map[string]interface{}{
+			"data":   base64.StdEncoding.EncodeToString(audio.Data),
+			"format": audio.Format,
+		},
+	})
+	return parts
+}
+
+// audioCapabilities fills the audio-related capability fields from provider configuration.
+func (b *BaseOpenAICompatibleBackend) audioCapabilities(caps Capabilities) Capabilities {
+	if !b.audio.Enabled {
+		return caps
+	}
+	caps.SupportsAudio = true
+	caps.SupportedFormats = append([]string(nil), b.audio.Formats...)
+	caps.MaxAudioSize = b.audio.MaxSize
+	return caps
+}
+
 // addDefaultParameters 添加默认参数 - 可被子类重写
 func (b *BaseOpenAICompatibleBackend) addDefaultParameters(apiReq map[string]interface{}) {
 	// 从配置中读取参数，如果配置中没有则使用默认值
@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestBaseOpenAICompatibleBackend_Process_TracesAndPropagates(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": "ok"}}},
+			"usage":   map[string]any{"prompt_tokens": 5, "total_tokens": 7},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	backend := NewBaseOpenAICompatibleBackend("test", srv.URL, "", "test-model", 3*time.Second, config.LLMParameters{}, newTestLogger())
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	if _, err := backend.Process(ctx, &LLMRequest{UserPrompt: "user"}); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	parent.End()
+
+	var client sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "llm.chat_completions" {
+			client = span
+		}
+	}
+	if client == nil {
+		t.Fatalf("no llm.chat_completions span recorded")
+	}
+	if client.SpanKind() != trace.SpanKindClient || client.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("kind=%v parent=%s want client child of %s", client.SpanKind(), client.Parent().SpanID(), parent.SpanContext().SpanID())
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range client.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs["llm.backend"].AsString() != "test" || attrs["llm.total_tokens"].AsInt64() != 7 {
+		t.Fatalf("unexpected attributes: %v", client.Attributes())
+	}
+	if want := "00-" + client.SpanContext().TraceID().String() + "-" + client.SpanContext().SpanID().String() + "-01"; traceparent != want {
+		t.Fatalf("traceparent=%q want %q", traceparent, want)
+	}
+}
@@ -3,7 +3,10 @@ package llm
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
@@ -20,11 +23,20 @@ type LLMRequest struct {
 	Options      map[string]interface{} `json:"options,omitempty"`
 	Tools        []ToolDefinition       `json:"tools,omitempty"`
 	ToolChoice   *ToolChoice            `json:"tool_choice,omitempty"`
+	// Audio is attached to the user message as an input_audio content part.
+	// Only backends advertising SupportsAudio are selected for such requests.
+	Audio *AudioInput `json:"-"`
 	// Context carries internal metadata for multi-stage processors.
 	// It is not forwarded to the LLM backend.
 	Context map[string]interface{} `json:"-"`
 }
 
+// AudioInput carries raw audio for audio-capable chat models.
+type AudioInput struct {
+	Data   []byte
+	Format string
+}
+
 // LLMResponse LLM响应
 type LLMResponse struct {
 	Content      string                 `json:"content"`
@@ -67,6 +79,7 @@ type Manager struct {
 	config       ManagerConfig
 	logger       *logrus.Logger
 	mu           sync.RWMutex
+	audioNext    atomic.Uint64 // 音频请求在可用后端间轮询，不占用共享负载均衡器的轮次
 }
 
 // NewManager 创建LLM管理器
@@ -160,9 +173,9 @@ func (m *Manager) ProcessWithTimeout(ctx context.Context, req *LLMRequest) (*LLM
 // Process 处理请求
 func (m *Manager) Process(ctx context.Context, req *LLMRequest) (*LLMResponse, error) {
 	// 选择后端
-	backend, err := m.loadBalancer.SelectBackend(ctx, req)
+	backend, err := m.selectBackend(ctx, req)
 	if err != nil {
-		return nil, coreerrors.NewLLMError("failed to select backend", err)
+		return nil, err
 	}
 
 	// 处理请求
@@ -190,6 +203,64 @@ func (m *Manager) Process(ctx context.Context, req *LLMRequest) (*LLMResponse, e
 	return resp, nil
 }
 
+// selectBackend picks a backend via the load balancer. Requests carrying audio rotate over
+// the backends that accept the audio instead.
+func (m *Manager) selectBackend(ctx context.Context, req *LLMRequest) (LLMBackend, error) {
+	if req == nil || req.Audio == nil {
+		backend, err := m.loadBalancer.SelectBackend(ctx, req)
+		if err != nil {
+			return nil, coreerrors.NewLLMError("failed to select backend", err)
+		}
+		return backend, nil
+	}
+
+	m.mu.RLock()
+	var capable []LLMBackend
+	for _, backend := range m.backends {
+		if acceptsAudio(backend.GetCapabilities(), req.Audio.Format, int64(len(req.Audio.Data))) {
+			capable = append(capable, backend)
+		}
+	}
+	m.mu.RUnlock()
+
+	if len(capable) == 0 {
+		return nil, coreerrors.NewLLMError(fmt.Sprintf("no audio-capable backend for format %q", req.Audio.Format), nil)
+	}
+	sort.Slice(capable, func(i, j int) bool { return capable[i].GetName() < capable[j].GetName() })
+	return capable[(m.audioNext.Add(1)-1)%uint64(len(capable))], nil
+}
+
+// SupportsAudioInput reports whether any backend accepts audio of the given format and size directly.
+func (m *Manager) SupportsAudioInput(format string, size int64) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, backend := range m.backends {
+		if acceptsAudio(backend.GetCapabilities(), format, size) {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsAudio(caps Capabilities, format string, size int64) bool {
+	if !caps.SupportsAudio {
+		return false
+	}
+	if caps.MaxAudioSize > 0 && size > caps.MaxAudioSize {
+		return false
+	}
+	if len(caps.SupportedFormats) == 0 {
+		return true
+	}
+	for _, f := range caps.SupportedFormats {
+		if strings.EqualFold(f, format) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetBackend 获取指定后端
 func (m *Manager) GetBackend(name string) (LLMBackend, bool) {
 	m.mu.RLock()
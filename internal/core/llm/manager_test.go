@@ -15,6 +15,7 @@ type mockBackend struct {
 	response   *LLMResponse
 	delay      time.Duration
 	healthErr  error
+	caps       Capabilities
 }
 
 func (b *mockBackend) Process(ctx context.Context, req *LLMRequest) (*LLMResponse, error) {
@@ -35,7 +36,7 @@ func (b *mockBackend) HealthCheck(ctx context.Context) error {
 }
 
 func (b *mockBackend) GetCapabilities() Capabilities {
-	return Capabilities{}
+	return b.caps
 }
 
 func (b *mockBackend) GetName() string {
@@ -175,3 +176,94 @@ func TestManager_HealthCheck(t *testing.T) {
 		t.Fatalf("expected bad backend to have error")
 	}
 }
+
+func TestManager_Process_AudioSelectsCapableBackend(t *testing.T) {
+	t.Parallel()
+
+	logger := newTestLogger()
+	textOnly := &mockBackend{name: "text"}
+	audioCapable := &mockBackend{
+		name: "audio",
+		caps: Capabilities{SupportsAudio: true, SupportedFormats: []string{"wav"}},
+	}
+
+	lb := NewLoadBalancer("round_robin", logger)
+	lb.AddBackend(textOnly)
+	lb.AddBackend(audioCapable)
+
+	m := &Manager{
+		backends:     map[string]LLMBackend{textOnly.name: textOnly, audioCapable.name: audioCapable},
+		loadBalancer: lb,
+		logger:       logger,
+	}
+
+	if !m.SupportsAudioInput("WAV", 10) {
+		t.Fatalf("expected wav audio input to be supported")
+	}
+	if m.SupportsAudioInput("mp3", 10) {
+		t.Fatalf("expected mp3 audio input to be unsupported")
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := m.Process(context.Background(), &LLMRequest{
+			UserPrompt: "hi",
+			Audio:      &AudioInput{Data: []byte{0x01}, Format: "wav"},
+		})
+		if err != nil {
+			t.Fatalf("Process: %v", err)
+		}
+		if resp.Metadata["backend"] != audioCapable.name {
+			t.Fatalf("backend=%v want %s", resp.Metadata["backend"], audioCapable.name)
+		}
+	}
+
+	if _, err := m.Process(context.Background(), &LLMRequest{
+		Audio: &AudioInput{Data: []byte{0x01}, Format: "mp3"},
+	}); err == nil {
+		t.Fatalf("expected error when no backend accepts the audio format")
+	}
+}
+
+func TestManager_Process_AudioRotatesWithoutSharedBalancer(t *testing.T) {
+	t.Parallel()
+
+	logger := newTestLogger()
+	textOnly := &mockBackend{name: "text"}
+	audioA := &mockBackend{name: "audio-a", caps: Capabilities{SupportsAudio: true}}
+	audioB := &mockBackend{name: "audio-b", caps: Capabilities{SupportsAudio: true}}
+
+	lb := NewLoadBalancer("round_robin", logger)
+	lb.AddBackend(textOnly)
+	lb.AddBackend(audioA)
+	lb.AddBackend(audioB)
+
+	m := &Manager{
+		backends:     map[string]LLMBackend{textOnly.name: textOnly, audioA.name: audioA, audioB.name: audioB},
+		loadBalancer: lb,
+		logger:       logger,
+	}
+
+	seen := map[string]int{}
+	for i := 0; i < 4; i++ {
+		resp, err := m.Process(context.Background(), &LLMRequest{
+			UserPrompt: "hi",
+			Audio:      &AudioInput{Data: []byte{0x01}, Format: "wav"},
+		})
+		if err != nil {
+			t.Fatalf("Process: %v", err)
+		}
+		seen[resp.Metadata["backend"].(string)]++
+	}
+	if seen[audioA.name] != 2 || seen[audioB.name] != 2 {
+		t.Fatalf("audio backends=%v want an even rotation", seen)
+	}
+
+	// 音频请求不推进共享负载均衡器，文本请求仍从第一个后端开始轮询
+	resp, err := m.Process(context.Background(), &LLMRequest{UserPrompt: "hi"})
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if resp.Metadata["backend"] != textOnly.name {
+		t.Fatalf("backend=%v want %s", resp.Metadata["backend"], textOnly.name)
+	}
+}
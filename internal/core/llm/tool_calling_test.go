@@ -115,3 +115,54 @@ func TestBaseOpenAICompatibleBackend_Process_ToolCalling_RequestAndParse(t *test
 		t.Fatalf("corrected_text=%q want 你好", parsed.CorrectedText)
 	}
 }
+
+func TestBaseOpenAICompatibleBackend_Process_InputAudioContent(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Role    string          `json:"role"`
+				Content json.RawMessage `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+
+		last := req.Messages[len(req.Messages)-1]
+		var parts []map[string]any
+		if err := json.Unmarshal(last.Content, &parts); err != nil {
+			t.Fatalf("expected content parts, got %s", string(last.Content))
+		}
+		if len(parts) != 2 || parts[0]["type"] != "text" || parts[1]["type"] != "input_audio" {
+			t.Fatalf("unexpected content parts: %v", parts)
+		}
+		audio, _ := parts[1]["input_audio"].(map[string]any)
+		if audio["data"] != "AAE=" || audio["format"] != "wav" {
+			t.Fatalf("unexpected input_audio: %v", audio)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": "ok"}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	backend := NewBaseOpenAICompatibleBackend("test", srv.URL, "", "audio-model", 3*time.Second, config.LLMParameters{}, newTestLogger())
+
+	resp, err := backend.Process(context.Background(), &LLMRequest{
+		SystemPrompt: "sys",
+		UserPrompt:   "transcribe",
+		Audio:        &AudioInput{Data: []byte{0x00, 0x01}, Format: "wav"},
+	})
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if resp.Content != "ok" {
+		t.Fatalf("content=%q want ok", resp.Content)
+	}
+}
@@ -0,0 +1,477 @@
+package pipeline
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+)
+
+// Condition is a parsed Step.When expression. It is evaluated against the same values
+// input mappings resolve ("request.x", "<output_key>.field").
+//
+// Grammar:
+//
+//	expr    = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | compare
+//	compare = operand [ ("==" | "!=" | "<" | "<=" | ">" | ">=" | "in" | "not in") operand ]
+//	operand = "(" expr ")" | "len(" operand ")" | string | number | true | false | path
+//	path    = source "." key { "." key | "[" index "]" }
+//
+// Paths that cannot be resolved evaluate to nil. A bare operand is true when it is a
+// non-empty string, slice or map, a non-zero number or true.
+type Condition struct {
+	expr string
+	root condNode
+}
+
+// ParseCondition parses a When expression.
+func ParseCondition(expr string) (*Condition, error) {
+	p := &condParser{src: expr}
+	p.next()
+	root, err := p.parseOr()
+	if err == nil {
+		err = p.err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("invalid condition %q: unexpected %q", expr, p.tok.text)
+	}
+	return &Condition{expr: expr, root: root}, nil
+}
+
+// String returns the source expression.
+func (c *Condition) String() string {
+	return c.expr
+}
+
+// Eval reports whether the condition holds for pctx.
+func (c *Condition) Eval(pctx *tool.PipelineContext) bool {
+	return truthy(c.root.eval(pctx))
+}
+
+// References returns the sources ("request" or step output keys) the condition reads.
+func (c *Condition) References() []string {
+	return references(c.root)
+}
+
+// references lists the path sources read by nodes, in order of first use.
+func references(nodes ...condNode) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		node.walk(func(n condNode) {
+			if path, ok := n.(pathNode); ok {
+				source, _, _ := splitPath(string(path))
+				if !seen[source] {
+					seen[source] = true
+					refs = append(refs, source)
+				}
+			}
+		})
+	}
+	return refs
+}
+
+type condNode interface {
+	eval(pctx *tool.PipelineContext) interface{}
+	walk(fn func(condNode))
+}
+
+type literalNode struct{ value interface{} }
+
+func (n literalNode) eval(*tool.PipelineContext) interface{} { return n.value }
+func (n literalNode) walk(fn func(condNode))                 { fn(n) }
+
+type pathNode string
+
+func (n pathNode) eval(pctx *tool.PipelineContext) interface{} {
+	v, _ := resolveValue(pctx, string(n))
+	return v
+}
+func (n pathNode) walk(fn func(condNode)) { fn(n) }
+
+type lenNode struct{ arg condNode }
+
+func (n lenNode) eval(pctx *tool.PipelineContext) interface{} {
+	v := n.arg.eval(pctx)
+	if s, ok := v.(string); ok {
+		return float64(utf8.RuneCountInString(s))
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(rv.Len())
+	default:
+		return float64(0)
+	}
+}
+func (n lenNode) walk(fn func(condNode)) { fn(n); n.arg.walk(fn) }
+
+type notNode struct{ arg condNode }
+
+func (n notNode) eval(pctx *tool.PipelineContext) interface{} { return !truthy(n.arg.eval(pctx)) }
+func (n notNode) walk(fn func(condNode))                      { fn(n); n.arg.walk(fn) }
+
+type binaryNode struct {
+	op          string
+	left, right condNode
+}
+
+func (n binaryNode) walk(fn func(condNode)) { fn(n); n.left.walk(fn); n.right.walk(fn) }
+
+func (n binaryNode) eval(pctx *tool.PipelineContext) interface{} {
+	switch n.op {
+	case "&&":
+		return truthy(n.left.eval(pctx)) && truthy(n.right.eval(pctx))
+	case "||":
+		return truthy(n.left.eval(pctx)) || truthy(n.right.eval(pctx))
+	}
+
+	l, r := n.left.eval(pctx), n.right.eval(pctx)
+	switch n.op {
+	case "==":
+		return equalValues(l, r)
+	case "!=":
+		return !equalValues(l, r)
+	case "in":
+		return contains(r, l)
+	case "not in":
+		return !contains(r, l)
+	}
+
+	// 大小比较仅支持数字或字符串
+	if lf, ok := toFloat(l); ok {
+		rf, ok := toFloat(r)
+		if !ok {
+			return false
+		}
+		return compareOrdered(n.op, lf, rf)
+	}
+	ls, lok := l.(string)
+	rs, rok := r.(string)
+	if !lok || !rok {
+		return false
+	}
+	return compareOrdered(n.op, ls, rs)
+}
+
+func compareOrdered[T float64 | string](op string, l, r T) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+func truthy(v interface{}) bool {
+	switch vv := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return vv
+	case string:
+		return vv != ""
+	}
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() > 0
+	default:
+		return true
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch vv := v.(type) {
+	case float64:
+		return vv, true
+	case float32:
+		return float64(vv), true
+	case int:
+		return float64(vv), true
+	case int32:
+		return float64(vv), true
+	case int64:
+		return float64(vv), true
+	default:
+		return 0, false
+	}
+}
+
+func equalValues(l, r interface{}) bool {
+	if lf, ok := toFloat(l); ok {
+		rf, ok := toFloat(r)
+		return ok && lf == rf
+	}
+	if l == nil || r == nil {
+		return l == nil && r == nil
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+// contains reports whether needle is an element of a slice, a key of a map or a substring of a string.
+func contains(haystack, needle interface{}) bool {
+	if s, ok := haystack.(string); ok {
+		n, ok := needle.(string)
+		return ok && strings.Contains(s, n)
+	}
+	rv := reflect.ValueOf(haystack)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if equalValues(rv.Index(i).Interface(), needle) {
+				return true
+			}
+		}
+	case reflect.Map:
+		n, ok := needle.(string)
+		if !ok || rv.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		return rv.MapIndex(reflect.ValueOf(n).Convert(rv.Type().Key())).IsValid()
+	}
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+type condParser struct {
+	src string
+	pos int
+	tok token
+	err error
+}
+
+func (p *condParser) next() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF}
+		return
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case c == '"' || c == '\'':
+		p.pos++
+		for p.pos < len(p.src) && p.src[p.pos] != c {
+			if p.src[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.src) {
+			p.err = fmt.Errorf("unterminated string")
+			p.tok = token{kind: tokEOF}
+			return
+		}
+		p.pos++
+		p.tok = token{kind: tokString, text: p.src[start:p.pos]}
+	case c >= '0' && c <= '9' || c == '-' && p.pos+1 < len(p.src) && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9':
+		p.pos++
+		for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		p.tok = token{kind: tokNumber, text: p.src[start:p.pos]}
+	case isIdentByte(c):
+		// 路径中可含数组下标，如 asr_result.segments[0].text
+		depth := 0
+		for p.pos < len(p.src) {
+			b := p.src[p.pos]
+			if b == '[' {
+				depth++
+			} else if b == ']' && depth > 0 {
+				depth--
+			} else if !isIdentByte(b) && b != '.' && (b < '0' || b > '9') {
+				break
+			}
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: p.src[start:p.pos]}
+	default:
+		for _, op := range []string{"??", "&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ",", "+"} {
+			if strings.HasPrefix(p.src[p.pos:], op) {
+				p.pos += len(op)
+				p.tok = token{kind: tokOp, text: op}
+				return
+			}
+		}
+		p.err = fmt.Errorf("unexpected character %q", c)
+		p.tok = token{kind: tokEOF}
+	}
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *condParser) isOp(text string) bool {
+	return p.tok.kind == tokOp && p.tok.text == text
+}
+
+func (p *condParser) parseOr() (condNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.isOp("||") {
+		p.next()
+		var right condNode
+		right, err = p.parseAnd()
+		left = binaryNode{op: "||", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *condParser) parseAnd() (condNode, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.isOp("&&") {
+		p.next()
+		var right condNode
+		right, err = p.parseUnary()
+		left = binaryNode{op: "&&", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *condParser) parseUnary() (condNode, error) {
+	if p.isOp("!") {
+		p.next()
+		arg, err := p.parseUnary()
+		return notNode{arg: arg}, err
+	}
+	return p.parseCompare()
+}
+
+func (p *condParser) parseCompare() (condNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	var op string
+	switch {
+	case p.tok.kind == tokOp && comparisonOps[p.tok.text]:
+		op = p.tok.text
+	case p.tok.kind == tokIdent && p.tok.text == "in":
+		op = "in"
+	case p.tok.kind == tokIdent && p.tok.text == "not":
+		p.next()
+		if p.tok.kind != tokIdent || p.tok.text != "in" {
+			return nil, fmt.Errorf("expected \"in\" after \"not\"")
+		}
+		op = "not in"
+	default:
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return binaryNode{op: op, left: left, right: right}, nil
+}
+
+var comparisonOps = map[string]bool{"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+func (p *condParser) parseOperand() (condNode, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	tok := p.tok
+	switch tok.kind {
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	case tokString:
+		p.next()
+		quoted := tok.text
+		if quoted[0] == '\'' {
+			inner := strings.ReplaceAll(quoted[1:len(quoted)-1], `\'`, `'`)
+			quoted = `"` + strings.ReplaceAll(inner, `"`, `\"`) + `"`
+		}
+		s, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", tok.text)
+		}
+		return literalNode{value: s}, nil
+	case tokNumber:
+		p.next()
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", tok.text)
+		}
+		return literalNode{value: f}, nil
+	case tokOp:
+		if tok.text != "(" {
+			return nil, fmt.Errorf("unexpected %q", tok.text)
+		}
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			return nil, fmt.Errorf("missing \")\"")
+		}
+		p.next()
+		return inner, nil
+	}
+
+	p.next()
+	switch tok.text {
+	case "true", "false":
+		return literalNode{value: tok.text == "true"}, nil
+	case "len":
+		if !p.isOp("(") {
+			return nil, fmt.Errorf("expected \"(\" after len")
+		}
+		p.next()
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			return nil, fmt.Errorf("missing \")\" after len argument")
+		}
+		p.next()
+		return lenNode{arg: arg}, nil
+	}
+	return newPathNode(tok.text)
+}
+
+func newPathNode(text string) (condNode, error) {
+	if !strings.ContainsAny(text, ".[") {
+		return nil, fmt.Errorf("unknown identifier %q (paths look like request.x or <output_key>.field)", text)
+	}
+	if _, _, err := splitPath(text); err != nil {
+		return nil, err
+	}
+	return pathNode(text), nil
+}
@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+)
+
+func TestCondition_Eval(t *testing.T) {
+	t.Parallel()
+
+	pctx := &tool.PipelineContext{
+		OriginalRequest: map[string]interface{}{
+			"source_language":  "zh",
+			"target_languages": []string{"zh"},
+			"options":          map[string]interface{}{"mode": "fast", "count": 2.0},
+		},
+		StepOutputs: map[string]tool.Output{
+			"asr_result": {Data: map[string]interface{}{"text": "ok", "language": "en"}},
+			"translate":  {Data: map[string]interface{}{"translations": map[string]string{"ja": "はい"}}},
+		},
+	}
+
+	cases := map[string]bool{
+		`len(asr_result.text) > 3`:                                     false,
+		`len(asr_result.text) <= 2`:                                    true,
+		`len("你好吗") == 3`:                                              true,
+		`asr_result.language == "en"`:                                  true,
+		`asr_result.language != 'en'`:                                  false,
+		`request.source_language in request.target_languages`:          true,
+		`asr_result.language not in request.target_languages`:          true,
+		`"ja" in translate.translations`:                               true,
+		`"ok" in asr_result.text`:                                      true,
+		`request.options.mode == "fast" && request.options.count >= 2`: true,
+		`request.options.missing == "x" || !request.options.missing`:   true,
+		`!(len(request.target_languages) == 1 && request.source_language in request.target_languages)`: false,
+		`request.options.missing`:             false,
+		`translate.translations`:              true,
+		`len(request.options.missing) == 0`:   true,
+		`request.target_languages[0] == "zh"`: true,
+		`translate.translations.ja == "はい"`:   true,
+	}
+	for expr, want := range cases {
+		cond, err := ParseCondition(expr)
+		if err != nil {
+			t.Fatalf("ParseCondition(%q): %v", expr, err)
+		}
+		if got := cond.Eval(pctx); got != want {
+			t.Errorf("Eval(%q)=%v want %v", expr, got, want)
+		}
+	}
+}
+
+func TestParseCondition_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		``,
+		`len(asr_result.text`,
+		`asr_result.text ==`,
+		`"unterminated`,
+		`foo == 1`,
+		`asr_result.text not "x"`,
+		`a.b == 1 extra.c`,
+		`a.b # 1`,
+	} {
+		if _, err := ParseCondition(expr); err == nil {
+			t.Errorf("ParseCondition(%q) should fail", expr)
+		}
+	}
+}
+
+func TestCondition_References(t *testing.T) {
+	t.Parallel()
+
+	cond, err := ParseCondition(`len(correct.corrected_text) > 0 && asr_result.language in request.target_languages && correct.x`)
+	if err != nil {
+		t.Fatalf("ParseCondition: %v", err)
+	}
+	if got, want := cond.References(), []string{"correct", "asr_result", "request"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("References()=%v want %v", got, want)
+	}
+}
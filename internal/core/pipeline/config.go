@@ -0,0 +1,257 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+)
+
+// Pipeline definition types in config.
+const (
+	TypeAudio = "audio"
+	TypeText  = "text"
+)
+
+// OptionPipeline is the request option that picks a pipeline by name.
+const OptionPipeline = "pipeline"
+
+// RequestedName returns the pipeline named by the request options, if any.
+func RequestedName(options map[string]interface{}) string {
+	name, _ := options[OptionPipeline].(string)
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Catalog holds the pipelines one processor can run, keyed by name.
+type Catalog map[string]Pipeline
+
+// NewCatalog builds a catalog from the built-in pipelines plus the custom definitions of the given
+// type, checking every custom pipeline against reg. Custom names must not shadow built-in ones.
+func NewCatalog(builtins []Pipeline, defs map[string]config.PipelineDefinition, pipelineType string, reg *tool.Registry) (Catalog, error) {
+	c := make(Catalog, len(builtins)+len(defs))
+	for _, p := range builtins {
+		c[p.Name] = p
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := defs[name]
+		if def.Type != pipelineType {
+			continue
+		}
+		if _, exists := c[name]; exists {
+			return nil, fmt.Errorf("pipeline %s: name is reserved for a built-in pipeline", name)
+		}
+		p := FromConfig(name, def)
+		if err := p.Validate(reg); err != nil {
+			return nil, err
+		}
+		c[name] = p
+	}
+	return c, nil
+}
+
+// Lookup returns the pipeline with the given (case-insensitive) name.
+func (c Catalog) Lookup(name string) (Pipeline, bool) {
+	p, ok := c[strings.ToLower(strings.TrimSpace(name))]
+	return p, ok
+}
+
+// CheckTasks verifies that every task in mapping names a pipeline in the catalog.
+func (c Catalog) CheckTasks(mapping map[string]string) error {
+	for task, name := range mapping {
+		if _, ok := c.Lookup(name); !ok {
+			return fmt.Errorf("pipeline for task %s: unknown pipeline %q", task, name)
+		}
+	}
+	return nil
+}
+
+// Names lists the catalog's pipeline names in sorted order.
+func (c Catalog) Names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FromConfig converts a pipeline definition from config.
+func FromConfig(name string, def config.PipelineDefinition) Pipeline {
+	p := Pipeline{Name: name, Steps: make([]Step, 0, len(def.Steps))}
+	for _, s := range def.Steps {
+		mapping := make(map[string]string, len(s.InputMapping))
+		for k, v := range s.InputMapping {
+			mapping[k] = strings.TrimSpace(v)
+		}
+		p.Steps = append(p.Steps, Step{
+			ToolName:     strings.TrimSpace(s.Tool),
+			InputMapping: mapping,
+			OutputKey:    strings.TrimSpace(s.OutputKey),
+			DependsOn:    trimmed(s.DependsOn),
+			When:         strings.TrimSpace(s.When),
+			Policy: StepPolicy{
+				Timeout:      s.Timeout,
+				Retries:      s.Retries,
+				Backoff:      s.Backoff,
+				OnError:      strings.ToLower(strings.TrimSpace(s.OnError)),
+				FallbackTool: strings.TrimSpace(s.Fallback),
+			},
+		})
+	}
+	return p
+}
+
+// Validate checks that every tool is registered, that when conditions parse, and that input
+// mappings, conditions and depends_on only reference the request or outputs of earlier steps.
+func (p Pipeline) Validate(reg *tool.Registry) error {
+	seen := make(map[string]bool, len(p.Steps))
+	for i, step := range p.Steps {
+		if _, ok := reg.Get(step.ToolName); !ok {
+			return fmt.Errorf("pipeline %s step %d: tool not registered: %s (available: %s)", p.Name, i+1, step.ToolName, strings.Join(sortedTools(reg), ", "))
+		}
+		if step.Policy.OnError == OnErrorFallback {
+			if _, ok := reg.Get(step.Policy.FallbackTool); !ok {
+				return fmt.Errorf("pipeline %s step %d: fallback tool not registered: %s", p.Name, i+1, step.Policy.FallbackTool)
+			}
+		}
+		if step.OutputKey == "" || step.OutputKey == "request" || seen[step.OutputKey] {
+			return fmt.Errorf("pipeline %s step %d: invalid or duplicate output key %q", p.Name, i+1, step.OutputKey)
+		}
+		for _, dep := range step.DependsOn {
+			if !seen[dep] {
+				return fmt.Errorf("pipeline %s step %d: depends_on %q is not an earlier step", p.Name, i+1, dep)
+			}
+		}
+		if strings.TrimSpace(step.When) != "" {
+			cond, err := ParseCondition(step.When)
+			if err != nil {
+				return fmt.Errorf("pipeline %s step %d: %w", p.Name, i+1, err)
+			}
+			for _, source := range cond.References() {
+				if source != "request" && !seen[source] {
+					return fmt.Errorf("pipeline %s step %d: when references %q, which is not an earlier step", p.Name, i+1, source)
+				}
+			}
+		}
+		for field, expr := range step.InputMapping {
+			m, err := ParseMapping(expr)
+			if err != nil {
+				return fmt.Errorf("pipeline %s step %d: input %s: %w", p.Name, i+1, field, err)
+			}
+			for _, source := range m.References() {
+				if source != "request" && !seen[source] {
+					return fmt.Errorf("pipeline %s step %d: input %s references %q, which is not an earlier step", p.Name, i+1, field, source)
+				}
+			}
+		}
+		seen[step.OutputKey] = true
+	}
+	return nil
+}
+
+func trimmed(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func sortedTools(reg *tool.Registry) []string {
+	names := reg.List()
+	sort.Strings(names)
+	return names
+}
+
+// Result is the response-relevant part of a custom pipeline's outputs.
+type Result struct {
+	CorrectedText string
+	Translations  map[string]string
+	RawResponse   string
+	Metadata      map[string]interface{}
+	Moderation    *tool.ModerationResult // nil when no step produced a moderation result
+	Speech        *tool.SpeechResult     // last synthesized speech, nil when none
+	// Text is the source text as moderated by the last moderate step that checked a text,
+	// nil when none did. It replaces the transcription / source text in responses.
+	Text *string
+	// TextBlocked reports that the last moderate step blocked its text; every text field is cleared.
+	TextBlocked bool
+}
+
+// Collect merges step outputs in step order using the conventional output fields:
+// corrected_text, raw_response and speech (last step wins), translations, moderation and
+// metadata (merged). A moderate step's text also replaces a corrected_text produced before it,
+// so the response never carries the unmoderated wording.
+func Collect(p Pipeline, pctx *tool.PipelineContext) Result {
+	res := Result{Translations: make(map[string]string), Metadata: make(map[string]interface{})}
+	for _, step := range p.Steps {
+		out, ok := pctx.StepOutputs[step.OutputKey]
+		if !ok {
+			continue
+		}
+		if v, ok := out.Data["corrected_text"].(string); ok && v != "" {
+			res.CorrectedText = v
+		}
+		if v, ok := out.Data["raw_response"].(string); ok && v != "" {
+			res.RawResponse = v
+		}
+		if translations, ok := out.Data["translations"].(map[string]string); ok {
+			for k, v := range translations {
+				res.Translations[k] = v
+			}
+		}
+		if m, ok := out.Data["moderation"].(tool.ModerationResult); ok {
+			if res.Moderation != nil {
+				m = res.Moderation.Merge(m)
+			}
+			res.Moderation = &m
+		}
+		if speech, ok := out.Data["speech"].(tool.SpeechResult); ok {
+			res.Speech = &speech
+		}
+		if step.ToolName == "moderate" {
+			if text, ok := out.Data["text"].(string); ok {
+				res.Text = &text
+				res.TextBlocked = textBlocked(out.Data["moderation"])
+				if res.CorrectedText != "" {
+					res.CorrectedText = text
+				}
+			}
+		}
+		for k, v := range out.Metadata {
+			res.Metadata[k] = v
+		}
+	}
+	if res.TextBlocked {
+		res.CorrectedText = ""
+	}
+	return res
+}
+
+// textBlocked reports whether a moderation result blocked the text field (not only a translation).
+func textBlocked(v interface{}) bool {
+	m, ok := v.(tool.ModerationResult)
+	if !ok {
+		return false
+	}
+	for _, match := range m.Matches {
+		if match.Field == "text" && match.Action == config.ModerationBlock {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+)
+
+func TestNewCatalog_ValidatesDefinitions(t *testing.T) {
+	t.Parallel()
+
+	reg := tool.NewRegistry()
+	for _, name := range []string{"text_correct", "text_translate"} {
+		if err := reg.Register(mockTool{name: name}); err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+	}
+	step := func(toolName, out string, mapping map[string]string) config.PipelineStepConfig {
+		return config.PipelineStepConfig{Tool: toolName, OutputKey: out, InputMapping: mapping}
+	}
+
+	defs := map[string]config.PipelineDefinition{
+		"polish_then_translate": {Type: TypeText, Steps: []config.PipelineStepConfig{
+			step("text_correct", "correct_result", map[string]string{"text": "request.text"}),
+			step("text_translate", "translate_result", map[string]string{"text": "correct_result.corrected_text"}),
+		}},
+		// Audio definitions are ignored when building the text catalog.
+		"audio_only": {Type: TypeAudio, Steps: []config.PipelineStepConfig{step("asr", "asr_result", nil)}},
+	}
+	catalog, err := NewCatalog(TextBuiltins(), defs, TypeText, reg)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	if _, ok := catalog.Lookup("Polish_Then_Translate"); !ok {
+		t.Fatalf("custom pipeline missing from catalog: %v", catalog.Names())
+	}
+	if _, ok := catalog.Lookup("audio_only"); ok {
+		t.Fatalf("audio pipeline must not be part of the text catalog")
+	}
+	if _, ok := catalog.Lookup(PipelineTextTranslate); !ok {
+		t.Fatalf("built-in pipeline missing from catalog")
+	}
+	if err := catalog.CheckTasks(map[string]string{"translate": "polish_then_translate"}); err != nil {
+		t.Fatalf("CheckTasks: %v", err)
+	}
+	if err := catalog.CheckTasks(map[string]string{"translate": "missing"}); err == nil {
+		t.Fatalf("expected error for unknown task pipeline")
+	}
+
+	cases := map[string]struct {
+		def  config.PipelineDefinition
+		name string
+		want string
+	}{
+		"unknown tool": {
+			name: "bad", want: "tool not registered: moderate",
+			def: config.PipelineDefinition{Type: TypeText, Steps: []config.PipelineStepConfig{step("moderate", "out", nil)}},
+		},
+		"forward reference": {
+			name: "bad", want: "not an earlier step",
+			def: config.PipelineDefinition{Type: TypeText, Steps: []config.PipelineStepConfig{
+				step("text_translate", "translate_result", map[string]string{"text": "correct_result.corrected_text"}),
+				step("text_correct", "correct_result", map[string]string{"text": "request.text"}),
+			}},
+		},
+		"later dependency": {
+			name: "bad", want: "depends_on",
+			def: config.PipelineDefinition{Type: TypeText, Steps: []config.PipelineStepConfig{
+				{Tool: "text_correct", OutputKey: "correct_result", DependsOn: []string{"translate_result"}},
+				step("text_translate", "translate_result", nil),
+			}},
+		},
+		"invalid condition": {
+			name: "bad", want: "invalid condition",
+			def: config.PipelineDefinition{Type: TypeText, Steps: []config.PipelineStepConfig{
+				{Tool: "text_correct", OutputKey: "correct_result", When: "len(request.text"},
+			}},
+		},
+		"condition on later step": {
+			name: "bad", want: "when references",
+			def: config.PipelineDefinition{Type: TypeText, Steps: []config.PipelineStepConfig{
+				{Tool: "text_correct", OutputKey: "correct_result", When: "translate_result.translations"},
+				step("text_translate", "translate_result", nil),
+			}},
+		},
+		"reserved name": {
+			name: PipelineTextTranslate, want: "reserved",
+			def: config.PipelineDefinition{Type: TypeText, Steps: []config.PipelineStepConfig{step("text_translate", "out", nil)}},
+		},
+	}
+	for name, tc := range cases {
+		_, err := NewCatalog(TextBuiltins(), map[string]config.PipelineDefinition{tc.name: tc.def}, TypeText, reg)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: err=%v want %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestCollect_MergesStepOutputs(t *testing.T) {
+	t.Parallel()
+
+	p := Pipeline{Name: "custom", Steps: []Step{
+		{ToolName: "text_correct", OutputKey: "correct_result"},
+		{ToolName: "text_translate", OutputKey: "translate_ja"},
+		{ToolName: "text_translate", OutputKey: "translate_en"},
+	}}
+	pctx := &tool.PipelineContext{StepOutputs: map[string]tool.Output{
+		"correct_result": {Data: map[string]interface{}{"corrected_text": "你好。", "raw_response": "c"}, Metadata: map[string]interface{}{"backend": "a"}},
+		"translate_ja":   {Data: map[string]interface{}{"translations": map[string]string{"ja": "こんにちは"}, "raw_response": "ja"}},
+		"translate_en":   {Data: map[string]interface{}{"translations": map[string]string{"en": "Hello."}, "raw_response": "en"}, Metadata: map[string]interface{}{"backend": "b"}},
+	}}
+
+	res := Collect(p, pctx)
+	if res.CorrectedText != "你好。" || res.RawResponse != "en" || res.Metadata["backend"] != "b" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if len(res.Translations) != 2 || res.Translations["ja"] != "こんにちは" || res.Translations["en"] != "Hello." {
+		t.Fatalf("translations=%v", res.Translations)
+	}
+}
+
+func TestCollect_ModeratedText(t *testing.T) {
+	t.Parallel()
+
+	p := Pipeline{Name: "custom", Steps: []Step{
+		{ToolName: "text_correct", OutputKey: "correct_result"},
+		{ToolName: "moderate", OutputKey: "moderated"},
+	}}
+	masked := &tool.PipelineContext{StepOutputs: map[string]tool.Output{
+		"correct_result": {Data: map[string]interface{}{"corrected_text": "you jerk."}},
+		"moderated": {Data: map[string]interface{}{"text": "you ****.", "blocked": false, "moderation": tool.ModerationResult{
+			Flagged: true,
+			Matches: []tool.ModerationMatch{{Field: "text", Rule: "insults", Action: config.ModerationMask, Match: "jerk"}},
+		}}},
+	}}
+	res := Collect(p, masked)
+	if res.Text == nil || *res.Text != "you ****." || res.CorrectedText != "you ****." || res.TextBlocked {
+		t.Fatalf("unexpected masked result: %+v", res)
+	}
+
+	blocked := &tool.PipelineContext{StepOutputs: map[string]tool.Output{
+		"correct_result": {Data: map[string]interface{}{"corrected_text": "call 555-1234"}},
+		"moderated": {Data: map[string]interface{}{"text": "", "blocked": true, "moderation": tool.ModerationResult{
+			Flagged: true, Blocked: true,
+			Matches: []tool.ModerationMatch{{Field: "text", Rule: "doxxing", Action: config.ModerationBlock, Match: "555-1234"}},
+		}}},
+	}}
+	res = Collect(p, blocked)
+	if res.Text == nil || *res.Text != "" || res.CorrectedText != "" || !res.TextBlocked {
+		t.Fatalf("unexpected blocked result: %+v", res)
+	}
+}
@@ -2,22 +2,56 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// DefaultMaxParallelSteps is the number of independent steps run at once when no limit is configured.
+const DefaultMaxParallelSteps = 4
+
 type Executor struct {
-	registry *tool.Registry
+	registry    *tool.Registry
+	progress    ProgressHook
+	maxParallel int
 }
 
 func NewExecutor(registry *tool.Registry) *Executor {
-	return &Executor{registry: registry}
+	return &Executor{registry: registry, maxParallel: DefaultMaxParallelSteps}
+}
+
+// WithProgressHook reports step transitions to hook.
+func (e *Executor) WithProgressHook(hook ProgressHook) *Executor {
+	e.progress = hook
+	return e
+}
+
+// WithMaxParallel caps how many independent steps run concurrently (n <= 0 keeps the default).
+func (e *Executor) WithMaxParallel(n int) *Executor {
+	if n <= 0 {
+		n = DefaultMaxParallelSteps
+	}
+	e.maxParallel = n
+	return e
 }
 
+// stepResult is sent by a step goroutine when the step finishes.
+type stepResult struct {
+	index int
+	err   error
+}
+
+// Execute runs p as a dependency graph: a step starts once the steps it depends on have
+// completed, with at most maxParallel steps running at a time. The first failing step
+// cancels its running siblings and its error is returned.
 func (e *Executor) Execute(ctx context.Context, p Pipeline, pctx *tool.PipelineContext) (*tool.PipelineContext, error) {
 	if e == nil || e.registry == nil {
 		return nil, coreerrors.NewInternalError("pipeline executor not configured", nil)
@@ -32,80 +66,317 @@ func (e *Executor) Execute(ctx context.Context, p Pipeline, pctx *tool.PipelineC
 		pctx.Metrics = make(map[string]time.Duration)
 	}
 
-	for _, step := range p.Steps {
+	tools := make([]tool.Tool, len(p.Steps))
+	fallbacks := make([]tool.Tool, len(p.Steps))
+	for i, step := range p.Steps {
 		if strings.TrimSpace(step.ToolName) == "" {
 			return nil, coreerrors.NewValidationError("pipeline step tool name is required", nil)
 		}
 		if strings.TrimSpace(step.OutputKey) == "" {
 			return nil, coreerrors.NewValidationError("pipeline step output key is required", nil)
 		}
-
 		t, ok := e.registry.Get(step.ToolName)
 		if !ok {
 			return nil, coreerrors.NewValidationError(fmt.Sprintf("tool not registered: %s", step.ToolName), nil)
 		}
-
-		stepInput := make(map[string]interface{})
-		for key, expr := range step.InputMapping {
-			val, ok := resolveValue(pctx, expr)
+		tools[i] = t
+		if step.Policy.OnError == OnErrorFallback {
+			fb, ok := e.registry.Get(step.Policy.FallbackTool)
 			if !ok {
-				return nil, coreerrors.NewValidationError(fmt.Sprintf("failed to resolve input mapping %q: %s", key, expr), nil)
+				return nil, coreerrors.NewValidationError(fmt.Sprintf("fallback tool not registered: %s", step.Policy.FallbackTool), nil)
 			}
-			stepInput[key] = val
+			fallbacks[i] = fb
 		}
-
-		in := tool.Input{Data: stepInput, Context: pctx}
-		if err := t.Validate(in); err != nil {
-			return nil, err
+	}
+	conditions := make([]*Condition, len(p.Steps))
+	mappings := make([]map[string]*Mapping, len(p.Steps))
+	for i, step := range p.Steps {
+		mappings[i] = make(map[string]*Mapping, len(step.InputMapping))
+		for key, expr := range step.InputMapping {
+			m, err := ParseMapping(expr)
+			if err != nil {
+				return nil, coreerrors.NewValidationError(fmt.Sprintf("pipeline step %s input %s: %v", step.OutputKey, key, err), nil)
+			}
+			mappings[i][key] = m
 		}
-
-		start := time.Now()
-		out, err := t.Execute(ctx, in)
-		pctx.Metrics[step.OutputKey] = time.Since(start)
+		if strings.TrimSpace(step.When) == "" {
+			continue
+		}
+		cond, err := ParseCondition(step.When)
 		if err != nil {
-			return nil, err
+			return nil, coreerrors.NewValidationError(fmt.Sprintf("pipeline step %s: %v", step.OutputKey, err), nil)
 		}
+		conditions[i] = cond
+	}
+	deps, err := stepDependencies(p, mappings, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		pctx.StepOutputs[step.OutputKey] = out
+	limit := e.maxParallel
+	if limit <= 0 {
+		limit = DefaultMaxParallelSteps
 	}
 
+	run := &pipelineRun{executor: e, pipeline: p, pctx: pctx, mappings: mappings}
+	results := make(chan stepResult, len(p.Steps))
+	started := make([]bool, len(p.Steps))
+	finished := make([]bool, len(p.Steps))
+	running, remaining := 0, len(p.Steps)
+	var firstErr error
+
+	for remaining > 0 {
+		if firstErr == nil {
+			for i := range p.Steps {
+				if running >= limit {
+					break
+				}
+				if started[i] || !allFinished(deps[i], finished) {
+					continue
+				}
+				started[i] = true
+				running++
+				go func(i int) {
+					results <- stepResult{index: i, err: run.step(runCtx, i, tools[i], fallbacks[i], conditions[i])}
+				}(i)
+			}
+		}
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		remaining--
+		finished[res.index] = true
+		if res.err != nil && firstErr == nil {
+			firstErr = res.err
+			// 取消仍在运行的兄弟步骤，等待其退出后返回首个错误
+			cancel()
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
 	return pctx, nil
 }
 
-func resolveValue(pctx *tool.PipelineContext, expr string) (interface{}, bool) {
-	expr = strings.TrimSpace(expr)
-	if expr == "" {
-		return nil, false
-	}
+// pipelineRun holds the state shared by the steps of one Execute call.
+type pipelineRun struct {
+	executor *Executor
+	pipeline Pipeline
+	pctx     *tool.PipelineContext
+	mappings []map[string]*Mapping // parsed InputMapping of each step
 
-	parts := strings.Split(expr, ".")
-	if len(parts) < 2 {
-		return nil, false
+	mu        sync.Mutex // serializes progress reports
+	completed int
+}
+
+// step runs one step inside its own span, tagged with the recorded outcome.
+func (r *pipelineRun) step(ctx context.Context, i int, t, fallback tool.Tool, cond *Condition) error {
+	step := r.pipeline.Steps[i]
+	ctx, span := tracing.Start(ctx, "pipeline.step "+step.OutputKey, trace.SpanKindInternal,
+		attribute.String("pipeline.name", r.pipeline.Name),
+		attribute.String("pipeline.step", step.OutputKey),
+		attribute.String("pipeline.tool", step.ToolName),
+	)
+	err := r.runStep(ctx, i, t, fallback, cond)
+	if outcome, ok := r.pctx.Outcome(step.OutputKey); ok {
+		span.SetAttributes(
+			attribute.String("pipeline.outcome", outcome.Status),
+			attribute.Int("pipeline.attempts", outcome.Attempts),
+		)
+		if outcome.Tool != "" {
+			span.SetAttributes(attribute.String("pipeline.fallback_tool", outcome.Tool))
+		}
 	}
+	tracing.End(span, err)
+	return err
+}
+
+func (r *pipelineRun) runStep(ctx context.Context, i int, t, fallback tool.Tool, cond *Condition) error {
+	step := r.pipeline.Steps[i]
 
-	var current interface{}
-	switch parts[0] {
-	case "request":
-		current = pctx.OriginalRequest
-	default:
-		out, ok := pctx.StepOutputs[parts[0]]
+	stepInput := make(map[string]interface{})
+	for key, m := range r.mappings[i] {
+		val, ok := m.Resolve(r.pctx)
 		if !ok {
-			return nil, false
+			return coreerrors.NewValidationError(fmt.Sprintf("failed to resolve input mapping %q: %s", key, m), nil)
 		}
-		current = out.Data
+		stepInput[key] = val
 	}
 
-	for _, key := range parts[1:] {
-		m, ok := current.(map[string]interface{})
-		if !ok {
-			return nil, false
+	in := tool.Input{Data: stepInput, Context: r.pctx}
+	event := StepEvent{Pipeline: r.pipeline.Name, Step: step.OutputKey, Tool: step.ToolName, Index: i, Total: len(r.pipeline.Steps), State: StepStarted}
+
+	if cond != nil && !cond.Eval(r.pctx) {
+		// 条件不满足：跳过执行，以工具的透传输出占位，后续步骤与响应构建照常读取
+		r.pctx.SetOutput(step.OutputKey, tool.PassthroughOutput(ctx, t, in))
+		r.pctx.SetOutcome(step.OutputKey, tool.StepOutcome{Status: tool.OutcomeSkipped})
+		event.State = StepSkipped
+		r.report(ctx, event)
+		return nil
+	}
+
+	outcome := tool.StepOutcome{Status: tool.OutcomeOK}
+	var out tool.Output
+	err := t.Validate(in)
+	if err == nil {
+		r.report(ctx, event)
+		start := time.Now()
+		out, outcome.Attempts, err = execWithRetry(ctx, t, in, step.Policy)
+		event.Duration = time.Since(start)
+		r.pctx.SetMetric(step.OutputKey, event.Duration)
+		if outcome.Attempts > 1 {
+			outcome.Status = tool.OutcomeRetried
 		}
-		v, ok := m[key]
-		if !ok {
-			return nil, false
+	} else if step.Policy.OnError == "" || step.Policy.OnError == OnErrorFail {
+		// 输入校验失败且无兜底策略：与之前一样直接返回，不上报步骤事件
+		return err
+	}
+
+	// 兄弟步骤失败导致的取消不走 on_error 策略
+	if err != nil && ctx.Err() == nil {
+		switch step.Policy.OnError {
+		case OnErrorSkip:
+			outcome = tool.StepOutcome{Status: tool.OutcomeSkipped, Attempts: outcome.Attempts, Error: err.Error()}
+			out, err = tool.PassthroughOutput(ctx, t, in), nil
+			event.State = StepSkipped
+		case OnErrorFallback:
+			outcome = tool.StepOutcome{Status: tool.OutcomeFallback, Attempts: outcome.Attempts, Tool: fallback.Name(), Error: err.Error()}
+			if err = fallback.Validate(in); err == nil {
+				out, err = execAttempt(ctx, fallback, in, step.Policy.Timeout)
+			}
 		}
-		current = v
+	}
+	if err != nil {
+		event.State, event.Err = StepFailed, err
+		r.report(ctx, event)
+		return err
 	}
 
-	return current, true
+	r.pctx.SetOutput(step.OutputKey, out)
+	r.pctx.SetOutcome(step.OutputKey, outcome)
+	if event.State != StepSkipped {
+		event.State = StepCompleted
+	}
+	r.report(ctx, event)
+	return nil
+}
+
+// execWithRetry runs t up to policy.Retries+1 times with exponential backoff and returns the
+// number of attempts made.
+func execWithRetry(ctx context.Context, t tool.Tool, in tool.Input, policy StepPolicy) (tool.Output, int, error) {
+	backoff := policy.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	for attempt := 1; ; attempt++ {
+		out, err := execAttempt(ctx, t, in, policy.Timeout)
+		if err == nil || attempt > policy.Retries || !retryable(ctx, err) {
+			return out, attempt, err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return out, attempt, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// execAttempt runs t once under timeout and checks its output against t.OutputSchema().
+func execAttempt(ctx context.Context, t tool.Tool, in tool.Input, timeout time.Duration) (tool.Output, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	out, err := t.Execute(ctx, in)
+	if err != nil {
+		return out, err
+	}
+	if err := tool.ValidateSchema(t.OutputSchema(), out.Data); err != nil {
+		return tool.Output{}, coreerrors.NewParsingError(
+			fmt.Sprintf("tool %s returned output that does not match its schema: %v", t.Name(), err), err)
+	}
+	return out, nil
+}
+
+// retryable reports whether a failed attempt is worth repeating: the pipeline is still running
+// and the error is not a validation error, which would fail again with the same input.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var appErr *coreerrors.AppError
+	return !errors.As(err, &appErr) || appErr.Code != coreerrors.ErrCodeValidation
+}
+
+// report fills in Completed and calls the progress hook, one event at a time.
+func (r *pipelineRun) report(ctx context.Context, event StepEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if event.State == StepCompleted || event.State == StepSkipped {
+		r.completed++
+	}
+	event.Completed = r.completed
+	r.executor.report(ctx, event)
+}
+
+func (e *Executor) report(ctx context.Context, event StepEvent) {
+	if e.progress != nil {
+		e.progress(ctx, event)
+	}
+}
+
+// stepDependencies returns, for every step, the indexes of the earlier steps it waits for:
+// those referenced by its InputMapping or When condition and those listed in DependsOn.
+func stepDependencies(p Pipeline, mappings []map[string]*Mapping, conditions []*Condition) ([][]int, error) {
+	index := make(map[string]int, len(p.Steps))
+	deps := make([][]int, len(p.Steps))
+	for i, step := range p.Steps {
+		seen := make(map[int]bool)
+		add := func(key string) bool {
+			j, ok := index[key]
+			if ok && !seen[j] {
+				seen[j] = true
+				deps[i] = append(deps[i], j)
+			}
+			return ok
+		}
+		// 引用未知步骤时不建立依赖，由输入解析报错
+		for _, m := range mappings[i] {
+			for _, source := range m.References() {
+				add(source)
+			}
+		}
+		if conditions[i] != nil {
+			for _, source := range conditions[i].References() {
+				add(source)
+			}
+		}
+		for _, key := range step.DependsOn {
+			if !add(key) {
+				return nil, coreerrors.NewValidationError(fmt.Sprintf("pipeline step %s depends on %q, which is not an earlier step", step.OutputKey, key), nil)
+			}
+		}
+		index[step.OutputKey] = i
+	}
+	return deps, nil
+}
+
+func allFinished(indexes []int, finished []bool) bool {
+	for _, i := range indexes {
+		if !finished[i] {
+			return false
+		}
+	}
+	return true
 }
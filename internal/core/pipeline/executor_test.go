@@ -2,21 +2,29 @@ package pipeline
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
 )
 
 type mockTool struct {
-	name     string
-	validate func(tool.Input) error
-	execute  func(context.Context, tool.Input) (tool.Output, error)
+	name         string
+	outputSchema map[string]interface{}
+	validate     func(tool.Input) error
+	execute      func(context.Context, tool.Input) (tool.Output, error)
 }
 
 func (m mockTool) Name() string                         { return m.name }
 func (m mockTool) Description() string                  { return m.name }
 func (m mockTool) Schema() map[string]interface{}       { return nil }
-func (m mockTool) OutputSchema() map[string]interface{} { return nil }
+func (m mockTool) OutputSchema() map[string]interface{} { return m.outputSchema }
 func (m mockTool) Validate(in tool.Input) error {
 	if m.validate != nil {
 		return m.validate(in)
@@ -119,3 +127,529 @@ func TestExecutor_Execute_MissingMapping(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestExecutor_Execute_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{name: "noop"})
+	_ = reg.Register(mockTool{
+		name: "boom",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			return tool.Output{}, errors.New("boom")
+		},
+	})
+
+	var events []StepEvent
+	exec := NewExecutor(reg).WithProgressHook(func(ctx context.Context, e StepEvent) {
+		events = append(events, e)
+	})
+	_, err := exec.Execute(context.Background(), Pipeline{
+		Name: "p",
+		Steps: []Step{
+			{ToolName: "noop", OutputKey: "first"},
+			{ToolName: "boom", OutputKey: "second", DependsOn: []string{"first"}},
+		},
+	}, nil)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	want := []struct {
+		step      string
+		index     int
+		state     StepState
+		completed int
+	}{
+		{"first", 0, StepStarted, 0},
+		{"first", 0, StepCompleted, 1},
+		{"second", 1, StepStarted, 1},
+		{"second", 1, StepFailed, 1},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events=%+v want %d", events, len(want))
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Pipeline != "p" || e.Step != w.step || e.Index != w.index || e.Total != 2 || e.State != w.state || e.Completed != w.completed {
+			t.Fatalf("event %d = %+v", i, e)
+		}
+	}
+	if events[3].Err == nil {
+		t.Fatalf("failed event should carry the error")
+	}
+}
+
+func TestExecutor_Execute_RunsIndependentStepsInParallel(t *testing.T) {
+	t.Parallel()
+
+	// 两个翻译步骤都只依赖 asr_result，必须同时运行才能通过屏障
+	var wg sync.WaitGroup
+	wg.Add(2)
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{
+		name: "asr",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			return tool.Output{Data: map[string]interface{}{"text": "hello"}}, nil
+		},
+	})
+	_ = reg.Register(mockTool{
+		name: "translate",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			wg.Done()
+			wg.Wait()
+			lang := in.Data["target_languages"].([]string)[0]
+			return tool.Output{Data: map[string]interface{}{"translations": map[string]string{lang: in.Data["text"].(string)}}}, nil
+		},
+	})
+	_ = reg.Register(mockTool{name: "merge"})
+
+	p := Pipeline{Name: "fanout", Steps: []Step{
+		{ToolName: "asr", OutputKey: "asr_result"},
+		{ToolName: "translate", InputMapping: map[string]string{"text": "asr_result.text", "target_languages": "request.group_a"}, OutputKey: "translate_a"},
+		{ToolName: "translate", InputMapping: map[string]string{"text": "asr_result.text", "target_languages": "request.group_b"}, OutputKey: "translate_b"},
+		{ToolName: "merge", OutputKey: "merged", DependsOn: []string{"translate_a", "translate_b"}},
+	}}
+
+	var mu sync.Mutex
+	var order []string
+	exec := NewExecutor(reg).WithMaxParallel(2).WithProgressHook(func(ctx context.Context, e StepEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if e.State == StepCompleted {
+			order = append(order, e.Step)
+			if e.Completed != len(order) {
+				t.Errorf("completed=%d after %d completions", e.Completed, len(order))
+			}
+		}
+	})
+
+	done := make(chan struct{})
+	var outCtx *tool.PipelineContext
+	var err error
+	go func() {
+		defer close(done)
+		outCtx, err = exec.Execute(context.Background(), p, &tool.PipelineContext{
+			OriginalRequest: map[string]interface{}{"group_a": []string{"en"}, "group_b": []string{"ja"}},
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("independent steps did not run in parallel")
+	}
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	res := Collect(p, outCtx)
+	if res.Translations["en"] != "hello" || res.Translations["ja"] != "hello" {
+		t.Fatalf("translations=%v", res.Translations)
+	}
+	if len(order) != 4 || order[0] != "asr_result" || order[3] != "merged" {
+		t.Fatalf("completion order=%v", order)
+	}
+}
+
+func TestExecutor_Execute_RespectsMaxParallel(t *testing.T) {
+	t.Parallel()
+
+	var running, peak int32
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{
+		name: "slow",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return tool.Output{}, nil
+		},
+	})
+
+	p := Pipeline{Name: "wide"}
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		p.Steps = append(p.Steps, Step{ToolName: "slow", OutputKey: key})
+	}
+	if _, err := NewExecutor(reg).WithMaxParallel(2).Execute(context.Background(), p, nil); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := atomic.LoadInt32(&peak); got != 2 {
+		t.Fatalf("peak concurrency=%d want 2", got)
+	}
+}
+
+func TestExecutor_Execute_CancelsSiblingsOnError(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	siblingCanceled := make(chan struct{})
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{
+		name: "wait",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			select {
+			case <-ctx.Done():
+				close(siblingCanceled)
+				return tool.Output{}, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return tool.Output{}, nil
+			}
+		},
+	})
+	_ = reg.Register(mockTool{
+		name: "boom",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			return tool.Output{}, boom
+		},
+	})
+	_ = reg.Register(mockTool{
+		name: "after",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			t.Errorf("dependent step must not run after a failure")
+			return tool.Output{}, nil
+		},
+	})
+
+	_, err := NewExecutor(reg).Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{
+		{ToolName: "wait", OutputKey: "slow"},
+		{ToolName: "boom", OutputKey: "fails"},
+		{ToolName: "after", OutputKey: "after", DependsOn: []string{"slow"}},
+	}}, nil)
+	if !errors.Is(err, boom) {
+		t.Fatalf("err=%v want boom", err)
+	}
+	select {
+	case <-siblingCanceled:
+	default:
+		t.Fatalf("running sibling was not canceled")
+	}
+}
+
+func TestExecutor_Execute_UnknownDependency(t *testing.T) {
+	t.Parallel()
+
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{name: "noop"})
+
+	_, err := NewExecutor(reg).Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{
+		{ToolName: "noop", OutputKey: "first", DependsOn: []string{"second"}},
+		{ToolName: "noop", OutputKey: "second"},
+	}}, nil)
+	if err == nil {
+		t.Fatalf("expected error for dependency on a later step")
+	}
+}
+
+func TestExecutor_Execute_SkipsStepsWhenConditionFalse(t *testing.T) {
+	t.Parallel()
+
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{
+		name: "asr",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			return tool.Output{Data: map[string]interface{}{"text": "lol", "language": "en"}}, nil
+		},
+	})
+	_ = reg.Register(mockTool{
+		name: "correct",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			t.Errorf("correct must be skipped for short text")
+			return tool.Output{}, nil
+		},
+	})
+	_ = reg.Register(mockTool{
+		name: "echo",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			return tool.Output{Data: map[string]interface{}{"echo": in.Data["text"]}}, nil
+		},
+	})
+
+	var mu sync.Mutex
+	var states []StepState
+	exec := NewExecutor(reg).WithProgressHook(func(ctx context.Context, e StepEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if e.Step == "correct_result" {
+			states = append(states, e.State)
+			if e.Completed != 2 {
+				t.Errorf("skipped step should count as completed, got %d", e.Completed)
+			}
+		}
+	})
+	outCtx, err := exec.Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{
+		{ToolName: "asr", OutputKey: "asr_result"},
+		// 未实现 Passthrough 的工具按原样透传输入
+		{ToolName: "correct", InputMapping: map[string]string{"text": "asr_result.text"}, OutputKey: "correct_result", When: "len(asr_result.text) > 3"},
+		{ToolName: "echo", InputMapping: map[string]string{"text": "correct_result.text"}, OutputKey: "echo_result"},
+	}}, nil)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := outCtx.StepOutputs["echo_result"].Data["echo"]; got != "lol" {
+		t.Fatalf("echo=%v want lol", got)
+	}
+	if _, ok := outCtx.Metrics["correct_result"]; ok {
+		t.Fatalf("skipped step should not record a duration")
+	}
+	if len(states) != 1 || states[0] != StepSkipped {
+		t.Fatalf("correct_result states=%v want [skipped]", states)
+	}
+}
+
+func TestExecutor_Execute_InvalidCondition(t *testing.T) {
+	t.Parallel()
+
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{name: "noop"})
+
+	_, err := NewExecutor(reg).Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{
+		{ToolName: "noop", OutputKey: "first", When: "len(request.text"},
+	}}, nil)
+	if err == nil {
+		t.Fatalf("expected error for invalid condition")
+	}
+}
+
+func TestExecutor_Execute_StepPolicies(t *testing.T) {
+	t.Parallel()
+
+	newRegistry := func(failures int32, calls *int32) *tool.Registry {
+		reg := tool.NewRegistry()
+		_ = reg.Register(mockTool{
+			name: "flaky",
+			execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+				if atomic.AddInt32(calls, 1) <= failures {
+					return tool.Output{}, errors.New("upstream unavailable")
+				}
+				return tool.Output{Data: map[string]interface{}{"value": "primary"}}, nil
+			},
+		})
+		_ = reg.Register(mockTool{
+			name: "slow",
+			execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+				<-ctx.Done()
+				return tool.Output{}, ctx.Err()
+			},
+		})
+		_ = reg.Register(mockTool{
+			name: "backup",
+			execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+				return tool.Output{Data: map[string]interface{}{"value": "backup"}}, nil
+			},
+		})
+		_ = reg.Register(mockTool{
+			name: "invalid",
+			validate: func(tool.Input) error {
+				return coreerrors.NewValidationError("text is required", nil)
+			},
+		})
+		return reg
+	}
+
+	cases := map[string]struct {
+		failures int32
+		step     Step
+		wantErr  bool
+		wantOut  interface{}
+		want     tool.StepOutcome
+		calls    int32
+	}{
+		"ok": {
+			step:    Step{ToolName: "flaky", Policy: StepPolicy{Retries: 2}},
+			wantOut: "primary", want: tool.StepOutcome{Status: tool.OutcomeOK, Attempts: 1}, calls: 1,
+		},
+		"retried": {
+			failures: 2,
+			step:     Step{ToolName: "flaky", Policy: StepPolicy{Retries: 2, Backoff: time.Millisecond}},
+			wantOut:  "primary", want: tool.StepOutcome{Status: tool.OutcomeRetried, Attempts: 3}, calls: 3,
+		},
+		"retries exhausted": {
+			failures: 5,
+			step:     Step{ToolName: "flaky", Policy: StepPolicy{Retries: 1, Backoff: time.Millisecond}},
+			wantErr:  true, calls: 2,
+		},
+		"timeout then skip": {
+			step:    Step{ToolName: "slow", InputMapping: map[string]string{"value": "request.text"}, Policy: StepPolicy{Timeout: 10 * time.Millisecond, OnError: OnErrorSkip}},
+			wantOut: "original", want: tool.StepOutcome{Status: tool.OutcomeSkipped, Attempts: 1, Error: context.DeadlineExceeded.Error()},
+		},
+		"fallback": {
+			failures: 5,
+			step:     Step{ToolName: "flaky", Policy: StepPolicy{Retries: 1, Backoff: time.Millisecond, OnError: OnErrorFallback, FallbackTool: "backup"}},
+			wantOut:  "backup", want: tool.StepOutcome{Status: tool.OutcomeFallback, Attempts: 2, Tool: "backup", Error: "upstream unavailable"}, calls: 2,
+		},
+		"validation error is not retried": {
+			step:    Step{ToolName: "invalid", InputMapping: map[string]string{"value": "request.text"}, Policy: StepPolicy{Retries: 3, OnError: OnErrorSkip}},
+			wantOut: "original", want: tool.StepOutcome{Status: tool.OutcomeSkipped, Error: "text is required"},
+		},
+	}
+	for name, tc := range cases {
+		var calls int32
+		step := tc.step
+		step.OutputKey = "out"
+		outCtx, err := NewExecutor(newRegistry(tc.failures, &calls)).Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{step}}, &tool.PipelineContext{
+			OriginalRequest: map[string]interface{}{"text": "original"},
+		})
+		if got := atomic.LoadInt32(&calls); got != tc.calls {
+			t.Errorf("%s: calls=%d want %d", name, got, tc.calls)
+		}
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Execute: %v", name, err)
+		}
+		if got := outCtx.StepOutputs["out"].Data["value"]; got != tc.wantOut {
+			t.Errorf("%s: value=%v want %v", name, got, tc.wantOut)
+		}
+		if got := outCtx.StepOutcomes["out"]; got != tc.want {
+			t.Errorf("%s: outcome=%+v want %+v", name, got, tc.want)
+		}
+	}
+}
+
+func TestExecutor_Execute_ValidatesStepOutput(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{
+		name: "translate",
+		outputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"translations": map[string]interface{}{
+					"type":                 "object",
+					"additionalProperties": map[string]string{"type": "string"},
+				},
+			},
+			"required": []string{"translations"},
+		},
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return tool.Output{Data: map[string]interface{}{"translations": map[string]interface{}{"en": 1}}}, nil
+			}
+			return tool.Output{Data: map[string]interface{}{"translation": "hello"}}, nil
+		},
+	})
+
+	_, err := NewExecutor(reg).Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{
+		{ToolName: "translate", OutputKey: "translation", Policy: StepPolicy{Retries: 1, Backoff: time.Millisecond}},
+	}}, nil)
+	var appErr *coreerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != coreerrors.ErrCodeParsing {
+		t.Fatalf("expected parsing error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "missing required property \"translations\"") {
+		t.Fatalf("error does not name the violation: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("calls=%d want 2 (schema failures are retried)", got)
+	}
+}
+
+func TestExecutor_Execute_MappingExpressions(t *testing.T) {
+	t.Parallel()
+
+	newRegistry := func(got *map[string]interface{}) *tool.Registry {
+		reg := tool.NewRegistry()
+		_ = reg.Register(mockTool{
+			name: "asr",
+			execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+				return tool.Output{Data: map[string]interface{}{
+					"text":     "hello world",
+					"segments": []map[string]interface{}{{"text": "hello"}, {"text": "world"}},
+					"scores":   []float64{0.5, 2},
+				}}, nil
+			},
+		})
+		_ = reg.Register(mockTool{
+			name: "capture",
+			execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+				*got = in.Data
+				return tool.Output{}, nil
+			},
+		})
+		return reg
+	}
+
+	cases := map[string]struct {
+		expr    string
+		want    interface{}
+		wantErr bool
+	}{
+		"path":                  {expr: "asr_result.text", want: "hello world"},
+		"array index":           {expr: "asr_result.segments[1].text", want: "world"},
+		"index out of range":    {expr: "asr_result.segments[2].text", wantErr: true},
+		"string literal":        {expr: `"en"`, want: "en"},
+		"number literal":        {expr: "3", want: float64(3)},
+		"list literal":          {expr: `["en", request.lang]`, want: []interface{}{"en", "ja"}},
+		"default falls back":    {expr: "asr_result.corrected_text ?? asr_result.text", want: "hello world"},
+		"default keeps first":   {expr: "request.lang ?? 'en'", want: "ja"},
+		"default to literal":    {expr: "request.missing ?? ''", want: ""},
+		"all alternatives fail": {expr: "request.missing ?? asr_result.missing", wantErr: true},
+		"concatenation":         {expr: `asr_result.segments[0].text + ", " + request.lang + "#" + asr_result.scores[1]`, want: "hello, ja#2"},
+		"concatenation missing": {expr: `request.missing + "!" ?? "fallback"`, want: "fallback"},
+		"invalid":               {expr: "asr_result.segments[x]", wantErr: true},
+	}
+	for name, tc := range cases {
+		var got map[string]interface{}
+		_, err := NewExecutor(newRegistry(&got)).Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{
+			{ToolName: "asr", OutputKey: "asr_result"},
+			{ToolName: "capture", OutputKey: "out", InputMapping: map[string]string{"value": tc.expr}},
+		}}, &tool.PipelineContext{OriginalRequest: map[string]interface{}{"lang": "ja"}})
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Execute: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got["value"], tc.want) {
+			t.Errorf("%s: value=%#v want %#v", name, got["value"], tc.want)
+		}
+	}
+}
+
+func TestExecutor_Execute_MappingFallbackWaitsForReferencedSteps(t *testing.T) {
+	t.Parallel()
+
+	reg := tool.NewRegistry()
+	_ = reg.Register(mockTool{
+		name: "slow",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			time.Sleep(20 * time.Millisecond)
+			return tool.Output{Data: map[string]interface{}{"text": "corrected"}}, nil
+		},
+	})
+	var got interface{}
+	_ = reg.Register(mockTool{
+		name: "capture",
+		execute: func(ctx context.Context, in tool.Input) (tool.Output, error) {
+			got = in.Data["text"]
+			return tool.Output{}, nil
+		},
+	})
+
+	_, err := NewExecutor(reg).Execute(context.Background(), Pipeline{Name: "p", Steps: []Step{
+		{ToolName: "slow", OutputKey: "correct_result"},
+		{ToolName: "capture", OutputKey: "out", InputMapping: map[string]string{"text": "correct_result.text ?? request.text"}},
+	}}, &tool.PipelineContext{OriginalRequest: map[string]interface{}{"text": "original"}})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got != "corrected" {
+		t.Fatalf("text=%v want corrected (the fallback must not race the referenced step)", got)
+	}
+}
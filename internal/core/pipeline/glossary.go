@@ -0,0 +1,37 @@
+package pipeline
+
+// translationSources maps the built-in pipelines that translate to the paths of their source
+// text and translations.
+var translationSources = map[string][2]string{
+	PipelineTranslateMerged:          {"correct_translate_result.corrected_text", "correct_translate_result.translations"},
+	PipelineTranslateSplit:           {"correct_result.corrected_text", "translate_result.translations"},
+	PipelineTranslate:                {"asr_result.text", "translate_result.translations"},
+	PipelineAudioDirect:              {"asr_result.text", "asr_result.translations"},
+	PipelineTextTranslate:            {"request.text", "translate_result.translations"},
+	PipelineTextCorrectTranslate:     {"correct_translate_result.corrected_text", "correct_translate_result.translations"},
+	PipelineTextCorrectThenTranslate: {"correct_result.corrected_text", "translate_result.translations"},
+}
+
+// WithGlossaryCheck returns p with a final glossary_check step (output key glossary_result)
+// when p is a built-in pipeline that translates; other pipelines are returned unchanged.
+// Processors apply it when the dictionary defines required term translations; custom
+// pipelines declare the step themselves. A failed check keeps the translations.
+func WithGlossaryCheck(p Pipeline) Pipeline {
+	src, ok := translationSources[p.Name]
+	if !ok {
+		return p
+	}
+	steps := make([]Step, len(p.Steps), len(p.Steps)+1)
+	copy(steps, p.Steps)
+	p.Steps = append(steps, Step{
+		ToolName: "glossary_check",
+		Policy:   StepPolicy{OnError: OnErrorSkip},
+		InputMapping: map[string]string{
+			"text":            src[0],
+			"translations":    src[1],
+			"source_language": "request.source_language",
+		},
+		OutputKey: "glossary_result",
+	})
+	return p
+}
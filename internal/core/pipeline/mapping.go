@@ -0,0 +1,263 @@
+package pipeline
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+)
+
+// Mapping is a parsed Step.InputMapping expression.
+//
+// Grammar:
+//
+//	mapping = concat { "??" concat }
+//	concat  = term { "+" term }
+//	term    = string | number | true | false | null | "[" [ term { "," term } ] "]" | path
+//	path    = source "." key { "." key | "[" index "]" }
+//
+// "a ?? b" yields the first alternative that resolves to a non-nil value, so a missing key
+// falls through to the next one. A single term keeps its type; "+" joins terms as strings.
+type Mapping struct {
+	expr         string
+	alternatives [][]condNode
+}
+
+// ParseMapping parses an input mapping expression.
+func ParseMapping(expr string) (*Mapping, error) {
+	p := &condParser{src: expr}
+	p.next()
+	m := &Mapping{expr: expr}
+	for {
+		var terms []condNode
+		for {
+			term, err := p.parseTerm()
+			if err == nil {
+				err = p.err
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid mapping %q: %w", expr, err)
+			}
+			terms = append(terms, term)
+			if !p.isOp("+") {
+				break
+			}
+			p.next()
+		}
+		m.alternatives = append(m.alternatives, terms)
+		if !p.isOp("??") {
+			break
+		}
+		p.next()
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("invalid mapping %q: unexpected %q", expr, p.tok.text)
+	}
+	return m, nil
+}
+
+// String returns the source expression.
+func (m *Mapping) String() string {
+	return m.expr
+}
+
+// Resolve evaluates the mapping against pctx. It reports false when no alternative resolves.
+func (m *Mapping) Resolve(pctx *tool.PipelineContext) (interface{}, bool) {
+alternatives:
+	for a, terms := range m.alternatives {
+		last := a == len(m.alternatives)-1
+		values, ok := resolveTerms(pctx, terms)
+		if !ok {
+			continue
+		}
+		if len(values) == 1 {
+			// 显式的 nil 值仅在没有后续备选时返回
+			if values[0] == nil && !last {
+				continue
+			}
+			return values[0], true
+		}
+		var b strings.Builder
+		for _, v := range values {
+			if v == nil {
+				continue alternatives
+			}
+			b.WriteString(formatValue(v))
+		}
+		return b.String(), true
+	}
+	return nil, false
+}
+
+// resolveTerms evaluates terms; it reports false if a path cannot be resolved.
+func resolveTerms(pctx *tool.PipelineContext, terms []condNode) ([]interface{}, bool) {
+	values := make([]interface{}, len(terms))
+	for i, term := range terms {
+		if path, ok := term.(pathNode); ok {
+			v, ok := resolveValue(pctx, string(path))
+			if !ok {
+				return nil, false
+			}
+			values[i] = v
+			continue
+		}
+		values[i] = term.eval(pctx)
+	}
+	return values, true
+}
+
+// References returns the sources ("request" or step output keys) the mapping reads.
+func (m *Mapping) References() []string {
+	var nodes []condNode
+	for _, terms := range m.alternatives {
+		nodes = append(nodes, terms...)
+	}
+	return references(nodes...)
+}
+
+// parseTerm parses one mapping term: a literal, a list literal or a path.
+func (p *condParser) parseTerm() (condNode, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	switch {
+	case p.isOp("["):
+		p.next()
+		var items listNode
+		for !p.isOp("]") {
+			if len(items) > 0 {
+				if !p.isOp(",") {
+					return nil, fmt.Errorf("expected \",\" or \"]\" in list")
+				}
+				p.next()
+			}
+			item, err := p.parseTerm()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		p.next()
+		return items, nil
+	case p.tok.kind == tokIdent && p.tok.text == "null":
+		p.next()
+		return literalNode{}, nil
+	case p.tok.kind == tokIdent && p.tok.text == "len", p.tok.kind == tokOp:
+		return nil, fmt.Errorf("unexpected %q", p.tok.text)
+	}
+	return p.parseOperand()
+}
+
+// listNode is a list literal; it evaluates to nil if any element does.
+type listNode []condNode
+
+func (n listNode) eval(pctx *tool.PipelineContext) interface{} {
+	out := make([]interface{}, len(n))
+	for i, item := range n {
+		if out[i] = item.eval(pctx); out[i] == nil {
+			return nil
+		}
+	}
+	return out
+}
+func (n listNode) walk(fn func(condNode)) {
+	fn(n)
+	for _, item := range n {
+		item.walk(fn)
+	}
+}
+
+func formatValue(v interface{}) string {
+	switch vv := v.(type) {
+	case string:
+		return vv
+	case float64:
+		return strconv.FormatFloat(vv, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// pathSegment is one step of a path: a map key, or a slice index when index >= 0.
+type pathSegment struct {
+	key   string
+	index int
+}
+
+// splitPath splits "source.key[0].key" into its source and segments.
+func splitPath(expr string) (string, []pathSegment, error) {
+	end := strings.IndexAny(expr, ".[")
+	if end <= 0 || expr[end] != '.' {
+		return "", nil, fmt.Errorf("invalid path %q (paths look like request.x or <output_key>.field)", expr)
+	}
+	source := expr[:end]
+	var segments []pathSegment
+	for rest := expr[end:]; rest != ""; {
+		switch rest[0] {
+		case '.':
+			n := strings.IndexAny(rest[1:], ".[")
+			if n < 0 {
+				n = len(rest) - 1
+			}
+			if n == 0 {
+				return "", nil, fmt.Errorf("invalid path %q: empty key", expr)
+			}
+			segments = append(segments, pathSegment{key: rest[1 : n+1], index: -1})
+			rest = rest[n+1:]
+		case '[':
+			n := strings.IndexByte(rest, ']')
+			index, err := strconv.Atoi(rest[1:max(n, 1)])
+			if n < 0 || err != nil || index < 0 {
+				return "", nil, fmt.Errorf("invalid path %q: bad index", expr)
+			}
+			segments = append(segments, pathSegment{index: index})
+			rest = rest[n+1:]
+		default:
+			return "", nil, fmt.Errorf("invalid path %q", expr)
+		}
+	}
+	return source, segments, nil
+}
+
+// resolveValue looks up a path ("request.x" or "<output_key>.field[0].x") in pctx.
+func resolveValue(pctx *tool.PipelineContext, expr string) (interface{}, bool) {
+	source, segments, err := splitPath(strings.TrimSpace(expr))
+	if err != nil {
+		return nil, false
+	}
+
+	var current interface{}
+	switch source {
+	case "request":
+		current = pctx.OriginalRequest
+	default:
+		out, ok := pctx.Output(source)
+		if !ok {
+			return nil, false
+		}
+		current = out.Data
+	}
+
+	for _, seg := range segments {
+		rv := reflect.ValueOf(current)
+		if seg.index >= 0 {
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || seg.index >= rv.Len() {
+				return nil, false
+			}
+			current = rv.Index(seg.index).Interface()
+			continue
+		}
+		if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		v := rv.MapIndex(reflect.ValueOf(seg.key).Convert(rv.Type().Key()))
+		if !v.IsValid() {
+			return nil, false
+		}
+		current = v.Interface()
+	}
+
+	return current, true
+}
@@ -1,15 +1,55 @@
 package pipeline
 
+import "time"
+
 // Step is one tool execution in a pipeline.
 // InputMapping maps tool input field -> value expression (e.g. "request.audio", "asr_result.text").
+// A step depends on the earlier steps its InputMapping references plus those listed in DependsOn;
+// steps without a pending dependency run in parallel.
+// When, if set, is a Condition expression; a step whose condition is false is skipped and
+// its output is the tool's pass-through of the step input (see tool.Passthrough).
 type Step struct {
 	ToolName     string
 	InputMapping map[string]string
 	OutputKey    string
+	DependsOn    []string // output keys of earlier steps
+	When         string
+	Policy       StepPolicy
 }
 
-// Pipeline is a static, predefined sequence of tools.
+// On-error behaviors of a step whose tool fails after all retries.
+const (
+	OnErrorFail     = "fail"     // fail the pipeline (default)
+	OnErrorSkip     = "skip"     // continue with the tool's pass-through output
+	OnErrorFallback = "fallback" // run FallbackTool on the same input
+)
+
+// DefaultRetryBackoff is the delay before the first retry when StepPolicy.Backoff is unset.
+const DefaultRetryBackoff = 200 * time.Millisecond
+
+// StepPolicy controls how a step is executed and how its failures are handled.
+// The zero value runs the tool once without a timeout and fails the pipeline on error.
+type StepPolicy struct {
+	Timeout      time.Duration // per attempt; 0 = no limit
+	Retries      int           // extra attempts after a failure; validation errors are not retried
+	Backoff      time.Duration // delay before the first retry, doubled for each further retry
+	OnError      string        // OnErrorFail / OnErrorSkip / OnErrorFallback
+	FallbackTool string        // tool run when OnError is OnErrorFallback
+}
+
+// Pipeline is a static, predefined list of tools, ordered so that every step comes after
+// the steps it depends on.
 type Pipeline struct {
 	Name  string
 	Steps []Step
 }
+
+// UsesTool reports whether a step of p runs the named tool, directly or as its fallback.
+func (p Pipeline) UsesTool(name string) bool {
+	for _, step := range p.Steps {
+		if step.ToolName == name || step.Policy.FallbackTool == name {
+			return true
+		}
+	}
+	return false
+}
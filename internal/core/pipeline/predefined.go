@@ -6,8 +6,21 @@ const (
 	PipelineTranslateMerged   = "translate_merged"
 	PipelineTranslateSplit    = "translate_split"
 	PipelineTranslate         = "translate"
+	PipelineAudioDirect       = "audio_direct"
 )
 
+// correctionPolicy keeps the uncorrected text when a standalone correction step fails,
+// so a correction outage does not fail requests whose transcription succeeded.
+var correctionPolicy = StepPolicy{OnError: OnErrorSkip}
+
+// whenTranslationNeeded skips a translate step when the detected language is the only target.
+const whenTranslationNeeded = "len(request.target_languages) != 1 || asr_result.language not in request.target_languages"
+
+// AudioBuiltins returns the built-in audio pipelines.
+func AudioBuiltins() []Pipeline {
+	return []Pipeline{Transcribe(), TranscribeCorrect(), TranslateMerged(), TranslateSplit(), Translate(), AudioDirect()}
+}
+
 func Transcribe() Pipeline {
 	return Pipeline{
 		Name: PipelineTranscribe,
@@ -40,6 +53,7 @@ func TranscribeCorrect() Pipeline {
 			},
 			{
 				ToolName: "correct",
+				Policy:   correctionPolicy,
 				InputMapping: map[string]string{
 					"text": "asr_result.text",
 				},
@@ -89,6 +103,7 @@ func TranslateSplit() Pipeline {
 			},
 			{
 				ToolName: "correct",
+				Policy:   correctionPolicy,
 				InputMapping: map[string]string{
 					"text": "asr_result.text",
 				},
@@ -96,6 +111,7 @@ func TranslateSplit() Pipeline {
 			},
 			{
 				ToolName: "translate",
+				When:     whenTranslationNeeded,
 				InputMapping: map[string]string{
 					"text":             "correct_result.corrected_text",
 					"source_language":  "request.source_language",
@@ -122,6 +138,7 @@ func Translate() Pipeline {
 			},
 			{
 				ToolName: "translate",
+				When:     whenTranslationNeeded,
 				InputMapping: map[string]string{
 					"text":             "asr_result.text",
 					"source_language":  "request.source_language",
@@ -132,3 +149,23 @@ func Translate() Pipeline {
 		},
 	}
 }
+
+// AudioDirect sends audio straight to an audio-capable chat model for transcription and translation.
+// The step output is keyed as asr_result so response building treats it like an ASR stage.
+func AudioDirect() Pipeline {
+	return Pipeline{
+		Name: PipelineAudioDirect,
+		Steps: []Step{
+			{
+				ToolName: "audio_llm",
+				InputMapping: map[string]string{
+					"audio":            "request.audio",
+					"format":           "request.audio_format",
+					"source_language":  "request.source_language",
+					"target_languages": "request.target_languages",
+				},
+				OutputKey: "asr_result",
+			},
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+)
+
+// StepState is the lifecycle state reported for a step.
+type StepState string
+
+const (
+	StepStarted   StepState = "started"
+	StepCompleted StepState = "completed"
+	StepFailed    StepState = "failed"
+	StepSkipped   StepState = "skipped" // When condition was false
+)
+
+// StepEvent describes a step transition.
+type StepEvent struct {
+	Pipeline string
+	Step     string // output key
+	Tool     string
+	Index    int // 0-based
+	Total    int
+	// Completed is the number of steps of this run finished (or skipped) so far, including
+	// this one when State is StepCompleted or StepSkipped. Steps may run in parallel, so Index alone does not
+	// reflect progress.
+	Completed int
+	State     StepState
+	Duration  time.Duration // completed / failed only
+	Err       error         // failed only
+}
+
+// ProgressHook is called synchronously by the executor on every step transition.
+type ProgressHook func(ctx context.Context, event StepEvent)
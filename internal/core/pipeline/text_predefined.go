@@ -8,12 +8,21 @@ const (
 	PipelineTextPassthrough          = "text_passthrough"
 )
 
+// whenTextTranslationNeeded skips a text_translate step when the declared source language is the only target.
+const whenTextTranslationNeeded = "len(request.target_languages) != 1 || request.source_language not in request.target_languages"
+
+// TextBuiltins returns the built-in text pipelines.
+func TextBuiltins() []Pipeline {
+	return []Pipeline{TextTranslate(), TextCorrect(), TextCorrectTranslate(), TextCorrectThenTranslate(), TextPassthrough()}
+}
+
 func TextTranslate() Pipeline {
 	return Pipeline{
 		Name: PipelineTextTranslate,
 		Steps: []Step{
 			{
 				ToolName: "text_translate",
+				When:     whenTextTranslationNeeded,
 				InputMapping: map[string]string{
 					"text":             "request.text",
 					"source_language":  "request.source_language",
@@ -31,6 +40,7 @@ func TextCorrect() Pipeline {
 		Steps: []Step{
 			{
 				ToolName: "text_correct",
+				Policy:   correctionPolicy,
 				InputMapping: map[string]string{
 					"text": "request.text",
 				},
@@ -62,6 +72,7 @@ func TextCorrectThenTranslate() Pipeline {
 		Steps: []Step{
 			{
 				ToolName: "text_correct",
+				Policy:   correctionPolicy,
 				InputMapping: map[string]string{
 					"text": "request.text",
 				},
@@ -69,6 +80,7 @@ func TextCorrectThenTranslate() Pipeline {
 			},
 			{
 				ToolName: "text_translate",
+				When:     whenTextTranslationNeeded,
 				InputMapping: map[string]string{
 					"text":             "correct_result.corrected_text",
 					"source_language":  "request.source_language",
@@ -0,0 +1,47 @@
+package pipeline
+
+// OptionTTS is the request option that asks for speech: true, or an object with optional
+// language, voice and delivery fields.
+const OptionTTS = "tts"
+
+// SpeechRequested reports whether the request options ask for speech.
+func SpeechRequested(options map[string]interface{}) bool {
+	switch v := options[OptionTTS].(type) {
+	case bool:
+		return v
+	case map[string]interface{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithTTS returns p with a final tts step (output key tts_result) when p is a built-in
+// pipeline that translates; other pipelines are returned unchanged. The step speaks the
+// translation selected by request.options.tts.language (default: the first target language),
+// after glossary_check when p has one. Processors apply it when a request asks for speech;
+// custom pipelines declare the step themselves. A failed synthesis keeps the translations.
+func WithTTS(p Pipeline) Pipeline {
+	src, ok := translationSources[p.Name]
+	if !ok {
+		return p
+	}
+	translations := src[1]
+	if p.UsesTool("glossary_check") {
+		translations = "glossary_result.translations"
+	}
+	steps := make([]Step, len(p.Steps), len(p.Steps)+1)
+	copy(steps, p.Steps)
+	p.Steps = append(steps, Step{
+		ToolName: "tts",
+		Policy:   StepPolicy{OnError: OnErrorSkip},
+		InputMapping: map[string]string{
+			"translations": translations,
+			"language":     "request.options.tts.language ?? request.target_languages[0]",
+			"voice":        "request.options.tts.voice ?? null",
+			"delivery":     "request.options.tts.delivery ?? null",
+		},
+		OutputKey: "tts_result",
+	})
+	return p
+}
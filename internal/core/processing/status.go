@@ -1,9 +1,14 @@
 package processing
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/pipeline"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
 )
 
 var ErrStatusNotFound = errors.New("processing status not found")
@@ -14,9 +19,18 @@ type ProcessingStatus struct {
 	Status    string `json:"status"`
 	Progress  int    `json:"progress"`
 	Message   string `json:"message,omitempty"`
+	Pipeline  string `json:"pipeline,omitempty"`
+	Step      string `json:"step,omitempty"`       // 当前 pipeline step（如 asr_result）
+	StepIndex int    `json:"step_index,omitempty"` // 从 1 开始
+	StepCount int    `json:"step_count,omitempty"`
 	UpdatedAt int64  `json:"updated_at"`
 }
 
+// Terminal reports whether the request has finished (successfully or not).
+func (s *ProcessingStatus) Terminal() bool {
+	return s.Status == "completed" || s.Status == "failed"
+}
+
 // StatusStore provides a storage interface for ProcessingStatus lookups.
 type StatusStore interface {
 	Get(requestID string) (*ProcessingStatus, error)
@@ -84,3 +98,35 @@ func (s *InMemoryStatusStore) Set(requestID string, status *ProcessingStatus) er
 	})
 	return nil
 }
+
+// PipelineProgress returns a pipeline.ProgressHook that records step progress for the request ID in ctx.
+// Progress stays below 100 until the handler marks the request completed; failures are left to the handler.
+func PipelineProgress(store StatusStore) pipeline.ProgressHook {
+	return func(ctx context.Context, event pipeline.StepEvent) {
+		if store == nil || event.State == pipeline.StepFailed || event.Total <= 0 {
+			return
+		}
+		requestID, ok := logging.RequestIDFromContext(ctx)
+		if !ok || requestID == "" {
+			return
+		}
+
+		done := event.Completed
+		message := fmt.Sprintf("Running %s (%d/%d)", event.Tool, event.Index+1, event.Total)
+		switch event.State {
+		case pipeline.StepSkipped:
+			message = fmt.Sprintf("Skipped %s (%d/%d)", event.Tool, event.Index+1, event.Total)
+		case pipeline.StepCompleted:
+			message = fmt.Sprintf("Finished %s (%d/%d)", event.Tool, event.Index+1, event.Total)
+		}
+		_ = store.Set(requestID, &ProcessingStatus{
+			Status:    "processing",
+			Progress:  min(done*100/event.Total, 99),
+			Message:   message,
+			Pipeline:  event.Pipeline,
+			Step:      event.Step,
+			StepIndex: event.Index + 1,
+			StepCount: event.Total,
+		})
+	}
+}
@@ -3,6 +3,8 @@ package prompt
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
@@ -152,6 +154,55 @@ func (e *Engine) BuildTextCorrectTranslatePrompt(ctx context.Context, sourceText
 	return p, nil
 }
 
+// BuildAudioTranscribeTranslatePrompt builds a single-call transcription+translation prompt
+// for chat models that receive the audio directly. targetLangCodes may be empty for transcription only.
+func (e *Engine) BuildAudioTranscribeTranslatePrompt(ctx context.Context, sourceLanguage string, targetLangCodes []string, dictionary []config.DictionaryTerm) (*Prompt, error) {
+	targetLanguageNames, err := e.languageManager.ConvertCodesToDisplayNames(targetLangCodes)
+	if err != nil {
+		var appErr *coreerrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, coreerrors.NewValidationError("convert target language codes failed", err)
+	}
+
+	data := map[string]interface{}{
+		"SourceLanguage":           sourceLanguage,
+		"TargetLanguageCodes":      targetLangCodes,
+		"TargetLanguageNames":      targetLanguageNames,
+		"TargetLanguageStyleNotes": e.languageManager.BuildStyleNotes(targetLangCodes),
+		"Dictionary":               dictionary,
+	}
+
+	p, _, err := e.templateManager.BuildPrompt(ctx, "audio_transcribe_translate", data)
+	if err != nil {
+		return nil, coreerrors.NewInternalError("build audio transcribe+translate prompt failed", err)
+	}
+
+	p.OutputRules = e.languageManager.BuildDynamicOutputRules(TaskTranslate, targetLangCodes, true)
+	return p, nil
+}
+
+// ModerationItem is one text submitted to the moderation classifier.
+type ModerationItem struct {
+	ID   string // 回复中引用的编号，如 text、translations.en
+	Text string
+}
+
+// BuildModerationPrompt builds a classifier prompt that labels items with the given categories.
+func (e *Engine) BuildModerationPrompt(ctx context.Context, items []ModerationItem, categories []string) (*Prompt, error) {
+	data := map[string]interface{}{
+		"Items":      items,
+		"Categories": categories,
+	}
+
+	p, _, err := e.templateManager.BuildPrompt(ctx, "content_moderate", data)
+	if err != nil {
+		return nil, coreerrors.NewInternalError("build moderation prompt failed", err)
+	}
+	return p, nil
+}
+
 // BuildTextPrompt 构建文本翻译提示词
 func (e *Engine) BuildTextPrompt(ctx context.Context, req PromptRequest) (*Prompt, error) {
 	// 将短代码转换为中文显示名称用于构建LLM prompt
@@ -195,6 +246,23 @@ func (e *Engine) GetLanguages() map[string]*Language {
 	return e.languageManager.GetLanguages()
 }
 
+// NormalizeLanguage 将语言代码、别名或配置的语言名称（不区分大小写）转换为语言代码，
+// 用于把 ASR 返回的 "English" / "english" 等识别结果统一为 "en"
+func (e *Engine) NormalizeLanguage(input string) (string, error) {
+	if code, err := e.languageManager.NormalizeLanguage(input); err == nil {
+		return code, nil
+	}
+	name := strings.TrimSpace(input)
+	for code, lang := range e.languageManager.GetLanguages() {
+		for _, n := range lang.Names {
+			if strings.EqualFold(name, n) {
+				return code, nil
+			}
+		}
+	}
+	return "", coreerrors.NewValidationError(fmt.Sprintf("unknown language: %s", input), nil)
+}
+
 // ParseResponse 解析LLM响应 - 仅支持 JSON 解析
 func (e *Engine) ParseResponse(content string) (*ParsedResponse, error) {
 	// 只进行 JSON 块解析，失败时直接返回错误
@@ -2,6 +2,7 @@ package prompt
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
@@ -52,6 +53,35 @@ func TestEngine_Build_AudioTranslate(t *testing.T) {
 	}
 }
 
+func TestEngine_BuildAudioTranscribeTranslatePrompt(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.NewTestLogger()
+	engine, err := NewEngine(newTestPromptConfig(), logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	p, err := engine.BuildAudioTranscribeTranslatePrompt(context.Background(), "zh", []string{"en"}, nil)
+	if err != nil {
+		t.Fatalf("BuildAudioTranscribeTranslatePrompt: %v", err)
+	}
+	if !strings.Contains(p.System, `"transcription"`) || !strings.Contains(p.System, `"en"`) {
+		t.Fatalf("expected transcription and translation keys in system prompt, got: %s", p.System)
+	}
+	if len(p.OutputRules.Sections) != 2 {
+		t.Fatalf("sections=%d want 2 (source + en)", len(p.OutputRules.Sections))
+	}
+
+	p, err = engine.BuildAudioTranscribeTranslatePrompt(context.Background(), "", nil, nil)
+	if err != nil {
+		t.Fatalf("BuildAudioTranscribeTranslatePrompt (transcribe only): %v", err)
+	}
+	if strings.Contains(p.System, `"translations"`) {
+		t.Fatalf("expected no translations block for transcription-only prompt")
+	}
+}
+
 func TestEngine_BuildTextPrompt(t *testing.T) {
 	t.Parallel()
 
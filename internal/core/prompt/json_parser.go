@@ -16,6 +16,11 @@ func extractJSONBlock(raw string) ([]byte, bool) {
 	return nil, false
 }
 
+// ExtractJSONBlock returns the content of the first ```json``` block in raw.
+func ExtractJSONBlock(raw string) ([]byte, bool) {
+	return extractJSONBlock(raw)
+}
+
 // parseJSONResponse 解析 JSON 响应数据
 func parseJSONResponse(jsonData []byte) (*ParsedResponse, error) {
 	var parsed struct {
@@ -158,9 +158,91 @@ func (tm *TemplateManager) loadDefaultTemplates() error {
 {{ .SourceText }}`,
 	}
 
+	// 音频直连模板（音频直接输入支持音频的对话模型，一次完成转写与翻译）
+	audioTemplate := &PromptTemplate{
+		Name:        "audio_transcribe_translate",
+		Version:     "1.0",
+		Description: "音频直接转写并翻译（单次调用）",
+		SystemPrompt: `你是一个专业的语音转写和翻译助手。
+
+你的任务：
+1. 准确转写音频中的语音内容{{ if .SourceLanguage }}（源语言：{{ .SourceLanguage }}）{{ end }}
+2. 参考用户词典替换专有名词
+{{- if .TargetLanguageNames }}
+3. 将转写文本翻译成目标语言
+{{- end }}
+
+{{- if .Dictionary }}
+
+【用户词典】
+{{- range .Dictionary }}
+- {{ .Term }}（可能被误识别为：{{ join .Aliases ", " }}）
+{{- end }}
+{{- end }}
+
+{{- if .TargetLanguageNames }}
+
+【目标语言】
+{{- range $index, $langName := .TargetLanguageNames }}
+{{ add $index 1 }}. {{ $langName }}
+{{- end }}
+{{- end }}
+
+{{- if .TargetLanguageStyleNotes }}
+
+【翻译风格说明】
+{{- range .TargetLanguageStyleNotes }}
+- {{ .DisplayName }}：{{ .Note }}
+{{- end }}
+{{- end }}
+
+请以 JSON 格式输出：
+` + "```json" + `
+{
+  "transcription": "<音频转写文本>"{{ if .TargetLanguageCodes }},
+  "translations": {
+{{- range $index, $langCode := .TargetLanguageCodes }}
+    "{{ $langCode }}": "<{{ index $.TargetLanguageNames $index }} 译文>"{{ if ne $index (sub (len $.TargetLanguageCodes) 1) }},{{ end }}
+{{- end }}
+  }{{ end }}
+}
+` + "```",
+		UserPrompt: `请处理这段音频。`,
+	}
+
+	// 内容审核分类模板
+	moderationTemplate := &PromptTemplate{
+		Name:        "content_moderate",
+		Version:     "1.0",
+		Description: "内容审核分类",
+		SystemPrompt: `你是一个内容审核分类器。请判断每段文本是否包含以下类别的有害内容：
+{{- range .Categories }}
+- {{ . }}
+{{- end }}
+
+只标注明确属于上述类别的内容，正常的玩笑、游戏术语和日常用语不要标注。
+
+请以 JSON 格式输出，仅列出命中的文本编号及其类别：
+` + "```json" + `
+{
+  "flagged": {
+    "<文本编号>": ["<类别>"]
+  }
+}
+` + "```",
+		UserPrompt: `待审核的文本：
+{{- range .Items }}
+
+[{{ .ID }}]
+{{ .Text }}
+{{- end }}`,
+	}
+
 	tm.templates["text_correct"] = correctionTemplate
 	tm.templates["text_correct_translate"] = correctAndTranslateTemplate
 	tm.templates["text_translate"] = textTemplate
+	tm.templates["audio_transcribe_translate"] = audioTemplate
+	tm.templates["content_moderate"] = moderationTemplate
 
 	return nil
 }
@@ -0,0 +1,195 @@
+package stream
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+)
+
+// decodedSampleRate is the rate ffmpeg-decoded streams (Opus) are resampled to.
+const decodedSampleRate = 16000
+
+// decoder turns incoming audio frames into mono PCM16 samples delivered to a callback.
+type decoder interface {
+	// Write consumes one frame. Frames may split samples or container pages arbitrarily.
+	Write(frame []byte) error
+	// Close flushes buffered audio; the callback is not invoked after Close returns.
+	Close() error
+	// SampleRate is the rate of the samples passed to the callback.
+	SampleRate() int
+}
+
+// newDecoder picks a decoder for the stream format. Raw PCM is decoded in-process;
+// Opus (in an Ogg or WebM container) is piped through a long-lived ffmpeg process.
+func newDecoder(ctx context.Context, format string, sampleRate, channels int, onSamples func([]int16)) (decoder, error) {
+	switch strings.ToLower(format) {
+	case audio.FormatPCMS16LE, audio.FormatPCMF32LE:
+		if sampleRate <= 0 {
+			return nil, fmt.Errorf("sample_rate is required for %s", format)
+		}
+		if channels <= 0 {
+			channels = 1
+		}
+		return &pcmDecoder{format: strings.ToLower(format), sampleRate: sampleRate, channels: channels, onSamples: onSamples}, nil
+	case "opus", "ogg":
+		return newFFmpegDecoder(ctx, "ogg", onSamples)
+	case "webm":
+		return newFFmpegDecoder(ctx, "matroska", onSamples)
+	default:
+		return nil, fmt.Errorf("unsupported stream audio format: %s", format)
+	}
+}
+
+// pcmDecoder downmixes headerless little-endian PCM to mono PCM16.
+type pcmDecoder struct {
+	format     string
+	sampleRate int
+	channels   int
+	onSamples  func([]int16)
+	remainder  []byte
+}
+
+func (d *pcmDecoder) SampleRate() int { return d.sampleRate }
+
+func (d *pcmDecoder) Write(frame []byte) error {
+	sampleSize := 2
+	if d.format == audio.FormatPCMF32LE {
+		sampleSize = 4
+	}
+	blockSize := sampleSize * d.channels
+
+	data := frame
+	if len(d.remainder) > 0 {
+		data = append(d.remainder, frame...)
+	}
+	whole := len(data) / blockSize * blockSize
+	samples := make([]int16, 0, whole/blockSize)
+	for off := 0; off < whole; off += blockSize {
+		var sum float64
+		for ch := 0; ch < d.channels; ch++ {
+			b := data[off+ch*sampleSize:]
+			if sampleSize == 2 {
+				sum += float64(int16(binary.LittleEndian.Uint16(b)))
+			} else {
+				sum += float64(math.Float32frombits(binary.LittleEndian.Uint32(b))) * 32767
+			}
+		}
+		samples = append(samples, clampInt16(sum/float64(d.channels)))
+	}
+	d.remainder = append(d.remainder[:0], data[whole:]...)
+	if len(samples) > 0 {
+		d.onSamples(samples)
+	}
+	return nil
+}
+
+func (d *pcmDecoder) Close() error {
+	d.remainder = nil
+	return nil
+}
+
+func clampInt16(v float64) int16 {
+	switch {
+	case v > math.MaxInt16:
+		return math.MaxInt16
+	case v < math.MinInt16:
+		return math.MinInt16
+	default:
+		return int16(v)
+	}
+}
+
+// ffmpegDecoder feeds container bytes to ffmpeg's stdin and reads s16le mono from its stdout.
+type ffmpegDecoder struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	onSamples func([]int16)
+	done      chan struct{}
+	closeOnce sync.Once
+	readErr   error
+}
+
+func newFFmpegDecoder(ctx context.Context, inputFormat string, onSamples func([]int16)) (*ffmpegDecoder, error) {
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-hide_banner",
+		"-loglevel", "error",
+		"-f", inputFormat,
+		"-i", "pipe:0",
+		"-f", "s16le",
+		"-ac", "1",
+		"-ar", fmt.Sprint(decodedSampleRate),
+		"pipe:1",
+	)
+	cmd.WaitDelay = time.Second
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start ffmpeg decoder: %w", err)
+	}
+
+	d := &ffmpegDecoder{cmd: cmd, stdin: stdin, onSamples: onSamples, done: make(chan struct{})}
+	go d.readLoop(stdout)
+	return d, nil
+}
+
+func (d *ffmpegDecoder) SampleRate() int { return decodedSampleRate }
+
+func (d *ffmpegDecoder) readLoop(stdout io.Reader) {
+	defer close(d.done)
+	buf := make([]byte, decodedSampleRate/10*2) // 100ms
+	var odd []byte
+	for {
+		n, err := stdout.Read(buf)
+		if n > 0 {
+			data := append(odd, buf[:n]...)
+			whole := len(data) &^ 1
+			samples := make([]int16, whole/2)
+			for i := range samples {
+				samples[i] = int16(binary.LittleEndian.Uint16(data[2*i:]))
+			}
+			odd = append(odd[:0], data[whole:]...)
+			d.onSamples(samples)
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				d.readErr = err
+			}
+			return
+		}
+	}
+}
+
+func (d *ffmpegDecoder) Write(frame []byte) error {
+	if _, err := d.stdin.Write(frame); err != nil {
+		return fmt.Errorf("ffmpeg decoder: %w", err)
+	}
+	return nil
+}
+
+func (d *ffmpegDecoder) Close() error {
+	var err error
+	d.closeOnce.Do(func() {
+		_ = d.stdin.Close()
+		<-d.done
+		err = d.cmd.Wait()
+		if err == nil {
+			err = d.readErr
+		}
+	})
+	return err
+}
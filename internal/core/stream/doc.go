@@ -0,0 +1,5 @@
+// Package stream implements real-time speech sessions: incoming audio frames are decoded to PCM,
+// segmented into utterances by an energy-based voice activity detector, and each utterance is
+// run through the audio pipeline while interim and final results are pushed back to the client.
+// The session is transport-agnostic; the WebSocket handler is one front end.
+package stream
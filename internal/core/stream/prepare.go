@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
+)
+
+// Validator checks an utterance request before any audio arrives; *audio.Processor implements it.
+type Validator interface {
+	Validate(req audio.ProcessRequest) error
+}
+
+// PrepareSettings validates the stream parameters of a start message against the caller's limits
+// and completes settings.Request so that every utterance passes the same checks as /process_audio.
+// settings.Request must already carry the task, languages and caller fields.
+func PrepareSettings(v Validator, settings Settings, limits audio.Limits) (Settings, error) {
+	format := strings.ToLower(strings.TrimSpace(settings.AudioFormat))
+	if !limits.AllowsFormat(format) {
+		return Settings{}, fmt.Errorf("unsupported audio format: %s", settings.AudioFormat)
+	}
+	if audio.IsRawPCMFormat(format) && (settings.SampleRate < 8000 || settings.SampleRate > 192000) {
+		return Settings{}, fmt.Errorf("sample_rate must be between 8000 and 192000 for %s, got %d", format, settings.SampleRate)
+	}
+	if settings.Channels < 0 || settings.Channels > 8 {
+		return Settings{}, fmt.Errorf("channels must be between 1 and 8, got %d", settings.Channels)
+	}
+	// 会话内部将解码后的 PCM 交给处理流程，因此每句都以 pcm_s16le 提交
+	if !limits.AllowsFormat(audio.FormatPCMS16LE) {
+		limits.AllowedFormats = append(limits.AllowedFormats, audio.FormatPCMS16LE)
+	}
+	settings.AudioFormat = format
+	settings.Request.Limits = &limits
+
+	// 在收到音频前按与每句相同的规则校验任务、语言与预处理配置
+	probe := settings.Request
+	probe.Audio, probe.AudioFormat, probe.SampleRate, probe.Channels = make([]byte, 320), audio.FormatPCMS16LE, 16000, 1
+	if err := v.Validate(probe); err != nil {
+		return Settings{}, err
+	}
+	return settings, nil
+}
+
+// ProcessorBackend runs utterances through the same processing service as /process_audio.
+type ProcessorBackend struct {
+	processor *audio.Processor
+	service   *processing.Service[audio.ProcessRequest, *audio.ProcessResponse]
+}
+
+// NewProcessorBackend creates a Backend backed by the audio processor and processing service.
+func NewProcessorBackend(processor *audio.Processor, service *processing.Service[audio.ProcessRequest, *audio.ProcessResponse]) *ProcessorBackend {
+	return &ProcessorBackend{processor: processor, service: service}
+}
+
+// Transcribe implements Backend.
+func (b *ProcessorBackend) Transcribe(ctx context.Context, req audio.ProcessRequest) (string, error) {
+	resp, err := b.processor.Transcribe(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Text, nil
+}
+
+// Process implements Backend.
+func (b *ProcessorBackend) Process(ctx context.Context, req audio.ProcessRequest) (*audio.ProcessResponse, error) {
+	resp, err := b.service.Process(ctx, req, b.processor)
+	if err != nil {
+		return nil, err
+	}
+	metrics.ObserveAudioProcessingDuration(time.Duration(resp.ProcessingTime * float64(time.Second)))
+	return resp, nil
+}
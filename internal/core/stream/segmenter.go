@@ -0,0 +1,241 @@
+package stream
+
+import (
+	"math"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+)
+
+// 分段默认值（与 config/defaults.go 保持一致，零值配置时生效）
+const (
+	defaultSilenceThresholdDB = -40.0
+	defaultEndSilence         = 600 * time.Millisecond
+	defaultMinSpeech          = 250 * time.Millisecond
+	defaultMaxUtterance       = 15 * time.Second
+	defaultPreRoll            = 200 * time.Millisecond
+	defaultPartialInterval    = time.Second
+	defaultMaxFrameBytes      = 64 * 1024
+	defaultIdleTimeout        = 60 * time.Second
+	defaultQueueSize          = 4
+
+	// vadFrame is the analysis window of the voice activity detector.
+	vadFrame = 20 * time.Millisecond
+)
+
+// WithDefaults fills zero fields of cfg with the built-in defaults.
+// A negative PartialInterval disables interim results.
+func WithDefaults(cfg config.StreamConfig) config.StreamConfig {
+	if cfg.SilenceThresholdDB == 0 {
+		cfg.SilenceThresholdDB = defaultSilenceThresholdDB
+	}
+	if cfg.EndSilence <= 0 {
+		cfg.EndSilence = defaultEndSilence
+	}
+	if cfg.MinSpeech <= 0 {
+		cfg.MinSpeech = defaultMinSpeech
+	}
+	if cfg.MaxUtterance <= 0 {
+		cfg.MaxUtterance = defaultMaxUtterance
+	}
+	if cfg.PreRoll <= 0 {
+		cfg.PreRoll = defaultPreRoll
+	}
+	if cfg.PartialInterval == 0 {
+		cfg.PartialInterval = defaultPartialInterval
+	}
+	if cfg.MaxFrameBytes <= 0 {
+		cfg.MaxFrameBytes = defaultMaxFrameBytes
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = defaultIdleTimeout
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	return cfg
+}
+
+// Segment is a slice of speech produced by the Segmenter.
+// Partial segments are snapshots of an utterance still in progress; the final segment closes it.
+type Segment struct {
+	Utterance int // 1-based utterance counter
+	Samples   []int16
+	Start     time.Duration // offset of the first sample from the start of the stream
+	End       time.Duration
+	Final     bool
+	Forced    bool // final because MaxUtterance was reached rather than silence
+}
+
+// Segmenter splits a mono PCM16 stream into utterances using frame energy.
+// An utterance starts at the first frame above the silence threshold (plus PreRoll of
+// leading audio) and ends after EndSilence of quiet or when it reaches MaxUtterance.
+// It is not safe for concurrent use.
+type Segmenter struct {
+	cfg        config.StreamConfig
+	sampleRate int
+	frameLen   int
+
+	pending []int16 // samples not yet forming a full analysis frame
+	preRoll []int16
+	pos     int // samples consumed so far
+
+	active      bool
+	utterance   int
+	samples     []int16
+	start       int
+	voiced      time.Duration
+	silence     time.Duration
+	lastPartial time.Duration
+}
+
+// NewSegmenter creates a segmenter for mono PCM16 audio at sampleRate.
+func NewSegmenter(cfg config.StreamConfig, sampleRate int) *Segmenter {
+	cfg = WithDefaults(cfg)
+	frameLen := int(int64(sampleRate) * int64(vadFrame) / int64(time.Second))
+	if frameLen < 1 {
+		frameLen = 1
+	}
+	return &Segmenter{cfg: cfg, sampleRate: sampleRate, frameLen: frameLen}
+}
+
+// Push feeds samples and returns any partial or final segments they complete.
+func (s *Segmenter) Push(samples []int16) []Segment {
+	var out []Segment
+	s.pending = append(s.pending, samples...)
+	for len(s.pending) >= s.frameLen {
+		out = s.processFrame(s.pending[:s.frameLen], out)
+		s.pending = s.pending[s.frameLen:]
+	}
+	// Keep the backing array from growing without bound.
+	if cap(s.pending) > 8*s.frameLen {
+		s.pending = append([]int16(nil), s.pending...)
+	}
+	return out
+}
+
+// Flush ends the current utterance, if any, regardless of trailing silence.
+func (s *Segmenter) Flush() (Segment, bool) {
+	if len(s.pending) > 0 {
+		frame := s.pending
+		s.pending = nil
+		var out []Segment
+		if out = s.processFrame(frame, out); len(out) > 0 && out[len(out)-1].Final {
+			return out[len(out)-1], true
+		}
+	}
+	if !s.active {
+		return Segment{}, false
+	}
+	return s.finish(false)
+}
+
+func (s *Segmenter) processFrame(frame []int16, out []Segment) []Segment {
+	frameDur := s.duration(len(frame))
+	voiced := frameLevelDB(frame) >= s.cfg.SilenceThresholdDB
+
+	if !s.active {
+		if !voiced {
+			s.rememberPreRoll(frame)
+			s.pos += len(frame)
+			return out
+		}
+		s.active = true
+		s.utterance++
+		s.samples = append(append(s.samples[:0], s.preRoll...), frame...)
+		s.start = s.pos - len(s.preRoll)
+		s.preRoll = s.preRoll[:0]
+		s.voiced = frameDur
+		s.silence = 0
+		s.lastPartial = 0
+		s.pos += len(frame)
+		return out
+	}
+
+	s.samples = append(s.samples, frame...)
+	s.pos += len(frame)
+	if voiced {
+		s.voiced += frameDur
+		s.silence = 0
+	} else {
+		s.silence += frameDur
+	}
+
+	length := s.duration(len(s.samples))
+	switch {
+	case s.silence >= s.cfg.EndSilence:
+		if seg, ok := s.finish(false); ok {
+			out = append(out, seg)
+		}
+	case length >= s.cfg.MaxUtterance:
+		if seg, ok := s.finish(true); ok {
+			out = append(out, seg)
+		}
+	case s.cfg.PartialInterval > 0 && s.silence == 0 && length-s.lastPartial >= s.cfg.PartialInterval:
+		s.lastPartial = length
+		out = append(out, Segment{
+			Utterance: s.utterance,
+			Samples:   append([]int16(nil), s.samples...),
+			Start:     s.duration(s.start),
+			End:       s.duration(s.start + len(s.samples)),
+		})
+	}
+	return out
+}
+
+// finish closes the active utterance. Utterances with less than MinSpeech of voiced audio are dropped.
+func (s *Segmenter) finish(forced bool) (Segment, bool) {
+	s.active = false
+	if s.voiced < s.cfg.MinSpeech {
+		s.samples = s.samples[:0]
+		return Segment{}, false
+	}
+
+	// Trim trailing silence beyond what PreRoll keeps on the leading edge.
+	keep := len(s.samples)
+	if trailing := s.silence - s.cfg.PreRoll; trailing > 0 {
+		keep -= int(int64(trailing) * int64(s.sampleRate) / int64(time.Second))
+	}
+	seg := Segment{
+		Utterance: s.utterance,
+		Samples:   append([]int16(nil), s.samples[:keep]...),
+		Start:     s.duration(s.start),
+		End:       s.duration(s.start + keep),
+		Final:     true,
+		Forced:    forced,
+	}
+	s.samples = s.samples[:0]
+	return seg, true
+}
+
+func (s *Segmenter) rememberPreRoll(frame []int16) {
+	limit := int(int64(s.cfg.PreRoll) * int64(s.sampleRate) / int64(time.Second))
+	s.preRoll = append(s.preRoll, frame...)
+	if over := len(s.preRoll) - limit; over > 0 {
+		s.preRoll = append(s.preRoll[:0], s.preRoll[over:]...)
+	}
+}
+
+func (s *Segmenter) duration(samples int) time.Duration {
+	if s.sampleRate <= 0 {
+		return 0
+	}
+	return time.Duration(int64(samples) * int64(time.Second) / int64(s.sampleRate))
+}
+
+// frameLevelDB returns the RMS level of frame in dBFS.
+func frameLevelDB(frame []int16) float64 {
+	if len(frame) == 0 {
+		return math.Inf(-1)
+	}
+	var sum float64
+	for _, v := range frame {
+		f := float64(v) / 32768
+		sum += f * f
+	}
+	rms := math.Sqrt(sum / float64(len(frame)))
+	if rms == 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(rms)
+}
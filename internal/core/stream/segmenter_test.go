@@ -0,0 +1,106 @@
+package stream
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+)
+
+const testRate = 16000
+
+func tone(d time.Duration) []int16 {
+	n := int(int64(d) * testRate / int64(time.Second))
+	out := make([]int16, n)
+	for i := range out {
+		out[i] = int16(8000 * math.Sin(2*math.Pi*440*float64(i)/testRate))
+	}
+	return out
+}
+
+func silence(d time.Duration) []int16 {
+	return make([]int16, int(int64(d)*testRate/int64(time.Second)))
+}
+
+func finals(segs []Segment) []Segment {
+	var out []Segment
+	for _, s := range segs {
+		if s.Final {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func TestSegmenter_SplitsOnSilence(t *testing.T) {
+	t.Parallel()
+
+	s := NewSegmenter(config.StreamConfig{PartialInterval: -1}, testRate)
+	var segs []Segment
+	segs = append(segs, s.Push(silence(500*time.Millisecond))...)
+	segs = append(segs, s.Push(tone(time.Second))...)
+	segs = append(segs, s.Push(silence(time.Second))...)
+	segs = append(segs, s.Push(tone(800*time.Millisecond))...)
+	segs = append(segs, s.Push(silence(time.Second))...)
+
+	got := finals(segs)
+	if len(got) != 2 || len(segs) != 2 {
+		t.Fatalf("got %d segments (%d final), want 2 finals", len(segs), len(got))
+	}
+	if got[0].Utterance != 1 || got[1].Utterance != 2 {
+		t.Fatalf("utterance ids = %d, %d", got[0].Utterance, got[1].Utterance)
+	}
+	// Speech starts at 500ms; PreRoll keeps 200ms before it.
+	if got[0].Start != 300*time.Millisecond {
+		t.Fatalf("first start = %s want 300ms", got[0].Start)
+	}
+	if d := got[0].End - got[0].Start; d < time.Second || d > 1600*time.Millisecond {
+		t.Fatalf("first utterance length = %s", d)
+	}
+	if got[0].Forced {
+		t.Fatalf("first utterance should end on silence")
+	}
+}
+
+func TestSegmenter_PartialsAndForcedSplit(t *testing.T) {
+	t.Parallel()
+
+	s := NewSegmenter(config.StreamConfig{PartialInterval: 500 * time.Millisecond, MaxUtterance: 2 * time.Second}, testRate)
+	segs := s.Push(tone(3 * time.Second))
+
+	var partials int
+	for _, seg := range segs {
+		if !seg.Final {
+			partials++
+			if seg.Utterance != 1 && seg.Utterance != 2 {
+				t.Fatalf("partial for unexpected utterance %d", seg.Utterance)
+			}
+		}
+	}
+	if partials < 3 {
+		t.Fatalf("partials = %d want >= 3", partials)
+	}
+	got := finals(segs)
+	if len(got) != 1 || !got[0].Forced || got[0].End-got[0].Start != 2*time.Second {
+		t.Fatalf("unexpected forced split: %+v", got)
+	}
+
+	rest, ok := s.Flush()
+	if !ok || rest.Utterance != 2 || !rest.Final {
+		t.Fatalf("Flush = %+v, %v", rest, ok)
+	}
+}
+
+func TestSegmenter_DropsShortNoise(t *testing.T) {
+	t.Parallel()
+
+	s := NewSegmenter(config.StreamConfig{PartialInterval: -1}, testRate)
+	segs := s.Push(append(tone(100*time.Millisecond), silence(time.Second)...))
+	if len(segs) != 0 {
+		t.Fatalf("expected short noise to be dropped, got %+v", segs)
+	}
+	if _, ok := s.Flush(); ok {
+		t.Fatalf("Flush should have nothing to return")
+	}
+}
@@ -0,0 +1,344 @@
+package stream
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Event types pushed to the client.
+const (
+	EventReady   = "ready"
+	EventPartial = "partial"
+	EventFinal   = "final"
+	EventError   = "error"
+)
+
+// ErrSessionClosed is returned when audio is written after Close.
+var ErrSessionClosed = errors.New("stream session closed")
+
+// Event is one server-to-client message.
+type Event struct {
+	Type           string                 `json:"type"`
+	SessionID      string                 `json:"session_id,omitempty"`
+	UtteranceID    int                    `json:"utterance_id,omitempty"`
+	Start          float64                `json:"start,omitempty"` // 相对流开始的秒数
+	End            float64                `json:"end,omitempty"`
+	Transcription  string                 `json:"transcription,omitempty"`
+	CorrectedText  string                 `json:"corrected_text,omitempty"`
+	Translations   map[string]string      `json:"translations,omitempty"`
+	ProcessingTime float64                `json:"processing_time,omitempty"`
+	Forced         bool                   `json:"forced,omitempty"` // 达到 max_utterance 被强制切分
+	SampleRate     int                    `json:"sample_rate,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Moderation     *tool.ModerationResult `json:"moderation,omitempty"`
+	Speech         *tool.SpeechResult     `json:"speech,omitempty"`
+	Code           string                 `json:"code,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+}
+
+// Backend runs utterances through the audio pipeline.
+type Backend interface {
+	// Transcribe returns interim text for an utterance that is still in progress.
+	Transcribe(ctx context.Context, req audio.ProcessRequest) (string, error)
+	// Process runs the full pipeline (ASR, correction, translation) for a completed utterance.
+	Process(ctx context.Context, req audio.ProcessRequest) (*audio.ProcessResponse, error)
+}
+
+// Settings describes one session.
+type Settings struct {
+	ID          string
+	AudioFormat string // pcm_s16le / pcm_f32le / opus (Ogg) / webm
+	SampleRate  int    // 仅原始 PCM
+	Channels    int    // 仅原始 PCM
+	// Request is the template for every utterance; the audio fields are filled in per utterance.
+	Request  audio.ProcessRequest
+	Partials bool
+}
+
+// Session segments an audio stream into utterances and emits partial and final results.
+// WriteAudio, Flush and Close must be called from a single goroutine; emit may be called
+// from several goroutines but never concurrently.
+type Session struct {
+	settings Settings
+	backend  Backend
+	emit     func(Event) error
+	logger   *logrus.Logger
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	decoder decoder
+
+	mu        sync.Mutex // guards segmenter and ended
+	segmenter *Segmenter
+	ended     bool
+	finals    chan Segment
+
+	partialBusy atomic.Bool
+	partials    sync.WaitGroup
+
+	emitMu    sync.Mutex
+	lastFinal int // highest utterance that already got its final (or error) event
+
+	closing    atomic.Bool
+	workerDone chan struct{}
+	closeOnce  sync.Once
+	closeErr   error
+}
+
+// NewSession starts a session. emit receives every event; its errors are logged and otherwise ignored,
+// since a broken transport is noticed by the caller's read loop.
+func NewSession(ctx context.Context, settings Settings, cfg config.StreamConfig, backend Backend, emit func(Event) error, logger *logrus.Logger) (*Session, error) {
+	cfg = WithDefaults(cfg)
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Session{
+		settings:   settings,
+		backend:    backend,
+		emit:       emit,
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+		finals:     make(chan Segment, cfg.QueueSize),
+		workerDone: make(chan struct{}),
+	}
+	dec, err := newDecoder(ctx, settings.AudioFormat, settings.SampleRate, settings.Channels, s.onSamples)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s.decoder = dec
+	s.segmenter = NewSegmenter(cfg, dec.SampleRate())
+	go s.worker()
+	return s, nil
+}
+
+// SampleRate is the rate of the PCM sent to the pipeline.
+func (s *Session) SampleRate() int {
+	return s.decoder.SampleRate()
+}
+
+// WriteAudio feeds one audio frame.
+func (s *Session) WriteAudio(frame []byte) error {
+	if s.closing.Load() {
+		return ErrSessionClosed
+	}
+	return s.decoder.Write(frame)
+}
+
+// Flush ends the current utterance immediately (e.g. push-to-talk release).
+// Audio still buffered inside an ffmpeg decoder is not included.
+func (s *Session) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	if seg, ok := s.segmenter.Flush(); ok {
+		s.dispatch(seg, false)
+	}
+}
+
+// Cancel aborts in-flight processing; queued utterances are dropped. Close must still be called.
+func (s *Session) Cancel() {
+	s.cancel()
+}
+
+// Close stops accepting audio, finishes the current utterance and waits until every
+// queued utterance has been processed and emitted.
+func (s *Session) Close() error {
+	s.closeOnce.Do(func() {
+		s.closing.Store(true)
+		s.closeErr = s.decoder.Close()
+
+		s.mu.Lock()
+		if seg, ok := s.segmenter.Flush(); ok {
+			s.dispatch(seg, true)
+		}
+		s.ended = true
+		close(s.finals)
+		s.mu.Unlock()
+
+		<-s.workerDone
+		s.partials.Wait()
+		s.cancel()
+	})
+	return s.closeErr
+}
+
+func (s *Session) onSamples(samples []int16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	for _, seg := range s.segmenter.Push(samples) {
+		s.dispatch(seg, false)
+	}
+}
+
+// dispatch queues a final segment or starts a partial transcription. Called with s.mu held.
+func (s *Session) dispatch(seg Segment, block bool) {
+	if !seg.Final {
+		s.startPartial(seg)
+		return
+	}
+	if block {
+		s.finals <- seg
+		return
+	}
+	select {
+	case s.finals <- seg:
+	default:
+		s.emitError(seg.Utterance, "QUEUE_FULL", "too many utterances waiting to be processed; utterance dropped")
+	}
+}
+
+// startPartial transcribes a snapshot of the utterance in progress. At most one runs at a
+// time; snapshots arriving meanwhile are skipped rather than queued.
+func (s *Session) startPartial(seg Segment) {
+	if !s.settings.Partials || !s.partialBusy.CompareAndSwap(false, true) {
+		return
+	}
+	s.partials.Add(1)
+	go func() {
+		defer s.partials.Done()
+		defer s.partialBusy.Store(false)
+
+		ctx, req := s.utteranceRequest(seg)
+		text, err := s.backend.Transcribe(ctx, req)
+		if err != nil {
+			if s.ctx.Err() == nil {
+				s.logger.WithError(err).WithField(logging.FieldRequestID, s.settings.ID).Debug("Partial transcription failed")
+			}
+			return
+		}
+		if text == "" {
+			return
+		}
+
+		s.emitMu.Lock()
+		defer s.emitMu.Unlock()
+		if seg.Utterance <= s.lastFinal {
+			return // the final result is already out
+		}
+		s.send(Event{
+			Type:          EventPartial,
+			UtteranceID:   seg.Utterance,
+			Start:         seg.Start.Seconds(),
+			End:           seg.End.Seconds(),
+			Transcription: text,
+		})
+	}()
+}
+
+func (s *Session) worker() {
+	defer close(s.workerDone)
+	for seg := range s.finals {
+		if s.ctx.Err() != nil {
+			continue
+		}
+		ctx, req := s.utteranceRequest(seg)
+		start := time.Now()
+		resp, err := s.backend.Process(ctx, req)
+		if err != nil {
+			if s.ctx.Err() != nil {
+				continue
+			}
+			code := string(coreerrors.ErrCodeInternal)
+			var appErr *coreerrors.AppError
+			if errors.As(err, &appErr) {
+				code = string(appErr.Code)
+			}
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				logging.FieldRequestID: s.settings.ID,
+				"utterance_id":         seg.Utterance,
+			}).Warn("Stream utterance processing failed")
+			s.emitError(seg.Utterance, code, err.Error())
+			continue
+		}
+
+		event := Event{
+			Type:           EventFinal,
+			UtteranceID:    seg.Utterance,
+			Start:          seg.Start.Seconds(),
+			End:            seg.End.Seconds(),
+			Transcription:  resp.Transcription,
+			CorrectedText:  resp.CorrectedText,
+			Translations:   make(map[string]string, len(resp.Translations)),
+			ProcessingTime: time.Since(start).Seconds(),
+			Forced:         seg.Forced,
+			Metadata:       make(map[string]interface{}, len(resp.Metadata)),
+			Moderation:     resp.Moderation,
+			Speech:         resp.Speech,
+		}
+		// resp is pooled; copy before releasing it.
+		for k, v := range resp.Translations {
+			event.Translations[k] = v
+		}
+		for k, v := range resp.Metadata {
+			event.Metadata[k] = v
+		}
+		resp.Release()
+
+		s.emitMu.Lock()
+		if seg.Utterance > s.lastFinal {
+			s.lastFinal = seg.Utterance
+		}
+		s.send(event)
+		s.emitMu.Unlock()
+	}
+}
+
+// utteranceRequest builds the pipeline request for a segment from the session template.
+func (s *Session) utteranceRequest(seg Segment) (context.Context, audio.ProcessRequest) {
+	req := s.settings.Request
+	req.Audio = pcm16Bytes(seg.Samples)
+	req.AudioFormat = audio.FormatPCMS16LE
+	req.SampleRate = s.decoder.SampleRate()
+	req.Channels = 1
+	if req.Options != nil {
+		options := make(map[string]interface{}, len(req.Options))
+		for k, v := range req.Options {
+			options[k] = v
+		}
+		req.Options = options
+	}
+	requestID := fmt.Sprintf("%s-%d", s.settings.ID, seg.Utterance)
+	return logging.WithRequestID(s.ctx, requestID), req
+}
+
+func (s *Session) emitError(utterance int, code, message string) {
+	s.emitMu.Lock()
+	defer s.emitMu.Unlock()
+	if utterance > s.lastFinal {
+		s.lastFinal = utterance
+	}
+	s.send(Event{Type: EventError, UtteranceID: utterance, Code: code, Error: message})
+}
+
+// send delivers an event. Called with s.emitMu held.
+func (s *Session) send(event Event) {
+	event.SessionID = s.settings.ID
+	if err := s.emit(event); err != nil && s.ctx.Err() == nil {
+		s.logger.WithError(err).WithField(logging.FieldRequestID, s.settings.ID).Debug("Failed to emit stream event")
+	}
+}
+
+func pcm16Bytes(samples []int16) []byte {
+	out := make([]byte, len(samples)*2)
+	for i, v := range samples {
+		binary.LittleEndian.PutUint16(out[2*i:], uint16(v))
+	}
+	return out
+}
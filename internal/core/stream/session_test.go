@@ -0,0 +1,250 @@
+package stream
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/audio"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+)
+
+type fakeBackend struct {
+	mu       sync.Mutex
+	requests []audio.ProcessRequest
+	ids      []string
+	failID   string
+}
+
+func (b *fakeBackend) Transcribe(ctx context.Context, req audio.ProcessRequest) (string, error) {
+	return "partial", nil
+}
+
+func (b *fakeBackend) Process(ctx context.Context, req audio.ProcessRequest) (*audio.ProcessResponse, error) {
+	id, _ := logging.RequestIDFromContext(ctx)
+	b.mu.Lock()
+	b.requests = append(b.requests, req)
+	b.ids = append(b.ids, id)
+	b.mu.Unlock()
+	if id == b.failID {
+		return nil, coreerrors.NewLLMError("backend unavailable", nil)
+	}
+	return &audio.ProcessResponse{
+		Transcription: "你好",
+		Translations:  map[string]string{"en": "hello"},
+		Metadata:      map[string]interface{}{"pipeline": "test"},
+	}, nil
+}
+
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) emit(e Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *eventRecorder) ofType(typ string) []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []Event
+	for _, e := range r.events {
+		if e.Type == typ {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func f32Bytes(samples []int16, channels int) []byte {
+	out := make([]byte, 0, len(samples)*4*channels)
+	for _, v := range samples {
+		for ch := 0; ch < channels; ch++ {
+			out = binary.LittleEndian.AppendUint32(out, math.Float32bits(float32(v)/32767))
+		}
+	}
+	return out
+}
+
+func TestSession_EmitsFinalPerUtterance(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeBackend{failID: "sess-2"}
+	rec := &eventRecorder{}
+	settings := Settings{
+		ID:          "sess",
+		AudioFormat: audio.FormatPCMF32LE,
+		SampleRate:  testRate,
+		Channels:    2,
+		Request: audio.ProcessRequest{
+			Task:            prompt.TaskTranslate,
+			TargetLanguages: []string{"en"},
+			Options:         map[string]interface{}{"audio_profile": "none"},
+		},
+	}
+	s, err := NewSession(context.Background(), settings, config.StreamConfig{PartialInterval: -1}, backend, rec.emit, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	stream := f32Bytes(append(append(tone(time.Second), silence(time.Second)...), tone(time.Second)...), 2)
+	// Odd-sized frames split samples across writes.
+	for off := 0; off < len(stream); off += 999 {
+		end := min(off+999, len(stream))
+		if err := s.WriteAudio(stream[off:end]); err != nil {
+			t.Fatalf("WriteAudio: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := s.WriteAudio([]byte{0, 0}); !errors.Is(err, ErrSessionClosed) {
+		t.Fatalf("WriteAudio after Close = %v", err)
+	}
+
+	final := rec.ofType(EventFinal)
+	if len(final) != 1 || final[0].UtteranceID != 1 || final[0].Translations["en"] != "hello" || final[0].SessionID != "sess" {
+		t.Fatalf("unexpected final events: %+v", final)
+	}
+	// The second utterance is still open at Close and fails in the backend.
+	errs := rec.ofType(EventError)
+	if len(errs) != 1 || errs[0].UtteranceID != 2 || errs[0].Code != string(coreerrors.ErrCodeLLM) {
+		t.Fatalf("unexpected error events: %+v", errs)
+	}
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if len(backend.requests) != 2 {
+		t.Fatalf("backend got %d requests want 2", len(backend.requests))
+	}
+	req := backend.requests[0]
+	if req.AudioFormat != audio.FormatPCMS16LE || req.SampleRate != testRate || req.Channels != 1 || req.Task != prompt.TaskTranslate {
+		t.Fatalf("unexpected utterance request: format=%s rate=%d channels=%d task=%s", req.AudioFormat, req.SampleRate, req.Channels, req.Task)
+	}
+	if len(req.Audio)%2 != 0 || len(req.Audio) < 2*testRate {
+		t.Fatalf("utterance audio has %d bytes", len(req.Audio))
+	}
+	if backend.ids[0] != "sess-1" {
+		t.Fatalf("request id = %q want sess-1", backend.ids[0])
+	}
+}
+
+func TestSession_PartialResults(t *testing.T) {
+	t.Parallel()
+
+	rec := &eventRecorder{}
+	settings := Settings{ID: "sess", AudioFormat: audio.FormatPCMS16LE, SampleRate: testRate, Partials: true}
+	s, err := NewSession(context.Background(), settings, config.StreamConfig{PartialInterval: 200 * time.Millisecond}, &fakeBackend{}, rec.emit, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	frame := tone(100 * time.Millisecond)
+	buf := make([]byte, 2*len(frame))
+	for i, v := range frame {
+		binary.LittleEndian.PutUint16(buf[2*i:], uint16(v))
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for i := 0; i < 5 || (len(rec.ofType(EventPartial)) == 0 && time.Now().Before(deadline)); i++ {
+		if err := s.WriteAudio(buf); err != nil {
+			t.Fatalf("WriteAudio: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.Flush()
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	partials := rec.ofType(EventPartial)
+	if len(partials) == 0 || partials[0].Transcription != "partial" || partials[0].UtteranceID != 1 {
+		t.Fatalf("unexpected partial events: %+v", partials)
+	}
+	final := rec.ofType(EventFinal)
+	if len(final) != 1 {
+		t.Fatalf("final events = %d want 1", len(final))
+	}
+
+	// No partial may follow the final for the same utterance.
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	seenFinal := false
+	for _, e := range rec.events {
+		if e.Type == EventFinal {
+			seenFinal = true
+		} else if e.Type == EventPartial && seenFinal {
+			t.Fatalf("partial emitted after final: %+v", rec.events)
+		}
+	}
+}
+
+func TestNewSession_RejectsUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSession(context.Background(), Settings{AudioFormat: "mp3"}, config.StreamConfig{}, &fakeBackend{}, func(Event) error { return nil }, testutil.NewTestLogger())
+	if err == nil {
+		t.Fatalf("expected error for mp3 stream")
+	}
+	_, err = NewSession(context.Background(), Settings{AudioFormat: audio.FormatPCMS16LE}, config.StreamConfig{}, &fakeBackend{}, func(Event) error { return nil }, testutil.NewTestLogger())
+	if err == nil {
+		t.Fatalf("expected error for PCM without sample rate")
+	}
+}
+
+func TestSession_OpusStreamDecodedByFFmpeg(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg script requires a POSIX shell")
+	}
+	// The fake ffmpeg records its arguments and passes stdin through, so the "Opus" stream is PCM16 here.
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\nexec cat\n"
+	if err := os.WriteFile(filepath.Join(dir, "ffmpeg"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake ffmpeg: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	backend := &fakeBackend{}
+	rec := &eventRecorder{}
+	s, err := NewSession(context.Background(), Settings{ID: "opus", AudioFormat: "webm"}, config.StreamConfig{PartialInterval: -1}, backend, rec.emit, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	if s.SampleRate() != decodedSampleRate {
+		t.Fatalf("SampleRate=%d want %d", s.SampleRate(), decodedSampleRate)
+	}
+	samples := append(tone(time.Second), silence(time.Second)...)
+	if err := s.WriteAudio(pcm16Bytes(samples)); err != nil {
+		t.Fatalf("WriteAudio: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if final := rec.ofType(EventFinal); len(final) != 1 {
+		t.Fatalf("final events=%+v want 1", rec.events)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("read args: %v", err)
+	}
+	if !strings.Contains(string(args), "-f matroska -i pipe:0") || !strings.Contains(string(args), "-f s16le -ac 1 -ar 16000") {
+		t.Fatalf("unexpected ffmpeg args: %s", args)
+	}
+}
@@ -11,8 +11,10 @@ import (
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/pipeline"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
 	"github.com/sirupsen/logrus"
@@ -51,6 +53,8 @@ type ProcessResponse struct {
 	RawResponse    string                 `json:"raw_response"`
 	ProcessingTime float64                `json:"processing_time"`
 	Metadata       map[string]interface{} `json:"metadata"`
+	Moderation     *tool.ModerationResult `json:"moderation,omitempty"` // 仅含 moderate 步骤的 pipeline
+	Speech         *tool.SpeechResult     `json:"speech,omitempty"`     // 仅在请求 options.tts 时返回
 }
 
 func (r *ProcessResponse) SetProcessingTime(seconds float64) {
@@ -71,6 +75,11 @@ type Processor struct {
 	pipelineCfg  config.PipelineConfig
 	toolRegistry *tool.Registry
 	pipelineExec *pipeline.Executor
+	pipelines    pipeline.Catalog
+	statusStore  processing.StatusStore
+	ttsManager   *tts.Manager
+	ttsStore     *tts.Store
+	ttsConfig    config.TTSConfig
 	logger       *logrus.Logger
 
 	translationCache cache.TranslationCache
@@ -131,6 +140,24 @@ func (p *Processor) WithPipelineConfig(cfg config.PipelineConfig) *Processor {
 	return p
 }
 
+// WithStatusStore records per-step pipeline progress under the request ID.
+func (p *Processor) WithStatusStore(store processing.StatusStore) *Processor {
+	p.statusStore = store
+	p.toolRegistry = nil
+	p.pipelineExec = nil
+	return p
+}
+
+// WithTTS enables the tts tool; requests ask for speech with options.tts.
+func (p *Processor) WithTTS(manager *tts.Manager, store *tts.Store, cfg config.TTSConfig) *Processor {
+	p.ttsManager = manager
+	p.ttsStore = store
+	p.ttsConfig = cfg
+	p.toolRegistry = nil
+	p.pipelineExec = nil
+	return p
+}
+
 // Process 方法已移除 - 现在使用 ProcessingService 统一处理流程
 
 func (p *Processor) ensurePipelineInitialized() error {
@@ -146,18 +173,107 @@ func (p *Processor) ensurePipelineInitialized() error {
 	if err := reg.Register(tool.NewTextCorrectTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)); err != nil {
 		return err
 	}
-	if err := reg.Register(tool.NewTextTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)); err != nil {
+	textTranslate := tool.NewTextTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)
+	textTranslate.WithTranslationConfig(p.pipelineCfg.Translation)
+	if err := reg.Register(textTranslate); err != nil {
 		return err
 	}
-	if err := reg.Register(tool.NewTextCorrectTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)); err != nil {
+	if err := reg.Register(tool.NewGlossaryCheckTool(textTranslate.TranslateTool)); err != nil {
 		return err
 	}
+	textCorrectTranslate := tool.NewTextCorrectTranslateTool(p.llmManager, p.promptEngine, toolCallingEnabled, allowThinking)
+	textCorrectTranslate.WithTranslationConfig(p.pipelineCfg.Translation)
+	if err := reg.Register(textCorrectTranslate); err != nil {
+		return err
+	}
+	moderate, err := tool.NewModerateTool(p.pipelineCfg.Moderation, p.llmManager, p.promptEngine, toolCallingEnabled)
+	if err != nil {
+		return coreerrors.NewInternalError("invalid moderation configuration", err)
+	}
+	if err := reg.Register(moderate); err != nil {
+		return err
+	}
+	if p.ttsManager != nil {
+		if err := reg.Register(tool.NewTTSTool(p.ttsManager, p.ttsStore, p.ttsConfig)); err != nil {
+			return err
+		}
+	}
+
+	catalog, err := pipeline.NewCatalog(pipeline.TextBuiltins(), p.pipelineCfg.Definitions, pipeline.TypeText, reg)
+	if err != nil {
+		return coreerrors.NewInternalError("invalid text pipeline configuration", err)
+	}
+	if err := catalog.CheckTasks(p.pipelineCfg.Tasks.Text); err != nil {
+		return coreerrors.NewInternalError("invalid text pipeline configuration", err)
+	}
 
 	p.toolRegistry = reg
-	p.pipelineExec = pipeline.NewExecutor(reg)
+	p.pipelines = catalog
+	p.pipelineExec = pipeline.NewExecutor(reg).WithMaxParallel(p.pipelineCfg.MaxParallelSteps)
+	if p.statusStore != nil {
+		p.pipelineExec.WithProgressHook(processing.PipelineProgress(p.statusStore))
+	}
 	return nil
 }
 
+// ValidatePipelines builds the tool registry and checks the configured pipelines against it.
+func (p *Processor) ValidatePipelines() error {
+	return p.ensurePipelineInitialized()
+}
+
+// PipelineNames lists the pipelines that requests may select with options.pipeline.
+func (p *Processor) PipelineNames() []string {
+	if err := p.ensurePipelineInitialized(); err != nil {
+		return nil
+	}
+	return p.pipelines.Names()
+}
+
+// customPipelineSelected reports whether req bypasses the built-in pipeline selection.
+func (p *Processor) customPipelineSelected(req ProcessRequest, task prompt.TaskType) bool {
+	if pipeline.RequestedName(req.Options) != "" {
+		return true
+	}
+	_, ok := p.pipelineCfg.Tasks.Text[string(task)]
+	return ok
+}
+
+// selectPipeline picks the pipeline for req: options.pipeline first, then the configured task
+// mapping, then the built-in choice based on the correction settings.
+func (p *Processor) selectPipeline(ctx context.Context, req ProcessRequest, task prompt.TaskType) (pipeline.Pipeline, error) {
+	if name := pipeline.RequestedName(req.Options); name != "" {
+		selected, ok := p.pipelines.Lookup(name)
+		if !ok {
+			return pipeline.Pipeline{}, coreerrors.NewValidationError(fmt.Sprintf("unknown pipeline: %s", name), nil)
+		}
+		return selected, nil
+	}
+	if name, ok := p.pipelineCfg.Tasks.Text[string(task)]; ok {
+		// 启动时已校验映射的 pipeline 存在
+		selected, _ := p.pipelines.Lookup(name)
+		return selected, nil
+	}
+
+	switch task {
+	case prompt.TaskTranslate:
+		if p.correction.Enabled {
+			// 流式请求需先推送纠错结果，因此不合并纠错与翻译
+			if p.correction.MergeWithTranslation && !tool.HasPartialListener(ctx) {
+				return pipeline.TextCorrectTranslate(), nil
+			}
+			return pipeline.TextCorrectThenTranslate(), nil
+		}
+		return pipeline.TextTranslate(), nil
+	case prompt.TaskTranscribe:
+		if p.correction.Enabled {
+			return pipeline.TextCorrect(), nil
+		}
+		return pipeline.TextPassthrough(), nil
+	default:
+		return pipeline.Pipeline{}, coreerrors.NewValidationError(fmt.Sprintf("unsupported task type: %s", task), nil)
+	}
+}
+
 // Validate 验证请求 - 实现 LogicHandler 接口
 func (p *Processor) Validate(req ProcessRequest) error {
 	if req.Text == "" {
@@ -195,7 +311,7 @@ func (p *Processor) TryGetCachedResponse(ctx context.Context, req ProcessRequest
 	if task == "" {
 		task = prompt.TaskTranslate
 	}
-	if task != prompt.TaskTranslate || p.correction.Enabled {
+	if task != prompt.TaskTranslate || p.correction.Enabled || p.customPipelineSelected(req, task) || hasRequestGlossary(req) || pipeline.SpeechRequested(req.Options) {
 		return nil, false, nil
 	}
 
@@ -237,7 +353,7 @@ func (p *Processor) StoreCachedResponse(ctx context.Context, req ProcessRequest,
 	if task == "" {
 		task = prompt.TaskTranslate
 	}
-	if task != prompt.TaskTranslate || p.correction.Enabled {
+	if task != prompt.TaskTranslate || p.correction.Enabled || p.customPipelineSelected(req, task) || hasRequestGlossary(req) || pipeline.SpeechRequested(req.Options) {
 		return nil
 	}
 	if resp == nil || resp.Status != "success" || len(resp.Translations) == 0 {
@@ -258,6 +374,13 @@ func (p *Processor) StoreCachedResponse(ctx context.Context, req ProcessRequest,
 	return nil
 }
 
+// hasRequestGlossary reports whether the request's own dictionary requires term translations;
+// the cache key does not cover the dictionary, so such requests bypass the translation cache.
+// Requests asking for speech bypass it as well, since speech is not cached.
+func hasRequestGlossary(req ProcessRequest) bool {
+	return len(tool.GlossaryTerms(req.UserDictionary)) > 0
+}
+
 // ProcessDirect optionally handles requests that don't fit the single-call ProcessingService flow.
 func (p *Processor) ProcessDirect(ctx context.Context, req ProcessRequest) (*ProcessResponse, bool, error) {
 	resp, err := p.processWithPipeline(ctx, req)
@@ -291,26 +414,22 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 
 	dictionary := correction.MergeDictionaries(p.correction.GlobalDictionary, req.UserDictionary)
 
-	var selected pipeline.Pipeline
-	switch task {
-	case prompt.TaskTranslate:
-		if p.correction.Enabled {
-			if p.correction.MergeWithTranslation {
-				selected = pipeline.TextCorrectTranslate()
-			} else {
-				selected = pipeline.TextCorrectThenTranslate()
-			}
-		} else {
-			selected = pipeline.TextTranslate()
-		}
-	case prompt.TaskTranscribe:
-		if p.correction.Enabled {
-			selected = pipeline.TextCorrect()
-		} else {
-			selected = pipeline.TextPassthrough()
+	selected, err := p.selectPipeline(ctx, req, task)
+	if err != nil {
+		return nil, err
+	}
+	if len(tool.GlossaryTerms(dictionary)) > 0 {
+		selected = pipeline.WithGlossaryCheck(selected)
+	}
+	if pipeline.SpeechRequested(req.Options) {
+		if _, ok := p.toolRegistry.Get("tts"); !ok {
+			return nil, coreerrors.NewValidationError("tts is not configured on this server", nil)
 		}
-	default:
-		return nil, coreerrors.NewValidationError(fmt.Sprintf("unsupported task type: %s", task), nil)
+		selected = pipeline.WithTTS(selected)
+	}
+	if selected.UsesTool("moderate") {
+		// 中间结果未经审核，含 moderate 步骤的 pipeline 只返回最终结果
+		ctx = tool.WithPartialListener(ctx, nil)
 	}
 
 	pctx := &tool.PipelineContext{
@@ -341,6 +460,9 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 		stepDurations[k] = d.Milliseconds()
 	}
 	resp.Metadata["step_durations_ms"] = stepDurations
+	if len(outCtx.StepOutcomes) > 0 {
+		resp.Metadata["step_outcomes"] = outCtx.StepOutcomes
+	}
 
 	switch selected.Name {
 	case pipeline.PipelineTextPassthrough:
@@ -409,7 +531,41 @@ func (p *Processor) processWithPipeline(ctx context.Context, req ProcessRequest)
 			resp.Metadata[k] = v
 		}
 	default:
-		return nil, coreerrors.NewInternalError(fmt.Sprintf("unknown pipeline: %s", selected.Name), nil)
+		// 配置定义的 pipeline 按约定字段汇总各步骤输出
+		result := pipeline.Collect(selected, outCtx)
+		resp.CorrectedText = result.CorrectedText
+		resp.RawResponse = result.RawResponse
+		resp.Moderation = result.Moderation
+		resp.Speech = result.Speech
+		if result.Text != nil {
+			resp.SourceText = *result.Text
+		}
+		for k, v := range result.Translations {
+			resp.Translations[k] = v
+		}
+		for k, v := range result.Metadata {
+			resp.Metadata[k] = v
+		}
+	}
+
+	// 术语校验步骤可能替换了部分语言的译文
+	if glossaryOut, ok := outCtx.StepOutputs["glossary_result"]; ok {
+		if translations, ok := glossaryOut.Data["translations"].(map[string]string); ok {
+			for k, v := range translations {
+				resp.Translations[k] = v
+			}
+		}
+		for k, v := range glossaryOut.Metadata {
+			resp.Metadata[k] = v
+		}
+	}
+	if ttsOut, ok := outCtx.StepOutputs["tts_result"]; ok {
+		if speech, ok := ttsOut.Data["speech"].(tool.SpeechResult); ok {
+			resp.Speech = &speech
+		}
+		for k, v := range ttsOut.Metadata {
+			resp.Metadata[k] = v
+		}
 	}
 
 	if task == prompt.TaskTranslate {
@@ -3,19 +3,26 @@ package text
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/cache"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/processing"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tool"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
 	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/metrics"
 )
 
@@ -191,3 +198,559 @@ func TestProcessor_TranslationCache_HitAvoidsLLM(t *testing.T) {
 		t.Fatalf("pipeline=%v want text_translate", resp2.Metadata["pipeline"])
 	}
 }
+
+type recordingStatusStore struct {
+	mu      sync.Mutex
+	updates []processing.ProcessingStatus
+}
+
+func (s *recordingStatusStore) Get(requestID string) (*processing.ProcessingStatus, error) {
+	return nil, processing.ErrStatusNotFound
+}
+
+func (s *recordingStatusStore) Set(requestID string, status *processing.ProcessingStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *status
+	cp.RequestID = requestID
+	s.updates = append(s.updates, cp)
+	return nil
+}
+
+func TestProcessor_StatusStore_RecordsStepProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "```json\n{\"corrected_text\":\"你好\",\"translations\":{\"en\":\"hello\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	store := &recordingStatusStore{}
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger).
+		WithCorrectionConfig(config.CorrectionConfig{Enabled: true}).
+		WithStatusStore(store)
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	ctx := logging.WithRequestID(context.Background(), "req-progress")
+	resp, err := service.Process(ctx, ProcessRequest{Text: "你好", TargetLanguages: []string{"en"}}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	resp.Release()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	want := []struct {
+		step     string
+		index    int
+		progress int
+	}{
+		{"correct_result", 1, 0},
+		{"correct_result", 1, 50},
+		{"translate_result", 2, 50},
+		{"translate_result", 2, 99},
+	}
+	if len(store.updates) != len(want) {
+		t.Fatalf("updates=%+v want %d", store.updates, len(want))
+	}
+	for i, w := range want {
+		got := store.updates[i]
+		if got.RequestID != "req-progress" || got.Status != "processing" || got.Step != w.step || got.StepIndex != w.index || got.StepCount != 2 || got.Progress != w.progress {
+			t.Fatalf("update %d = %+v, want step=%s index=%d progress=%d", i, got, w.step, w.index, w.progress)
+		}
+	}
+}
+
+func TestProcessor_ConfiguredPipelines(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "```json\n{\"corrected_text\":\"你好。\",\"translations\":{\"en\":\"hello\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	pipelineCfg := config.PipelineConfig{
+		ToolCalling: config.ToolCallingConfig{Enabled: false},
+		Definitions: map[string]config.PipelineDefinition{
+			"polish_translate": {Type: "text", Steps: []config.PipelineStepConfig{
+				{Tool: "text_correct", InputMapping: map[string]string{"text": "request.text"}, OutputKey: "polished"},
+				{Tool: "text_translate", InputMapping: map[string]string{
+					"text":             "polished.corrected_text",
+					"target_languages": "request.target_languages",
+				}, OutputKey: "translated"},
+			}},
+		},
+		Tasks: config.PipelineTaskMapping{Text: map[string]string{"translate": "polish_translate"}},
+	}
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger).
+		WithPipelineConfig(pipelineCfg)
+	if err := p.ValidatePipelines(); err != nil {
+		t.Fatalf("ValidatePipelines: %v", err)
+	}
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	// 任务映射选择自定义 pipeline：纠错 + 翻译两次调用
+	resp, err := service.Process(context.Background(), ProcessRequest{Text: "你好", TargetLanguages: []string{"en"}}, p)
+	if err != nil {
+		t.Fatalf("Process (task mapping): %v", err)
+	}
+	if resp.CorrectedText != "你好。" || resp.Translations["en"] != "hello" {
+		t.Fatalf("unexpected response: corrected=%q translations=%v", resp.CorrectedText, resp.Translations)
+	}
+	resp.Release()
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("llm calls=%d want 2", got)
+	}
+
+	// options.pipeline 优先于任务映射
+	resp, err = service.Process(context.Background(), ProcessRequest{
+		Text:            "你好",
+		TargetLanguages: []string{"en"},
+		Options:         map[string]interface{}{"pipeline": "Text_Translate"},
+	}, p)
+	if err != nil {
+		t.Fatalf("Process (options.pipeline): %v", err)
+	}
+	if resp.CorrectedText != "" || resp.Translations["en"] != "hello" {
+		t.Fatalf("unexpected response: corrected=%q translations=%v", resp.CorrectedText, resp.Translations)
+	}
+	resp.Release()
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("llm calls=%d want 3", got)
+	}
+
+	_, err = service.Process(context.Background(), ProcessRequest{
+		Text:            "你好",
+		TargetLanguages: []string{"en"},
+		Options:         map[string]interface{}{"pipeline": "missing"},
+	}, p)
+	var appErr *coreerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != coreerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error for unknown pipeline, got %v", err)
+	}
+}
+
+func TestProcessor_SkipsTranslationIntoSourceLanguage(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "unexpected call", http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger)
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	resp, err := service.Process(context.Background(), ProcessRequest{Text: "你好", SourceLanguage: "zh", TargetLanguages: []string{"zh"}}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	defer resp.Release()
+	if resp.Translations["zh"] != "你好" {
+		t.Fatalf("translations=%v want source text passed through", resp.Translations)
+	}
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Fatalf("llm calls=%d want 0", got)
+	}
+}
+
+func TestProcessor_CorrectionFailureKeepsOriginalText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// 纠错请求失败，翻译请求正常返回
+		if !strings.Contains(string(body), "translations") {
+			http.Error(w, "correction backend down", http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "```json\n{\"translations\":{\"en\":\"hello\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger).
+		WithCorrectionConfig(config.CorrectionConfig{Enabled: true})
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	resp, err := service.Process(context.Background(), ProcessRequest{Text: "你好", TargetLanguages: []string{"en"}}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	defer resp.Release()
+	if resp.CorrectedText != "你好" || resp.Translations["en"] != "hello" {
+		t.Fatalf("unexpected response: corrected=%q translations=%v", resp.CorrectedText, resp.Translations)
+	}
+	outcomes, ok := resp.Metadata["step_outcomes"].(map[string]tool.StepOutcome)
+	if !ok {
+		t.Fatalf("step_outcomes missing: %v", resp.Metadata)
+	}
+	if outcomes["correct_result"].Status != tool.OutcomeSkipped || outcomes["correct_result"].Error == "" {
+		t.Fatalf("correct_result outcome=%+v", outcomes["correct_result"])
+	}
+	if outcomes["translate_result"].Status != tool.OutcomeOK {
+		t.Fatalf("translate_result outcome=%+v", outcomes["translate_result"])
+	}
+}
+
+func TestProcessor_GlossaryCheckRetranslatesViolations(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// 首次翻译未使用指定译法，带术语要求的重译使用了指定译法
+		content := "```json\n{\"translations\":{\"ja\":\"ブイアールチャットで会おう\"}}\n```"
+		if strings.Contains(string(body), "术语要求") {
+			content = "```json\n{\"translations\":{\"ja\":\"VRチャットで会おう\"}}\n```"
+		}
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": content}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger)
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	resp, err := service.Process(context.Background(), ProcessRequest{
+		Text:            "在vrchat见",
+		TargetLanguages: []string{"ja"},
+		UserDictionary:  []config.DictionaryTerm{{Term: "VRChat", Translations: map[string]string{"JA": "VRチャット"}}},
+	}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	defer resp.Release()
+	if resp.Translations["ja"] != "VRチャットで会おう" {
+		t.Fatalf("translations=%v want glossary-compliant re-translation", resp.Translations)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("llm calls=%d want 2", got)
+	}
+	if _, ok := resp.Metadata["glossary_violations"]; ok {
+		t.Fatalf("unexpected glossary_violations: %v", resp.Metadata["glossary_violations"])
+	}
+	outcomes, _ := resp.Metadata["step_outcomes"].(map[string]tool.StepOutcome)
+	if outcomes["glossary_result"].Status != tool.OutcomeOK {
+		t.Fatalf("glossary_result outcome=%+v", outcomes["glossary_result"])
+	}
+}
+
+func TestProcessor_ModerationStep(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "```json\n{\"translations\":{\"en\":\"you jerk\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	pipelineCfg := config.PipelineConfig{
+		Definitions: map[string]config.PipelineDefinition{
+			"safe_translate": {Type: "text", Steps: []config.PipelineStepConfig{
+				{Tool: "text_translate", InputMapping: map[string]string{
+					"text":             "request.text",
+					"target_languages": "request.target_languages",
+				}, OutputKey: "translated"},
+				{Tool: "moderate", InputMapping: map[string]string{"translations": "translated.translations"}, OutputKey: "moderated"},
+			}},
+		},
+		Tasks: config.PipelineTaskMapping{Text: map[string]string{"translate": "safe_translate"}},
+		Moderation: config.ModerationConfig{Rules: []config.ModerationRule{
+			{Name: "insults", Words: []string{"jerk"}, Action: config.ModerationMask},
+		}},
+	}
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger).
+		WithPipelineConfig(pipelineCfg)
+	if err := p.ValidatePipelines(); err != nil {
+		t.Fatalf("ValidatePipelines: %v", err)
+	}
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	resp, err := service.Process(context.Background(), ProcessRequest{Text: "你这个混蛋", TargetLanguages: []string{"en"}}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	defer resp.Release()
+	if resp.Translations["en"] != "you ****" {
+		t.Fatalf("translations=%v want masked", resp.Translations)
+	}
+	if resp.Moderation == nil || !resp.Moderation.Flagged || resp.Moderation.Blocked || len(resp.Moderation.Matches) != 1 {
+		t.Fatalf("moderation=%+v", resp.Moderation)
+	}
+	if m := resp.Moderation.Matches[0]; m.Field != "translations.en" || m.Rule != "insults" || m.Action != "mask" {
+		t.Fatalf("match=%+v", m)
+	}
+}
+
+func TestProcessor_ModerationMasksSourceText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "```json\n{\"translations\":{\"zh\":\"你这个****\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	pipelineCfg := config.PipelineConfig{
+		Definitions: map[string]config.PipelineDefinition{
+			"moderate_source": {Type: "text", Steps: []config.PipelineStepConfig{
+				{Tool: "moderate", InputMapping: map[string]string{"text": "request.text"}, OutputKey: "moderated"},
+				{Tool: "text_correct_translate", InputMapping: map[string]string{
+					"text":             "moderated.text",
+					"target_languages": "request.target_languages",
+				}, OutputKey: "translated", When: "!moderated.blocked"},
+			}},
+		},
+		Tasks: config.PipelineTaskMapping{Text: map[string]string{"translate": "moderate_source"}},
+		Moderation: config.ModerationConfig{Rules: []config.ModerationRule{
+			{Name: "insults", Words: []string{"jerk"}, Action: config.ModerationMask},
+			{Name: "doxxing", Patterns: []string{`\d{3}-\d{4}`}, Action: config.ModerationBlock},
+		}},
+	}
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger).
+		WithPipelineConfig(pipelineCfg)
+	if err := p.ValidatePipelines(); err != nil {
+		t.Fatalf("ValidatePipelines: %v", err)
+	}
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	resp, err := service.Process(context.Background(), ProcessRequest{Text: "you jerk", TargetLanguages: []string{"zh"}}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	body, err := json.Marshal(resp)
+	resp.Release()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded["source_text"] != "you ****" || strings.Contains(string(body), `"you jerk"`) {
+		t.Fatalf("source text not masked in body: %s", body)
+	}
+
+	resp, err = service.Process(context.Background(), ProcessRequest{Text: "call 555-1234", TargetLanguages: []string{"zh"}}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	body, err = json.Marshal(resp)
+	resp.Release()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(body), "call 555-1234") || !strings.Contains(string(body), `"blocked":true`) {
+		t.Fatalf("blocked text leaked into body: %s", body)
+	}
+}
+
+func TestProcessor_TTSStep(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/audio/speech") {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(body["voice"].(string) + ":" + body["input"].(string)))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "```json\n{\"translations\":{\"en\":\"hello\",\"ja\":\"こんにちは\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := testutil.NewTestLogger()
+	cfg := newTestPromptConfig()
+	engine, err := prompt.NewEngine(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	llmManager, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{Name: "test", Type: "openai", URL: server.URL, Model: "test-model"},
+		},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	ttsCfg := config.TTSConfig{
+		Providers:    []config.TTSProvider{{Name: "tts", Type: "openai", URL: server.URL, Model: "tts-1"}},
+		DefaultVoice: "alloy",
+		Voices:       map[string]string{"ja": "nova"},
+		Format:       "mp3",
+	}
+	ttsManager, err := tts.NewManager(ttsCfg, logger)
+	if err != nil {
+		t.Fatalf("tts.NewManager: %v", err)
+	}
+	store := tts.NewStore(time.Minute, 8)
+
+	p := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger).
+		WithTTS(ttsManager, store, ttsCfg)
+	service := processing.NewService[ProcessRequest, *ProcessResponse](llmManager, engine, logger)
+
+	resp, err := service.Process(context.Background(), ProcessRequest{
+		Text:            "你好",
+		TargetLanguages: []string{"en", "ja"},
+		Options:         map[string]interface{}{"tts": map[string]interface{}{"language": "ja", "delivery": "url"}},
+	}, p)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	defer resp.Release()
+	if resp.Speech == nil || resp.Speech.Language != "ja" || resp.Speech.Voice != "nova" || resp.Speech.URL == "" {
+		t.Fatalf("speech=%+v", resp.Speech)
+	}
+	clip, ok := store.Get(strings.TrimPrefix(resp.Speech.URL, tool.SpeechPathPrefix))
+	if !ok || string(clip.Audio) != "nova:こんにちは" {
+		t.Fatalf("stored clip=%q ok=%v", clip.Audio, ok)
+	}
+	if resp.Translations["en"] != "hello" || resp.Metadata["tts_backend"] != "tts" {
+		t.Fatalf("translations=%v metadata=%v", resp.Translations, resp.Metadata)
+	}
+
+	plain := NewProcessor(llmManager, engine, metrics.NewSimpleMetricsCollector(logger), cfg, logger)
+	_, err = service.Process(context.Background(), ProcessRequest{
+		Text:            "你好",
+		TargetLanguages: []string{"en"},
+		Options:         map[string]interface{}{"tts": true},
+	}, plain)
+	var appErr *coreerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != coreerrors.ErrCodeValidation {
+		t.Fatalf("err=%v want validation error when tts is not configured", err)
+	}
+}
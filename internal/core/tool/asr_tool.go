@@ -2,17 +2,45 @@ package tool
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/cache"
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 )
 
+// LanguageNormalizer maps a language name or alias to its configured language code.
+type LanguageNormalizer interface {
+	NormalizeLanguage(input string) (string, error)
+}
+
 type ASRTool struct {
-	manager *asr.Manager
+	manager   *asr.Manager
+	cache     cache.TranscriptionCache
+	cacheTTL  time.Duration
+	languages LanguageNormalizer
 }
 
 func NewASRTool(manager *asr.Manager) *ASRTool {
-	return &ASRTool{manager: manager}
+	return NewASRToolWithCache(manager, nil, 0)
+}
+
+// NewASRToolWithCache creates an ASRTool that reuses cached transcriptions for identical audio.
+func NewASRToolWithCache(manager *asr.Manager, transcriptionCache cache.TranscriptionCache, cacheTTL time.Duration) *ASRTool {
+	return &ASRTool{
+		manager:  manager,
+		cache:    transcriptionCache,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// WithLanguageNormalizer reports the detected language as a language code. Backends return
+// names ("English", "english"); unknown values are passed through unchanged.
+func (t *ASRTool) WithLanguageNormalizer(languages LanguageNormalizer) *ASRTool {
+	t.languages = languages
+	return t
 }
 
 func (t *ASRTool) Name() string {
@@ -87,13 +115,33 @@ func (t *ASRTool) Execute(ctx context.Context, input Input) (Output, error) {
 	format := input.Data["format"].(string)
 	language, _ := input.Data["language"].(string)
 
-	resp, err := t.manager.Transcribe(ctx, &asr.ASRRequest{
-		Audio:       audioBytes,
-		AudioFormat: format,
-		Language:    language,
-	})
-	if err != nil {
-		return Output{}, err
+	cacheKey := ""
+	cacheHit := false
+	var resp *asr.ASRResponse
+	if t.cacheEnabled() {
+		cacheKey = cache.GenerateTranscriptionCacheKey(audioBytes, format, language, t.providerKey())
+		if cached, ok := t.cache.Get(cacheKey); ok && cached != nil && cached.Response != nil {
+			resp = cached.Response
+			cacheHit = true
+		}
+	}
+
+	if resp == nil {
+		var err error
+		resp, err = t.manager.Transcribe(ctx, &asr.ASRRequest{
+			Audio:       audioBytes,
+			AudioFormat: format,
+			Language:    language,
+		})
+		if err != nil {
+			return Output{}, err
+		}
+		if cacheKey != "" {
+			t.cache.Set(cacheKey, &cache.CachedTranscription{
+				Response: resp,
+				CachedAt: time.Now(),
+			}, t.cacheTTL)
+		}
 	}
 
 	segments := make([]map[string]interface{}, 0, len(resp.Segments))
@@ -106,15 +154,26 @@ func (t *ASRTool) Execute(ctx context.Context, input Input) (Output, error) {
 		})
 	}
 
+	detectedLanguage := resp.DetectedLanguage
+	if t.languages != nil && strings.TrimSpace(detectedLanguage) != "" {
+		if code, err := t.languages.NormalizeLanguage(detectedLanguage); err == nil {
+			detectedLanguage = code
+		}
+	}
+
 	out := Output{
 		Data: map[string]interface{}{
 			"text":     resp.Text,
-			"language": resp.DetectedLanguage,
+			"language": detectedLanguage,
 			"duration": resp.Duration,
 			"segments": segments,
 		},
 	}
 
+	if t.cache != nil {
+		out.Metadata = map[string]interface{}{"cache_hit": cacheHit}
+	}
+
 	if input.Context != nil && input.Context.OriginalRequest != nil {
 		if originalFormat, ok := input.Context.OriginalRequest["audio_format"].(string); ok && originalFormat != "" && originalFormat != format {
 			if out.Metadata == nil {
@@ -127,3 +186,14 @@ func (t *ASRTool) Execute(ctx context.Context, input Input) (Output, error) {
 
 	return out, nil
 }
+
+func (t *ASRTool) cacheEnabled() bool {
+	return t.cache != nil && t.cacheTTL > 0
+}
+
+// providerKey identifies the configured backend set so results from different providers are not mixed.
+func (t *ASRTool) providerKey() string {
+	names := t.manager.ListBackends()
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
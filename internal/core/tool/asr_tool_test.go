@@ -5,15 +5,28 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/asr"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/cache"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/testutil"
 )
 
 func newTestASRManager(t *testing.T, text string) *asr.Manager {
 	t.Helper()
+	return newCountingASRManager(t, text, nil)
+}
+
+func newCountingASRManager(t *testing.T, text string, calls *int32) *asr.Manager {
+	t.Helper()
+	return newLanguageASRManager(t, text, "zh", calls)
+}
+
+func newLanguageASRManager(t *testing.T, text, language string, calls *int32) *asr.Manager {
+	t.Helper()
 
 	asrSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -22,9 +35,12 @@ func newTestASRManager(t *testing.T, text string) *asr.Manager {
 			_, _ = w.Write([]byte(`{"data":[]}`))
 			return
 		case "/v1/audio/transcriptions":
+			if calls != nil {
+				atomic.AddInt32(calls, 1)
+			}
 			w.Header().Set("Content-Type", "application/json")
 			_ = json.NewEncoder(w).Encode(map[string]any{
-				"language": "zh",
+				"language": language,
 				"duration": 1.0,
 				"text":     text,
 			})
@@ -71,3 +87,62 @@ func TestASRTool_Execute(t *testing.T) {
 		t.Fatalf("text=%q want 你好", got)
 	}
 }
+
+func TestASRTool_ExecuteUsesCache(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	m := newCountingASRManager(t, "你好", &calls)
+	tool := NewASRToolWithCache(m, cache.NewInMemoryTranscriptionCache(10), time.Minute)
+
+	input := func(audio []byte) Input {
+		return Input{Data: map[string]interface{}{"audio": audio, "format": "wav"}}
+	}
+
+	first, err := tool.Execute(context.Background(), input([]byte{0x00, 0x01}))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if hit, _ := first.Metadata["cache_hit"].(bool); hit {
+		t.Fatalf("first call should miss the cache")
+	}
+
+	second, err := tool.Execute(context.Background(), input([]byte{0x00, 0x01}))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if hit, _ := second.Metadata["cache_hit"].(bool); !hit {
+		t.Fatalf("second call should hit the cache")
+	}
+	if got, _ := second.Data["text"].(string); got != "你好" {
+		t.Fatalf("text=%q want 你好", got)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("transcription calls=%d want 1", got)
+	}
+
+	if _, err := tool.Execute(context.Background(), input([]byte{0x02})); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("transcription calls=%d want 2 for different audio", got)
+	}
+}
+
+func TestASRTool_NormalizesDetectedLanguage(t *testing.T) {
+	t.Parallel()
+
+	engine := newTestPromptEngine(t)
+	for detected, want := range map[string]string{"english": "en", "Japanese": "ja", "en": "en", "klingon": "klingon"} {
+		tool := NewASRTool(newLanguageASRManager(t, "hello", detected, nil)).WithLanguageNormalizer(engine)
+		out, err := tool.Execute(context.Background(), Input{
+			Data: map[string]interface{}{"audio": []byte{0x00, 0x01}, "format": "wav"},
+		})
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		if got := out.Data["language"]; got != want {
+			t.Fatalf("language for %q = %v want %s", detected, got, want)
+		}
+	}
+}
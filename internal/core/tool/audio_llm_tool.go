@@ -0,0 +1,217 @@
+package tool
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+)
+
+// AudioLLMTool sends audio straight to an audio-capable chat model and asks for
+// transcription plus translations in one call.
+type AudioLLMTool struct {
+	llmManager          *llm.Manager
+	promptEngine        *prompt.Engine
+	toolCallingEnabled  bool
+	toolCallingThinking bool
+}
+
+func NewAudioLLMTool(llmManager *llm.Manager, promptEngine *prompt.Engine, toolCallingEnabled, allowThinking bool) *AudioLLMTool {
+	return &AudioLLMTool{
+		llmManager:          llmManager,
+		promptEngine:        promptEngine,
+		toolCallingEnabled:  toolCallingEnabled,
+		toolCallingThinking: allowThinking,
+	}
+}
+
+func (t *AudioLLMTool) Name() string {
+	return "audio_llm"
+}
+
+func (t *AudioLLMTool) Description() string {
+	return "Transcribe and translate audio with an audio-capable chat model (single call)"
+}
+
+func (t *AudioLLMTool) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"audio": map[string]interface{}{
+				"type":        "string",
+				"description": "Audio bytes",
+			},
+			"format": map[string]interface{}{
+				"type":        "string",
+				"description": "Audio format (e.g. wav, mp3)",
+			},
+			"source_language": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional source language hint",
+			},
+			"target_languages": map[string]interface{}{
+				"type":        "array",
+				"description": "Target language codes (empty for transcription only)",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			},
+		},
+		"required": []string{"audio", "format"},
+	}
+}
+
+func (t *AudioLLMTool) OutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"transcription": map[string]interface{}{
+				"type":        "string",
+				"description": "Transcribed source text",
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"description": "Detected source language code",
+			},
+			"translations": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Translation results keyed by language code",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+		},
+		"required": []string{"transcription"},
+	}
+}
+
+func (t *AudioLLMTool) Validate(input Input) error {
+	if input.Data == nil {
+		return coreerrors.NewValidationError("input data is required", nil)
+	}
+	audioBytes, ok := input.Data["audio"].([]byte)
+	if !ok || len(audioBytes) == 0 {
+		return coreerrors.NewValidationError("audio is required", nil)
+	}
+	format, ok := input.Data["format"].(string)
+	if !ok || strings.TrimSpace(format) == "" {
+		return coreerrors.NewValidationError("format is required", nil)
+	}
+	return nil
+}
+
+func (t *AudioLLMTool) Execute(ctx context.Context, input Input) (Output, error) {
+	if t.llmManager == nil {
+		return Output{}, coreerrors.NewInternalError("llm manager not configured", nil)
+	}
+	if t.promptEngine == nil {
+		return Output{}, coreerrors.NewInternalError("prompt engine not configured", nil)
+	}
+	if err := t.Validate(input); err != nil {
+		return Output{}, err
+	}
+
+	audioBytes := input.Data["audio"].([]byte)
+	format := strings.ToLower(strings.TrimSpace(input.Data["format"].(string)))
+	sourceLanguage, _ := input.Data["source_language"].(string)
+	targetLangs, _ := coerceStringSlice(input.Data["target_languages"])
+
+	dictionary := []config.DictionaryTerm{}
+	if input.Context != nil {
+		dictionary = input.Context.Dictionary
+	}
+
+	promptObj, err := t.promptEngine.BuildAudioTranscribeTranslatePrompt(ctx, sourceLanguage, targetLangs, dictionary)
+	if err != nil {
+		return Output{}, err
+	}
+
+	systemPrompt := promptObj.System
+	if t.toolCallingEnabled && !t.toolCallingThinking {
+		systemPrompt += "\n\n请不要输出解释或思考，仅通过工具调用返回结果。"
+	}
+
+	llmReq := &llm.LLMRequest{
+		SystemPrompt: systemPrompt,
+		UserPrompt:   promptObj.User,
+		Audio: &llm.AudioInput{
+			Data:   audioBytes,
+			Format: format,
+		},
+	}
+	if input.Context != nil && input.Context.OriginalRequest != nil {
+		if opts, ok := input.Context.OriginalRequest["options"].(map[string]interface{}); ok {
+			llmReq.Options = opts
+		}
+	}
+
+	if t.toolCallingEnabled {
+		llmReq.Tools = submitResultTools(t.OutputSchema(), "Submit transcription and translations")
+		llmReq.ToolChoice = &llm.ToolChoice{Mode: llm.ToolChoiceRequired}
+	}
+
+	var toolResult struct {
+		Transcription string            `json:"transcription"`
+		Language      string            `json:"language"`
+		Translations  map[string]string `json:"translations"`
+	}
+	llmResp, err := processToolCall(ctx, t.llmManager, llmReq, t.OutputSchema(), &toolResult)
+	if err != nil {
+		return Output{}, err
+	}
+
+	transcription := strings.TrimSpace(toolResult.Transcription)
+	language := strings.TrimSpace(toolResult.Language)
+	translations := map[string]string{}
+	for k, v := range toolResult.Translations {
+		if strings.TrimSpace(v) != "" {
+			translations[k] = v
+		}
+	}
+
+	if transcription == "" {
+		parsed, err := t.promptEngine.ParseResponse(llmResp.Content)
+		if err == nil {
+			transcription = strings.TrimSpace(parsed.Transcription)
+			if len(translations) == 0 {
+				for k, v := range parsed.Sections {
+					if strings.TrimSpace(v) != "" {
+						translations[k] = v
+					}
+				}
+			}
+		}
+	}
+
+	if transcription == "" {
+		return Output{}, coreerrors.NewParsingError("audio model returned no transcription", nil)
+	}
+	if language == "" {
+		language = sourceLanguage
+	}
+
+	filtered := make(map[string]string)
+	for _, code := range targetLangs {
+		if v, ok := translations[code]; ok {
+			filtered[code] = v
+		}
+	}
+
+	out := Output{
+		Data: map[string]interface{}{
+			"text":          transcription,
+			"transcription": transcription, // mirrors OutputSchema so the step output validates
+			"language":      language,
+			"translations":  filtered,
+			"raw_response":  bestEffortRawResponse(llmResp),
+		},
+		Metadata: map[string]interface{}{
+			"backend":       llmResp.Metadata["backend"],
+			"model":         llmResp.Model,
+			"prompt_tokens": llmResp.PromptTokens,
+			"total_tokens":  llmResp.TotalTokens,
+		},
+	}
+	return out, nil
+}
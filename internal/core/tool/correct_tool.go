@@ -79,6 +79,13 @@ func (t *CorrectTool) Validate(input Input) error {
 	return nil
 }
 
+// Passthrough returns the input text as corrected_text.
+func (t *CorrectTool) Passthrough(ctx context.Context, input Input) Output {
+	text, _ := input.Data["text"].(string)
+	PublishPartial(ctx, PartialResult{Kind: PartialCorrectedText, Text: text})
+	return Output{Data: map[string]interface{}{"corrected_text": text}}
+}
+
 func (t *CorrectTool) Execute(ctx context.Context, input Input) (Output, error) {
 	if t.llmManager == nil {
 		return Output{}, coreerrors.NewInternalError("llm manager not configured", nil)
@@ -123,21 +130,15 @@ func (t *CorrectTool) Execute(ctx context.Context, input Input) (Output, error)
 		llmReq.ToolChoice = &llm.ToolChoice{Mode: llm.ToolChoiceRequired}
 	}
 
-	llmResp, err := t.llmManager.ProcessWithTimeout(ctx, llmReq)
+	var toolResult struct {
+		CorrectedText string `json:"corrected_text"`
+	}
+	llmResp, err := processToolCall(ctx, t.llmManager, llmReq, t.OutputSchema(), &toolResult)
 	if err != nil {
 		return Output{}, err
 	}
 
-	correctedText := ""
-
-	if t.toolCallingEnabled {
-		var toolResult struct {
-			CorrectedText string `json:"corrected_text"`
-		}
-		if err := llm.ParseToolCallResponse(llmResp, submitResultFunctionName, &toolResult); err == nil {
-			correctedText = strings.TrimSpace(toolResult.CorrectedText)
-		}
-	}
+	correctedText := strings.TrimSpace(toolResult.CorrectedText)
 
 	if correctedText == "" {
 		parsed, err := t.promptEngine.ParseResponse(llmResp.Content)
@@ -148,6 +149,7 @@ func (t *CorrectTool) Execute(ctx context.Context, input Input) (Output, error)
 	if correctedText == "" {
 		correctedText = sourceText
 	}
+	PublishPartial(ctx, PartialResult{Kind: PartialCorrectedText, Text: correctedText})
 
 	out := Output{
 		Data: map[string]interface{}{
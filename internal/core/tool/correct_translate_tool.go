@@ -15,6 +15,7 @@ type CorrectTranslateTool struct {
 	promptEngine        *prompt.Engine
 	toolCallingEnabled  bool
 	toolCallingThinking bool
+	translation         config.TranslationConfig
 }
 
 func NewCorrectTranslateTool(llmManager *llm.Manager, promptEngine *prompt.Engine, toolCallingEnabled, allowThinking bool) *CorrectTranslateTool {
@@ -26,6 +27,13 @@ func NewCorrectTranslateTool(llmManager *llm.Manager, promptEngine *prompt.Engin
 	}
 }
 
+// WithTranslationConfig enables re-requesting languages missing from the merged reply through
+// the translate path (translation.retry_missing rounds, grouped when translation.fan_out is set).
+func (t *CorrectTranslateTool) WithTranslationConfig(cfg config.TranslationConfig) *CorrectTranslateTool {
+	t.translation = cfg
+	return t
+}
+
 func (t *CorrectTranslateTool) Name() string {
 	return "correct_translate"
 }
@@ -95,6 +103,16 @@ func (t *CorrectTranslateTool) Validate(input Input) error {
 	return nil
 }
 
+// Passthrough returns the input text as corrected_text and as every translation.
+func (t *CorrectTranslateTool) Passthrough(ctx context.Context, input Input) Output {
+	text, _ := input.Data["text"].(string)
+	PublishPartial(ctx, PartialResult{Kind: PartialCorrectedText, Text: text})
+	return Output{Data: map[string]interface{}{
+		"corrected_text": text,
+		"translations":   passthroughTranslations(ctx, input),
+	}}
+}
+
 func (t *CorrectTranslateTool) Execute(ctx context.Context, input Input) (Output, error) {
 	if t.llmManager == nil {
 		return Output{}, coreerrors.NewInternalError("llm manager not configured", nil)
@@ -139,26 +157,20 @@ func (t *CorrectTranslateTool) Execute(ctx context.Context, input Input) (Output
 		llmReq.ToolChoice = &llm.ToolChoice{Mode: llm.ToolChoiceRequired}
 	}
 
-	llmResp, err := t.llmManager.ProcessWithTimeout(ctx, llmReq)
+	var toolResult struct {
+		CorrectedText string            `json:"corrected_text"`
+		Translations  map[string]string `json:"translations"`
+	}
+	llmResp, err := processToolCall(ctx, t.llmManager, llmReq, t.OutputSchema(), &toolResult)
 	if err != nil {
 		return Output{}, err
 	}
 
-	correctedText := ""
+	correctedText := strings.TrimSpace(toolResult.CorrectedText)
 	translations := map[string]string{}
-
-	if t.toolCallingEnabled {
-		var toolResult struct {
-			CorrectedText string            `json:"corrected_text"`
-			Translations  map[string]string `json:"translations"`
-		}
-		if err := llm.ParseToolCallResponse(llmResp, submitResultFunctionName, &toolResult); err == nil {
-			correctedText = strings.TrimSpace(toolResult.CorrectedText)
-			for k, v := range toolResult.Translations {
-				if strings.TrimSpace(v) != "" {
-					translations[k] = v
-				}
-			}
+	for k, v := range toolResult.Translations {
+		if strings.TrimSpace(v) != "" {
+			translations[k] = v
 		}
 	}
 
@@ -188,6 +200,8 @@ func (t *CorrectTranslateTool) Execute(ctx context.Context, input Input) (Output
 			filtered[code] = v
 		}
 	}
+	PublishPartial(ctx, PartialResult{Kind: PartialCorrectedText, Text: correctedText})
+	publishTranslations(ctx, targetLangs, filtered)
 
 	out := Output{
 		Data: map[string]interface{}{
@@ -202,5 +216,48 @@ func (t *CorrectTranslateTool) Execute(ctx context.Context, input Input) (Output
 			"total_tokens":  llmResp.TotalTokens,
 		},
 	}
+	if missing := missingLanguages(targetLangs, filtered); len(missing) > 0 {
+		t.completeMissing(ctx, input, correctedText, missing, out)
+	}
 	return out, nil
 }
+
+// completeMissing translates the corrected text into languages the merged reply left out, when
+// translation.retry_missing allows it, and reports the ones still missing in out's metadata.
+func (t *CorrectTranslateTool) completeMissing(ctx context.Context, input Input, text string, missing []string, out Output) {
+	errored := map[string]bool{}
+	if t.translation.RetryMissing > 0 {
+		cfg := t.translation
+		cfg.RetryMissing-- // the merged call was the first round
+		translator := NewTranslateTool(t.llmManager, t.promptEngine, t.toolCallingEnabled, t.toolCallingThinking).
+			WithTranslationConfig(cfg)
+		split := oneGroup
+		if cfg.FanOut {
+			split = translator.languageGroups
+		}
+
+		retried, err := translator.executeGroups(ctx, input, text, "", missing, split, "")
+		if err != nil {
+			for _, code := range missing {
+				errored[code] = true
+			}
+		} else {
+			translations := out.Data["translations"].(map[string]string)
+			for k, v := range retried.Data["translations"].(map[string]string) {
+				translations[k] = v
+			}
+			if raw, _ := retried.Data["raw_response"].(string); raw != "" {
+				out.Data["raw_response"] = strings.TrimSpace(out.Data["raw_response"].(string) + "\n\n" + raw)
+			}
+			out.Metadata["prompt_tokens"] = out.Metadata["prompt_tokens"].(int) + retried.Metadata["prompt_tokens"].(int)
+			out.Metadata["total_tokens"] = out.Metadata["total_tokens"].(int) + retried.Metadata["total_tokens"].(int)
+			if failed, ok := retried.Metadata["failed_languages"].([]string); ok {
+				for _, code := range failed {
+					errored[code] = true
+				}
+			}
+			missing = missingLanguages(missing, translations)
+		}
+	}
+	reportMissingLanguages(out.Metadata, missing, errored)
+}
@@ -0,0 +1,269 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+)
+
+// GlossaryViolation is a translation that lacks the required translation of a dictionary term.
+type GlossaryViolation struct {
+	Term     string `json:"term"`
+	Language string `json:"language"`
+	Expected string `json:"expected"`
+}
+
+// GlossaryCheckTool enforces dictionary term translations. A term applies when it or one of
+// its aliases occurs in the source text (case-insensitive); every translation into a language
+// the term defines must then contain the required form verbatim. Violating languages are
+// re-translated once with the requirements spelled out, and a re-translation replaces the
+// original only if it satisfies all of them. Remaining violations are reported in metadata.
+type GlossaryCheckTool struct {
+	translator *TranslateTool
+}
+
+// NewGlossaryCheckTool creates the tool. translator is used for the constrained re-translation;
+// with a nil translator violations are only reported.
+func NewGlossaryCheckTool(translator *TranslateTool) *GlossaryCheckTool {
+	return &GlossaryCheckTool{translator: translator}
+}
+
+func (t *GlossaryCheckTool) Name() string {
+	return "glossary_check"
+}
+
+func (t *GlossaryCheckTool) Description() string {
+	return "Check translations against the dictionary's required term translations"
+}
+
+func (t *GlossaryCheckTool) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"text": map[string]interface{}{
+				"type":        "string",
+				"description": "Source text the translations were produced from",
+			},
+			"translations": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Translations keyed by language code",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+			"source_language": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional source language hint for re-translation",
+			},
+		},
+		"required": []string{"text", "translations"},
+	}
+}
+
+func (t *GlossaryCheckTool) OutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"translations": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+			"violations": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"term":     map[string]string{"type": "string"},
+						"language": map[string]string{"type": "string"},
+						"expected": map[string]string{"type": "string"},
+					},
+					"required": []string{"term", "language", "expected"},
+				},
+			},
+		},
+		"required": []string{"translations", "violations"},
+	}
+}
+
+func (t *GlossaryCheckTool) Validate(input Input) error {
+	if input.Data == nil {
+		return coreerrors.NewValidationError("input data is required", nil)
+	}
+	if _, ok := input.Data["text"].(string); !ok {
+		return coreerrors.NewValidationError("text must be a string", nil)
+	}
+	if _, ok := coerceStringMap(input.Data["translations"]); !ok {
+		return coreerrors.NewValidationError("translations must be an object of strings", nil)
+	}
+	return nil
+}
+
+// Passthrough returns the translations unchanged.
+func (t *GlossaryCheckTool) Passthrough(ctx context.Context, input Input) Output {
+	translations, _ := coerceStringMap(input.Data["translations"])
+	if translations == nil {
+		translations = map[string]string{}
+	}
+	return Output{Data: map[string]interface{}{
+		"translations": translations,
+		"violations":   []GlossaryViolation{},
+	}}
+}
+
+func (t *GlossaryCheckTool) Execute(ctx context.Context, input Input) (Output, error) {
+	if err := t.Validate(input); err != nil {
+		return Output{}, err
+	}
+
+	sourceText := strings.TrimSpace(input.Data["text"].(string))
+	translations, _ := coerceStringMap(input.Data["translations"])
+	sourceLang, _ := input.Data["source_language"].(string)
+	sourceLang = strings.TrimSpace(sourceLang)
+
+	var dictionary []config.DictionaryTerm
+	if input.Context != nil {
+		dictionary = input.Context.Dictionary
+	}
+	terms := matchedGlossaryTerms(GlossaryTerms(dictionary), sourceText)
+
+	result := make(map[string]string, len(translations))
+	for k, v := range translations {
+		result[k] = v
+	}
+	metadata := map[string]interface{}{}
+
+	violations := checkGlossary(terms, result)
+	if len(violations) > 0 && t.translator != nil && sourceText != "" {
+		langs := violationLanguages(violations)
+		metadata["glossary_retried_languages"] = langs
+
+		retried, _, err := t.translator.translate(ctx, input, sourceText, sourceLang, langs, glossaryConstraints(violations))
+		if err != nil {
+			metadata["glossary_retry_error"] = err.Error()
+		} else {
+			fixed := map[string]string{}
+			for _, lang := range langs {
+				candidate, ok := retried[lang]
+				if ok && len(checkGlossary(terms, map[string]string{lang: candidate})) == 0 {
+					fixed[lang] = candidate
+					result[lang] = candidate
+				}
+			}
+			publishTranslations(ctx, langs, fixed)
+			violations = checkGlossary(terms, result)
+		}
+	}
+	if len(violations) > 0 {
+		metadata["glossary_violations"] = violations
+	}
+
+	return Output{
+		Data: map[string]interface{}{
+			"translations": result,
+			"violations":   violations,
+		},
+		Metadata: metadata,
+	}, nil
+}
+
+// GlossaryTerms returns the dictionary terms that define at least one required translation.
+func GlossaryTerms(dictionary []config.DictionaryTerm) []config.DictionaryTerm {
+	var out []config.DictionaryTerm
+	for _, term := range dictionary {
+		for _, v := range term.Translations {
+			if strings.TrimSpace(v) != "" {
+				out = append(out, term)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// matchedGlossaryTerms returns the terms whose term or an alias occurs in text.
+func matchedGlossaryTerms(terms []config.DictionaryTerm, text string) []config.DictionaryTerm {
+	lower := strings.ToLower(text)
+	var out []config.DictionaryTerm
+	for _, term := range terms {
+		for _, form := range append([]string{term.Term}, term.Aliases...) {
+			form = strings.ToLower(strings.TrimSpace(form))
+			if form != "" && strings.Contains(lower, form) {
+				out = append(out, term)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// checkGlossary lists, in language order, every translation missing a required term translation.
+func checkGlossary(terms []config.DictionaryTerm, translations map[string]string) []GlossaryViolation {
+	langs := make([]string, 0, len(translations))
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	violations := []GlossaryViolation{}
+	for _, lang := range langs {
+		for _, term := range terms {
+			expected := requiredTranslation(term, lang)
+			if expected != "" && !strings.Contains(translations[lang], expected) {
+				violations = append(violations, GlossaryViolation{Term: term.Term, Language: lang, Expected: expected})
+			}
+		}
+	}
+	return violations
+}
+
+func requiredTranslation(term config.DictionaryTerm, lang string) string {
+	for code, v := range term.Translations {
+		if strings.EqualFold(code, lang) {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+func violationLanguages(violations []GlossaryViolation) []string {
+	seen := map[string]bool{}
+	var langs []string
+	for _, v := range violations {
+		if !seen[v.Language] {
+			seen[v.Language] = true
+			langs = append(langs, v.Language)
+		}
+	}
+	return langs
+}
+
+// glossaryConstraints renders the violated requirements as an extra instruction for the translator.
+func glossaryConstraints(violations []GlossaryViolation) string {
+	var b strings.Builder
+	b.WriteString("术语要求：以下术语必须使用指定译法，不得改写或省略：")
+	for _, v := range violations {
+		fmt.Fprintf(&b, "\n- %s：「%s」必须译为「%s」", v.Language, v.Term, v.Expected)
+	}
+	return b.String()
+}
+
+func coerceStringMap(v interface{}) (map[string]string, bool) {
+	switch vv := v.(type) {
+	case map[string]string:
+		return vv, true
+	case map[string]interface{}:
+		out := make(map[string]string, len(vv))
+		for k, item := range vv {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			out[k] = s
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,112 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+)
+
+func TestGlossaryCheckTool_Execute(t *testing.T) {
+	t.Parallel()
+
+	dictionary := []config.DictionaryTerm{
+		{Term: "VRChat", Aliases: []string{"VRC"}, Translations: map[string]string{"ja": "VRチャット", "en": "VRChat"}},
+		{Term: "Lingualink", Translations: map[string]string{"ja": "リンガリンク"}},
+		{Term: "avatar"},
+	}
+
+	cases := map[string]struct {
+		text           string
+		translations   map[string]string
+		reply          string
+		wantCalls      int
+		wantJA         string
+		wantViolations int
+	}{
+		"no violation": {
+			text:         "我在vrc里",
+			translations: map[string]string{"ja": "VRチャットにいる", "en": "I'm in VRChat"},
+			wantCalls:    0,
+			wantJA:       "VRチャットにいる",
+		},
+		"fixed by retry": {
+			text:         "我在VRChat里",
+			translations: map[string]string{"ja": "ブイアールチャットにいる", "en": "I'm in VRChat"},
+			reply:        `{"translations":{"ja":"VRチャットにいる"}}`,
+			wantCalls:    1,
+			wantJA:       "VRチャットにいる",
+		},
+		"retry still violates": {
+			text:           "我在VRChat里",
+			translations:   map[string]string{"ja": "ブイアールチャットにいる", "en": "I'm in VRChat"},
+			reply:          `{"translations":{"ja":"ブイアールチャット"}}`,
+			wantCalls:      1,
+			wantJA:         "ブイアールチャットにいる",
+			wantViolations: 1,
+		},
+	}
+	for name, tc := range cases {
+		var mu sync.Mutex
+		var bodies []string
+		llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			_ = r.Body.Close()
+			mu.Lock()
+			bodies = append(bodies, string(body))
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{
+					"message": map[string]any{
+						"content": nil,
+						"tool_calls": []map[string]any{{
+							"id":       "call_1",
+							"type":     "function",
+							"function": map[string]any{"name": submitResultFunctionName, "arguments": tc.reply},
+						}},
+					},
+				}},
+				"usage": map[string]any{"prompt_tokens": 1, "total_tokens": 2},
+			})
+		}))
+
+		translator := NewTranslateTool(newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), true, false)
+		out, err := NewGlossaryCheckTool(translator).Execute(context.Background(), Input{
+			Data:    map[string]any{"text": tc.text, "translations": tc.translations},
+			Context: &PipelineContext{OriginalRequest: map[string]any{}, Dictionary: dictionary},
+		})
+		llmSrv.Close()
+		if err != nil {
+			t.Fatalf("%s: Execute: %v", name, err)
+		}
+
+		if len(bodies) != tc.wantCalls {
+			t.Fatalf("%s: calls=%d want %d", name, len(bodies), tc.wantCalls)
+		}
+		if tc.wantCalls > 0 && !strings.Contains(bodies[0], "必须译为「VRチャット」") {
+			t.Errorf("%s: re-translation prompt lacks the term requirement: %s", name, bodies[0])
+		}
+		translations := out.Data["translations"].(map[string]string)
+		if translations["ja"] != tc.wantJA || translations["en"] != tc.translations["en"] {
+			t.Errorf("%s: translations=%v", name, translations)
+		}
+		violations, _ := out.Metadata["glossary_violations"].([]GlossaryViolation)
+		if len(violations) != tc.wantViolations {
+			t.Fatalf("%s: violations=%v want %d", name, violations, tc.wantViolations)
+		}
+		if tc.wantViolations > 0 && violations[0] != (GlossaryViolation{Term: "VRChat", Language: "ja", Expected: "VRチャット"}) {
+			t.Errorf("%s: violation=%+v", name, violations[0])
+		}
+		if err := ValidateSchema(NewGlossaryCheckTool(nil).OutputSchema(), out.Data); err != nil {
+			t.Errorf("%s: output does not match schema: %v", name, err)
+		}
+	}
+}
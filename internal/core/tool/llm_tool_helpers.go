@@ -1,13 +1,21 @@
 package tool
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
 )
 
 const submitResultFunctionName = "submit_result"
 
+// maxRepairAttempts bounds the follow-up requests sent when submit_result arguments
+// do not match the tool's output schema.
+const maxRepairAttempts = 1
+
 func submitResultTools(outputSchema map[string]interface{}, description string) []llm.ToolDefinition {
 	if outputSchema == nil {
 		return nil
@@ -24,6 +32,76 @@ func submitResultTools(outputSchema map[string]interface{}, description string)
 	}
 }
 
+// processToolCall sends req and, when it carries tools, decodes the submit_result arguments
+// into out after validating them against schema. Invalid arguments are sent back to the model
+// with the validation error for up to maxRepairAttempts retries; if they are still invalid a
+// parsing error is returned. A reply without submit_result arguments leaves out untouched so
+// callers can fall back to parsing the content.
+func processToolCall(ctx context.Context, m *llm.Manager, req *llm.LLMRequest, schema map[string]interface{}, out interface{}) (*llm.LLMResponse, error) {
+	resp, err := m.ProcessWithTimeout(ctx, req)
+	if err != nil || len(req.Tools) == 0 {
+		return resp, err
+	}
+	args := submitResultArguments(resp)
+	if args == "" {
+		return resp, nil
+	}
+
+	argErr := decodeToolArguments(args, schema, out)
+	for attempt := 0; argErr != nil && attempt < maxRepairAttempts; attempt++ {
+		repairReq := *req
+		repairReq.UserPrompt = req.UserPrompt + repairPrompt(args, argErr)
+		repaired, err := m.ProcessWithTimeout(ctx, &repairReq)
+		if err != nil {
+			return nil, err
+		}
+		repaired.PromptTokens += resp.PromptTokens
+		repaired.TotalTokens += resp.TotalTokens
+		resp = repaired
+
+		if next := submitResultArguments(resp); next != "" {
+			args = next
+			argErr = decodeToolArguments(args, schema, out)
+		} else {
+			argErr = fmt.Errorf("%s was not called", submitResultFunctionName)
+		}
+	}
+	if argErr != nil {
+		return resp, coreerrors.NewParsingError(
+			fmt.Sprintf("%s arguments do not match schema: %v", submitResultFunctionName, argErr), argErr)
+	}
+	return resp, nil
+}
+
+func submitResultArguments(resp *llm.LLMResponse) string {
+	if resp == nil {
+		return ""
+	}
+	for _, call := range resp.ToolCalls {
+		if call.Function.Name == submitResultFunctionName {
+			return strings.TrimSpace(call.Function.Arguments)
+		}
+	}
+	return ""
+}
+
+// decodeToolArguments validates args against schema and only then unmarshals them into out.
+func decodeToolArguments(args string, schema map[string]interface{}, out interface{}) error {
+	var value interface{}
+	if err := json.Unmarshal([]byte(args), &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := ValidateSchema(schema, value); err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(args), out)
+}
+
+func repairPrompt(args string, err error) string {
+	return fmt.Sprintf("\n\n上一次 %s 调用的参数不符合要求：%v\n上一次的参数：%s\n请修正后重新调用 %s，参数必须符合其 JSON Schema。",
+		submitResultFunctionName, err, args, submitResultFunctionName)
+}
+
 func bestEffortRawResponse(resp *llm.LLMResponse) string {
 	if resp == nil {
 		return ""
@@ -39,6 +117,19 @@ func bestEffortRawResponse(resp *llm.LLMResponse) string {
 	return ""
 }
 
+// passthroughTranslations maps every target language of input to the untranslated text
+// and publishes them as partial results.
+func passthroughTranslations(ctx context.Context, input Input) map[string]string {
+	text, _ := input.Data["text"].(string)
+	langs, _ := coerceStringSlice(input.Data["target_languages"])
+	translations := make(map[string]string, len(langs))
+	for _, lang := range langs {
+		translations[lang] = text
+	}
+	publishTranslations(ctx, langs, translations)
+	return translations
+}
+
 func coerceStringSlice(v interface{}) ([]string, bool) {
 	switch vv := v.(type) {
 	case []string:
@@ -7,7 +7,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
@@ -318,3 +324,499 @@ func TestCorrectTranslateTool_ToolCalling_ParseToolCall(t *testing.T) {
 		t.Fatalf("en=%q want hello", got)
 	}
 }
+
+func TestAudioLLMTool_SendsInputAudio(t *testing.T) {
+	t.Parallel()
+
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+
+		if !bytes.Contains(body, []byte(`"input_audio"`)) {
+			t.Fatalf("expected input_audio content part, got: %s", string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{
+					"message": map[string]any{
+						"content": nil,
+						"tool_calls": []map[string]any{
+							{
+								"id":   "call_1",
+								"type": "function",
+								"function": map[string]any{
+									"name":      submitResultFunctionName,
+									"arguments": "{\"transcription\":\"你好\",\"language\":\"zh\",\"translations\":{\"en\":\"Hello\",\"fr\":\"Bonjour\"}}",
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	m, err := llm.NewManager(config.BackendsConfig{
+		LoadBalancer: config.LoadBalancerConfig{Strategy: "round_robin"},
+		Providers: []config.BackendProvider{
+			{
+				Name:  "audio",
+				Type:  "openai",
+				URL:   llmSrv.URL,
+				Model: "qwen-audio",
+				Audio: config.BackendAudioConfig{Enabled: true, Formats: []string{"wav"}},
+			},
+		},
+	}, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("llm.NewManager: %v", err)
+	}
+
+	tool := NewAudioLLMTool(m, newTestPromptEngine(t), true, false)
+	out, err := tool.Execute(context.Background(), Input{
+		Data: map[string]interface{}{
+			"audio":            []byte{0x00, 0x01},
+			"format":           "wav",
+			"target_languages": []string{"en"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got, _ := out.Data["text"].(string); got != "你好" {
+		t.Fatalf("text=%q want 你好", got)
+	}
+	if got, _ := out.Data["language"].(string); got != "zh" {
+		t.Fatalf("language=%q want zh", got)
+	}
+	translations, _ := out.Data["translations"].(map[string]string)
+	if len(translations) != 1 || translations["en"] != "Hello" {
+		t.Fatalf("translations=%v want only en", translations)
+	}
+}
+
+func TestTranslateTool_PartialListener_SingleCall(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": "```json\n{\"translations\":{\"en\":\"hello\",\"ja\":\"こんにちは\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	var mu sync.Mutex
+	published := map[string]string{}
+	ctx := WithPartialListener(context.Background(), func(r PartialResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		published[r.Language] = r.Text
+	})
+
+	tool := NewTranslateTool(newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), false, false)
+	out, err := tool.Execute(ctx, Input{
+		Data: map[string]any{
+			"text":             "你好",
+			"target_languages": []string{"en", "ja"},
+		},
+		Context: &PipelineContext{OriginalRequest: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("llm calls=%d want 1 without fan_out", n)
+	}
+	if _, ok := out.Metadata["translation_mode"]; ok {
+		t.Fatalf("unexpected translation_mode: %v", out.Metadata["translation_mode"])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(published) != 2 || published["en"] != "hello" || published["ja"] != "こんにちは" {
+		t.Fatalf("partials=%v want en and ja from the single call", published)
+	}
+}
+
+func TestTranslateTool_FanOut_PublishesGroupsAsTheyFinish(t *testing.T) {
+	t.Parallel()
+
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+
+		content := "```json\n{\"translations\":{\"ja\":\"こんにちは\"}}\n```"
+		if !bytes.Contains(body, []byte("日文")) {
+			// English finishes last, so its partial must arrive after Japanese.
+			time.Sleep(200 * time.Millisecond)
+			content = "```json\n{\"translations\":{\"en\":\"hello\"}}\n```"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": content}},
+			},
+			"usage": map[string]any{
+				"prompt_tokens": 1,
+				"total_tokens":  2,
+			},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	var mu sync.Mutex
+	var partials []PartialResult
+	ctx := WithPartialListener(context.Background(), func(r PartialResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		partials = append(partials, r)
+	})
+
+	tool := NewTranslateTool(newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), false, false).
+		WithTranslationConfig(config.TranslationConfig{FanOut: true, GroupSize: 1})
+	out, err := tool.Execute(ctx, Input{
+		Data: map[string]any{
+			"text":             "你好",
+			"target_languages": []string{"en", "ja"},
+		},
+		Context: &PipelineContext{OriginalRequest: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	translations := out.Data["translations"].(map[string]string)
+	if translations["en"] != "hello" || translations["ja"] != "こんにちは" {
+		t.Fatalf("translations=%v", translations)
+	}
+	if out.Metadata["translation_mode"] != "fan_out" || out.Metadata["total_tokens"] != 4 {
+		t.Fatalf("metadata=%v", out.Metadata)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(partials) != 2 || partials[0].Language != "ja" || partials[1].Language != "en" || partials[1].Kind != PartialTranslation {
+		t.Fatalf("partials=%+v want ja then en", partials)
+	}
+}
+
+func TestTranslateTool_FanOut_RetriesMissingLanguages(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var requested [][]string
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+
+		var langs []string
+		for code, name := range map[string]string{"en": "英文", "ja": "日文", "zh": "中文"} {
+			if bytes.Contains(body, []byte(name)) {
+				langs = append(langs, code)
+			}
+		}
+		sort.Strings(langs)
+		mu.Lock()
+		requested = append(requested, langs)
+		mu.Unlock()
+
+		// en+zh 组的首次回复漏掉 zh，需要单独重试
+		content := map[string]string{}
+		for _, code := range langs {
+			if !(code == "zh" && len(langs) > 1) {
+				content[code] = "t-" + code
+			}
+		}
+		payload, _ := json.Marshal(map[string]any{"translations": content})
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": "```json\n" + string(payload) + "\n```"}},
+			},
+			"usage": map[string]any{"prompt_tokens": 1, "total_tokens": 2},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	tool := NewTranslateTool(newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), false, false).
+		WithTranslationConfig(config.TranslationConfig{FanOut: true, GroupSize: 2, Groups: [][]string{{"ja"}}, RetryMissing: 1})
+	out, err := tool.Execute(context.Background(), Input{
+		Data: map[string]any{
+			"text":             "hello",
+			"target_languages": []string{"en", "ja", "zh"},
+		},
+		Context: &PipelineContext{OriginalRequest: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	translations := out.Data["translations"].(map[string]string)
+	if len(translations) != 3 || translations["zh"] != "t-zh" || translations["ja"] != "t-ja" || translations["en"] != "t-en" {
+		t.Fatalf("translations=%v", translations)
+	}
+	if out.Metadata["translation_mode"] != "fan_out" || out.Metadata["total_tokens"] != 6 {
+		t.Fatalf("metadata=%v", out.Metadata)
+	}
+	if _, ok := out.Metadata["warnings"]; ok {
+		t.Fatalf("unexpected warnings: %v", out.Metadata["warnings"])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Slice(requested[:2], func(i, j int) bool { return len(requested[i]) < len(requested[j]) })
+	want := [][]string{{"ja"}, {"en", "zh"}, {"zh"}}
+	if !reflect.DeepEqual(requested, want) {
+		t.Fatalf("requested groups=%v want %v", requested, want)
+	}
+}
+
+func TestTranslateTool_FanOut_MaxParallel(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	inFlight, peak, calls := 0, 0, 0
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		mu.Lock()
+		inFlight++
+		calls++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(30 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		content := map[string]string{}
+		for code, name := range map[string]string{"en": "英文", "ja": "日文", "zh": "中文"} {
+			if bytes.Contains(body, []byte(name)) {
+				content[code] = "t-" + code
+			}
+		}
+		payload, _ := json.Marshal(map[string]any{"translations": content})
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": "```json\n" + string(payload) + "\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	tool := NewTranslateTool(newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), false, false).
+		WithTranslationConfig(config.TranslationConfig{FanOut: true, GroupSize: 1, MaxParallel: 1})
+	out, err := tool.Execute(context.Background(), Input{
+		Data:    map[string]any{"text": "hello", "target_languages": []string{"en", "ja", "zh"}},
+		Context: &PipelineContext{OriginalRequest: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if translations := out.Data["translations"].(map[string]string); len(translations) != 3 {
+		t.Fatalf("translations=%v", translations)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 3 || peak != 1 {
+		t.Fatalf("calls=%d peak concurrency=%d want 3 calls, at most 1 at a time", calls, peak)
+	}
+}
+
+func TestTranslateTool_ReportsMissingLanguages(t *testing.T) {
+	t.Parallel()
+
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]any{"content": "```json\n{\"translations\":{\"en\":\"hello\"}}\n```"}},
+			},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	tool := NewTranslateTool(newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), false, false)
+	out, err := tool.Execute(context.Background(), Input{
+		Data: map[string]any{
+			"text":             "你好",
+			"target_languages": []string{"en", "ja"},
+		},
+		Context: &PipelineContext{OriginalRequest: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !reflect.DeepEqual(out.Metadata["missing_languages"], []string{"ja"}) {
+		t.Fatalf("missing_languages=%v", out.Metadata["missing_languages"])
+	}
+	if warnings, ok := out.Metadata["warnings"].([]string); !ok || len(warnings) != 1 || !strings.Contains(warnings[0], "ja") {
+		t.Fatalf("warnings=%v", out.Metadata["warnings"])
+	}
+	if _, ok := out.Metadata["failed_languages"]; ok {
+		t.Fatalf("no call failed, got failed_languages=%v", out.Metadata["failed_languages"])
+	}
+}
+
+func TestCorrectTranslateTool_MissingLanguages(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(calls *int, mu *sync.Mutex) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			_ = r.Body.Close()
+			mu.Lock()
+			*calls++
+			first := *calls == 1
+			mu.Unlock()
+
+			// 合并请求漏掉 ja，之后的翻译请求只补 ja
+			content := `{"translations":{"ja":"こんにちは"}}`
+			if first {
+				content = `{"corrected_text":"你好！","translations":{"en":"hello"}}`
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{
+					{"message": map[string]any{"content": "```json\n" + content + "\n```"}},
+				},
+				"usage": map[string]any{"prompt_tokens": 1, "total_tokens": 2},
+			})
+		}))
+	}
+	input := Input{
+		Data:    map[string]any{"text": "你好", "target_languages": []string{"en", "ja"}},
+		Context: &PipelineContext{OriginalRequest: map[string]any{}},
+	}
+
+	var mu sync.Mutex
+	calls := 0
+	srv := newServer(&calls, &mu)
+	t.Cleanup(srv.Close)
+	out, err := NewCorrectTranslateTool(newTestLLMManager(t, srv.URL), newTestPromptEngine(t), false, false).
+		Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if translations := out.Data["translations"].(map[string]string); len(translations) != 1 || calls != 1 {
+		t.Fatalf("translations=%v calls=%d want only en without retry", translations, calls)
+	}
+	if !reflect.DeepEqual(out.Metadata["missing_languages"], []string{"ja"}) {
+		t.Fatalf("missing_languages=%v", out.Metadata["missing_languages"])
+	}
+	if warnings, ok := out.Metadata["warnings"].([]string); !ok || len(warnings) != 1 || !strings.Contains(warnings[0], "ja") {
+		t.Fatalf("warnings=%v", out.Metadata["warnings"])
+	}
+
+	retryCalls := 0
+	retrySrv := newServer(&retryCalls, &mu)
+	t.Cleanup(retrySrv.Close)
+	out, err = NewCorrectTranslateTool(newTestLLMManager(t, retrySrv.URL), newTestPromptEngine(t), false, false).
+		WithTranslationConfig(config.TranslationConfig{RetryMissing: 1}).
+		Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	translations := out.Data["translations"].(map[string]string)
+	if translations["en"] != "hello" || translations["ja"] != "こんにちは" || retryCalls != 2 {
+		t.Fatalf("translations=%v calls=%d want en and retried ja", translations, retryCalls)
+	}
+	if out.Data["corrected_text"] != "你好！" || out.Metadata["total_tokens"] != 4 {
+		t.Fatalf("data=%v metadata=%v", out.Data, out.Metadata)
+	}
+	if _, ok := out.Metadata["warnings"]; ok {
+		t.Fatalf("unexpected warnings: %v", out.Metadata["warnings"])
+	}
+}
+
+func TestCorrectTool_ToolCalling_RepairsInvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		replies   []string
+		wantText  string
+		wantErr   bool
+		wantCalls int
+	}{
+		"repaired": {
+			replies:   []string{`{"corrected_text":42}`, `{"corrected_text":"你好！"}`},
+			wantText:  "你好！",
+			wantCalls: 2,
+		},
+		"still invalid": {
+			replies:   []string{`{"text":"你好！"}`, `{"corrected_text":["你好！"]}`},
+			wantErr:   true,
+			wantCalls: 2,
+		},
+	}
+	for name, tc := range cases {
+		var mu sync.Mutex
+		var bodies []string
+		llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			_ = r.Body.Close()
+			mu.Lock()
+			bodies = append(bodies, string(body))
+			reply := tc.replies[min(len(bodies), len(tc.replies))-1]
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{
+					"message": map[string]any{
+						"content": nil,
+						"tool_calls": []map[string]any{{
+							"id":       "call_1",
+							"type":     "function",
+							"function": map[string]any{"name": submitResultFunctionName, "arguments": reply},
+						}},
+					},
+				}},
+				"usage": map[string]any{"prompt_tokens": 1, "total_tokens": 2},
+			})
+		}))
+
+		tool := NewCorrectTool(newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), true, false)
+		out, err := tool.Execute(context.Background(), Input{
+			Data:    map[string]any{"text": "你好"},
+			Context: &PipelineContext{OriginalRequest: map[string]any{}},
+		})
+		llmSrv.Close()
+
+		if len(bodies) != tc.wantCalls {
+			t.Fatalf("%s: calls=%d want %d", name, len(bodies), tc.wantCalls)
+		}
+		if !strings.Contains(bodies[1], "参数不符合要求") {
+			t.Errorf("%s: second request is not a repair prompt: %s", name, bodies[1])
+		}
+		if tc.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "do not match schema") {
+				t.Fatalf("%s: expected schema error, got %v", name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Execute: %v", name, err)
+		}
+		if got, _ := out.Data["corrected_text"].(string); got != tc.wantText {
+			t.Errorf("%s: corrected_text=%q want %q", name, got, tc.wantText)
+		}
+		if got := out.Metadata["total_tokens"]; got != 4 {
+			t.Errorf("%s: total_tokens=%v want 4 (both calls)", name, got)
+		}
+	}
+}
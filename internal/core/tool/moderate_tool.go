@@ -0,0 +1,436 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
+)
+
+// DefaultModerationCategories are the classifier labels used when moderation.classifier.categories is unset.
+var DefaultModerationCategories = []string{"harassment", "hate", "sexual", "self_harm", "violence"}
+
+// ModerationMatch is one rule or classifier hit.
+type ModerationMatch struct {
+	Field    string `json:"field"` // text 或 translations.<语言代码>
+	Language string `json:"language,omitempty"`
+	Rule     string `json:"rule"` // 规则名，分类器命中为 classifier
+	Action   string `json:"action"`
+	Match    string `json:"match,omitempty"`    // 命中的原文片段（规则）
+	Category string `json:"category,omitempty"` // 命中的类别（分类器）
+}
+
+// ModerationResult is the moderation section of a response.
+type ModerationResult struct {
+	Flagged bool              `json:"flagged"` // 有任何命中
+	Blocked bool              `json:"blocked"` // 有文本因 block 被清空
+	Matches []ModerationMatch `json:"matches,omitempty"`
+}
+
+// Merge combines the results of several moderate steps.
+func (r ModerationResult) Merge(other ModerationResult) ModerationResult {
+	r.Flagged = r.Flagged || other.Flagged
+	r.Blocked = r.Blocked || other.Blocked
+	r.Matches = append(append([]ModerationMatch(nil), r.Matches...), other.Matches...)
+	return r
+}
+
+type moderationRule struct {
+	name      string
+	languages map[string]bool
+	patterns  []*regexp.Regexp
+	action    string
+}
+
+func (r moderationRule) appliesTo(lang string) bool {
+	return len(r.languages) == 0 || lang == "" || r.languages[strings.ToLower(lang)]
+}
+
+// ModerateTool checks a text and / or its translations against word and regex rules and,
+// optionally, an LLM classifier. Matches are flagged, masked or blocked (the whole text is
+// cleared) according to the rule's action; every match is listed in the moderation output.
+// Rules limited to languages apply to the text only when its language is unknown or listed.
+type ModerateTool struct {
+	rules      []moderationRule
+	maskChar   string
+	classifier config.ModerationClassifierConfig
+
+	llmManager         *llm.Manager
+	promptEngine       *prompt.Engine
+	toolCallingEnabled bool
+}
+
+// NewModerateTool compiles the configured rules. The LLM manager and prompt engine are only
+// used when the classifier is enabled.
+func NewModerateTool(cfg config.ModerationConfig, llmManager *llm.Manager, promptEngine *prompt.Engine, toolCallingEnabled bool) (*ModerateTool, error) {
+	t := &ModerateTool{
+		maskChar:           cfg.MaskChar,
+		classifier:         cfg.Classifier,
+		llmManager:         llmManager,
+		promptEngine:       promptEngine,
+		toolCallingEnabled: toolCallingEnabled,
+	}
+	if t.maskChar == "" {
+		t.maskChar = "*"
+	}
+	if t.classifier.Action == "" {
+		t.classifier.Action = config.ModerationFlag
+	}
+	if len(t.classifier.Categories) == 0 {
+		t.classifier.Categories = DefaultModerationCategories
+	}
+
+	for i, rc := range cfg.Rules {
+		rule := moderationRule{name: rc.Name, action: rc.Action}
+		if rule.name == "" {
+			rule.name = fmt.Sprintf("rule_%d", i+1)
+		}
+		switch rule.action {
+		case config.ModerationFlag, config.ModerationMask, config.ModerationBlock:
+		default:
+			return nil, fmt.Errorf("moderation rule %s: unsupported action %q", rule.name, rc.Action)
+		}
+		if len(rc.Languages) > 0 {
+			rule.languages = make(map[string]bool, len(rc.Languages))
+			for _, lang := range rc.Languages {
+				rule.languages[strings.ToLower(strings.TrimSpace(lang))] = true
+			}
+		}
+		for _, word := range rc.Words {
+			if word = strings.TrimSpace(word); word != "" {
+				rule.patterns = append(rule.patterns, wordPattern(word))
+			}
+		}
+		for _, pattern := range rc.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("moderation rule %s: invalid pattern %q: %w", rule.name, pattern, err)
+			}
+			rule.patterns = append(rule.patterns, re)
+		}
+		t.rules = append(t.rules, rule)
+	}
+	return t, nil
+}
+
+// wordPattern matches word case-insensitively, on word boundaries where the word starts or
+// ends with an ASCII letter or digit (scripts without spaces match anywhere).
+func wordPattern(word string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(word)
+	if first, _ := utf8.DecodeRuneInString(word); isASCIIWordRune(first) {
+		expr = `\b` + expr
+	}
+	if last, _ := utf8.DecodeLastRuneInString(word); isASCIIWordRune(last) {
+		expr += `\b`
+	}
+	return regexp.MustCompile("(?i)" + expr)
+}
+
+func isASCIIWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+func (t *ModerateTool) Name() string {
+	return "moderate"
+}
+
+func (t *ModerateTool) Description() string {
+	return "Flag, mask or block harmful content in text and translations"
+}
+
+func (t *ModerateTool) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"text": map[string]interface{}{
+				"type":        "string",
+				"description": "Text to moderate",
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional language code of text, used to select rules",
+			},
+			"translations": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Translations to moderate, keyed by language code",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+		},
+	}
+}
+
+func (t *ModerateTool) OutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"text": map[string]string{"type": "string"},
+			"translations": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+			"blocked": map[string]string{"type": "boolean"},
+			"moderation": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"flagged": map[string]string{"type": "boolean"},
+					"blocked": map[string]string{"type": "boolean"},
+					"matches": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type":     "object",
+							"required": []string{"field", "rule", "action"},
+						},
+					},
+				},
+				"required": []string{"flagged", "blocked"},
+			},
+		},
+		"required": []string{"blocked", "moderation"},
+	}
+}
+
+func (t *ModerateTool) Validate(input Input) error {
+	if input.Data == nil {
+		return coreerrors.NewValidationError("input data is required", nil)
+	}
+	textAny, hasText := input.Data["text"]
+	if hasText {
+		if _, ok := textAny.(string); !ok {
+			return coreerrors.NewValidationError("text must be a string", nil)
+		}
+	}
+	translationsAny, hasTranslations := input.Data["translations"]
+	if hasTranslations {
+		if _, ok := coerceStringMap(translationsAny); !ok {
+			return coreerrors.NewValidationError("translations must be an object of strings", nil)
+		}
+	}
+	if !hasText && !hasTranslations {
+		return coreerrors.NewValidationError("text or translations is required", nil)
+	}
+	return nil
+}
+
+// Passthrough returns the input unmoderated.
+func (t *ModerateTool) Passthrough(ctx context.Context, input Input) Output {
+	data := map[string]interface{}{
+		"blocked":    false,
+		"moderation": ModerationResult{},
+	}
+	if text, ok := input.Data["text"].(string); ok {
+		data["text"] = text
+	}
+	if translations, ok := coerceStringMap(input.Data["translations"]); ok {
+		data["translations"] = translations
+	}
+	return Output{Data: data}
+}
+
+// moderationField is one text under moderation.
+type moderationField struct {
+	id       string
+	language string
+	original string
+	value    string
+	blocked  bool
+}
+
+func (t *ModerateTool) Execute(ctx context.Context, input Input) (Output, error) {
+	if err := t.Validate(input); err != nil {
+		return Output{}, err
+	}
+
+	var fields []*moderationField
+	text, hasText := input.Data["text"].(string)
+	if hasText {
+		lang, _ := input.Data["language"].(string)
+		fields = append(fields, &moderationField{id: "text", language: strings.TrimSpace(lang), original: text, value: text})
+	}
+	translations, hasTranslations := coerceStringMap(input.Data["translations"])
+	langs := make([]string, 0, len(translations))
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		fields = append(fields, &moderationField{id: "translations." + lang, language: lang, original: translations[lang], value: translations[lang]})
+	}
+
+	result := ModerationResult{}
+	for _, f := range fields {
+		result.Matches = append(result.Matches, t.applyRules(f)...)
+	}
+
+	var metadata map[string]interface{}
+	if t.classifier.Enabled {
+		matches, resp, err := t.classify(ctx, input, fields)
+		if err != nil {
+			return Output{}, err
+		}
+		result.Matches = append(result.Matches, matches...)
+		if resp != nil {
+			metadata = map[string]interface{}{
+				"moderation_backend":      resp.Metadata["backend"],
+				"moderation_model":        resp.Model,
+				"moderation_total_tokens": resp.TotalTokens,
+			}
+		}
+	}
+
+	data := map[string]interface{}{}
+	moderated := make(map[string]string, len(translations))
+	for _, f := range fields {
+		if f.blocked {
+			f.value = ""
+			result.Blocked = true
+		}
+		if f.id == "text" {
+			data["text"] = f.value
+		} else {
+			moderated[f.language] = f.value
+		}
+	}
+	if hasTranslations {
+		data["translations"] = moderated
+	}
+	result.Flagged = len(result.Matches) > 0
+	data["blocked"] = result.Blocked
+	data["moderation"] = result
+
+	return Output{Data: data, Metadata: metadata}, nil
+}
+
+// applyRules records every rule match in f, masks mask matches and marks f blocked on a block match.
+func (t *ModerateTool) applyRules(f *moderationField) []ModerationMatch {
+	var matches []ModerationMatch
+	for _, rule := range t.rules {
+		if !rule.appliesTo(f.language) {
+			continue
+		}
+		for _, re := range rule.patterns {
+			found := re.FindAllString(f.value, -1)
+			if len(found) == 0 {
+				continue
+			}
+			for _, m := range found {
+				matches = append(matches, ModerationMatch{Field: f.id, Language: f.language, Rule: rule.name, Action: rule.action, Match: m})
+			}
+			switch rule.action {
+			case config.ModerationMask:
+				f.value = re.ReplaceAllStringFunc(f.value, func(m string) string {
+					return strings.Repeat(t.maskChar, utf8.RuneCountInString(m))
+				})
+			case config.ModerationBlock:
+				f.blocked = true
+			}
+		}
+	}
+	return matches
+}
+
+// classify asks the LLM which fields contain content of the configured categories.
+func (t *ModerateTool) classify(ctx context.Context, input Input, fields []*moderationField) ([]ModerationMatch, *llm.LLMResponse, error) {
+	if t.llmManager == nil || t.promptEngine == nil {
+		return nil, nil, coreerrors.NewInternalError("moderation classifier requires an llm manager and prompt engine", nil)
+	}
+
+	items := make([]prompt.ModerationItem, 0, len(fields))
+	byID := make(map[string]*moderationField, len(fields))
+	for _, f := range fields {
+		if strings.TrimSpace(f.original) == "" {
+			continue
+		}
+		items = append(items, prompt.ModerationItem{ID: f.id, Text: f.original})
+		byID[f.id] = f
+	}
+	if len(items) == 0 {
+		return nil, nil, nil
+	}
+
+	promptObj, err := t.promptEngine.BuildModerationPrompt(ctx, items, t.classifier.Categories)
+	if err != nil {
+		return nil, nil, err
+	}
+	schema := classifierSchema()
+	llmReq := &llm.LLMRequest{SystemPrompt: promptObj.System, UserPrompt: promptObj.User}
+	if input.Context != nil && input.Context.OriginalRequest != nil {
+		if opts, ok := input.Context.OriginalRequest["options"].(map[string]interface{}); ok {
+			llmReq.Options = opts
+		}
+	}
+	if t.toolCallingEnabled {
+		llmReq.SystemPrompt += "\n\n请不要输出解释或思考，仅通过工具调用返回结果。"
+		llmReq.Tools = submitResultTools(schema, "Submit moderation labels")
+		llmReq.ToolChoice = &llm.ToolChoice{Mode: llm.ToolChoiceRequired}
+	}
+
+	var labels struct {
+		Flagged map[string][]string `json:"flagged"`
+	}
+	resp, err := processToolCall(ctx, t.llmManager, llmReq, schema, &labels)
+	if err != nil {
+		return nil, nil, err
+	}
+	if labels.Flagged == nil {
+		block, ok := prompt.ExtractJSONBlock(resp.Content)
+		if !ok {
+			return nil, nil, coreerrors.NewParsingError("moderation classifier returned no labels", nil)
+		}
+		if err := decodeToolArguments(string(block), schema, &labels); err != nil {
+			return nil, nil, coreerrors.NewParsingError("moderation classifier returned invalid labels", err)
+		}
+	}
+
+	allowed := make(map[string]bool, len(t.classifier.Categories))
+	for _, c := range t.classifier.Categories {
+		allowed[c] = true
+	}
+	ids := make([]string, 0, len(labels.Flagged))
+	for id := range labels.Flagged {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var matches []ModerationMatch
+	for _, id := range ids {
+		f, ok := byID[id]
+		if !ok {
+			continue
+		}
+		for _, category := range labels.Flagged[id] {
+			if !allowed[category] {
+				continue
+			}
+			matches = append(matches, ModerationMatch{Field: f.id, Language: f.language, Rule: "classifier", Action: t.classifier.Action, Category: category})
+			if t.classifier.Action == config.ModerationBlock {
+				f.blocked = true
+			}
+		}
+	}
+	return matches, resp, nil
+}
+
+func classifierSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"flagged": map[string]interface{}{
+				"type":        "object",
+				"description": "Categories keyed by the id of each flagged text",
+				"additionalProperties": map[string]interface{}{
+					"type":  "array",
+					"items": map[string]string{"type": "string"},
+				},
+			},
+		},
+		"required": []string{"flagged"},
+	}
+}
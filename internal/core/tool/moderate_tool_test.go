@@ -0,0 +1,127 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+)
+
+func TestModerateTool_Rules(t *testing.T) {
+	t.Parallel()
+
+	moderate, err := NewModerateTool(config.ModerationConfig{
+		Rules: []config.ModerationRule{
+			{Name: "slurs", Words: []string{"jerk", "笨蛋"}, Action: config.ModerationMask},
+			{Name: "doxxing", Patterns: []string{`\d{3}-\d{4}-\d{4}`}, Action: config.ModerationBlock},
+			{Name: "ja_only", Languages: []string{"ja"}, Words: []string{"バカ"}, Action: config.ModerationFlag},
+		},
+	}, nil, nil, false)
+	if err != nil {
+		t.Fatalf("NewModerateTool: %v", err)
+	}
+
+	out, err := moderate.Execute(context.Background(), Input{Data: map[string]interface{}{
+		"text":     "You JERK, jerky is fine, 笨蛋",
+		"language": "en",
+		"translations": map[string]string{
+			"ja": "バカ、電話は 090-1234-5678",
+			"zh": "バカ",
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if got := out.Data["text"]; got != "You ****, jerky is fine, **" {
+		t.Errorf("text=%q", got)
+	}
+	translations := out.Data["translations"].(map[string]string)
+	if translations["ja"] != "" || translations["zh"] != "バカ" {
+		t.Errorf("translations=%v want ja blocked and zh untouched", translations)
+	}
+	result := out.Data["moderation"].(ModerationResult)
+	if !result.Flagged || !result.Blocked || out.Data["blocked"] != true {
+		t.Errorf("result=%+v blocked=%v", result, out.Data["blocked"])
+	}
+	want := []ModerationMatch{
+		{Field: "text", Language: "en", Rule: "slurs", Action: "mask", Match: "JERK"},
+		{Field: "text", Language: "en", Rule: "slurs", Action: "mask", Match: "笨蛋"},
+		{Field: "translations.ja", Language: "ja", Rule: "doxxing", Action: "block", Match: "090-1234-5678"},
+		{Field: "translations.ja", Language: "ja", Rule: "ja_only", Action: "flag", Match: "バカ"},
+	}
+	if len(result.Matches) != len(want) {
+		t.Fatalf("matches=%+v", result.Matches)
+	}
+	for i := range want {
+		if result.Matches[i] != want[i] {
+			t.Errorf("match[%d]=%+v want %+v", i, result.Matches[i], want[i])
+		}
+	}
+	if err := ValidateSchema(moderate.OutputSchema(), out.Data); err != nil {
+		t.Errorf("output does not match schema: %v", err)
+	}
+
+	if _, err := NewModerateTool(config.ModerationConfig{Rules: []config.ModerationRule{{Action: "delete"}}}, nil, nil, false); err == nil {
+		t.Error("expected error for unsupported action")
+	}
+}
+
+func TestModerateTool_Classifier(t *testing.T) {
+	t.Parallel()
+
+	llmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{
+				"message": map[string]any{
+					"content": nil,
+					"tool_calls": []map[string]any{{
+						"id":   "call_1",
+						"type": "function",
+						"function": map[string]any{
+							"name":      submitResultFunctionName,
+							"arguments": `{"flagged":{"translations.en":["harassment","spam"],"unknown":["hate"]}}`,
+						},
+					}},
+				},
+			}},
+			"usage": map[string]any{"prompt_tokens": 1, "total_tokens": 2},
+		})
+	}))
+	t.Cleanup(llmSrv.Close)
+
+	moderate, err := NewModerateTool(config.ModerationConfig{
+		Classifier: config.ModerationClassifierConfig{Enabled: true, Action: config.ModerationBlock},
+	}, newTestLLMManager(t, llmSrv.URL), newTestPromptEngine(t), true)
+	if err != nil {
+		t.Fatalf("NewModerateTool: %v", err)
+	}
+
+	out, err := moderate.Execute(context.Background(), Input{
+		Data:    map[string]interface{}{"translations": map[string]string{"en": "get lost", "ja": "こんにちは"}},
+		Context: &PipelineContext{OriginalRequest: map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	translations := out.Data["translations"].(map[string]string)
+	if translations["en"] != "" || translations["ja"] != "こんにちは" {
+		t.Errorf("translations=%v", translations)
+	}
+	if _, ok := out.Data["text"]; ok {
+		t.Error("text should be absent when only translations were moderated")
+	}
+	result := out.Data["moderation"].(ModerationResult)
+	want := ModerationMatch{Field: "translations.en", Language: "en", Rule: "classifier", Action: "block", Category: "harassment"}
+	if len(result.Matches) != 1 || result.Matches[0] != want || !result.Blocked {
+		t.Fatalf("result=%+v", result)
+	}
+	if got := out.Metadata["moderation_total_tokens"]; got != 2 {
+		t.Errorf("moderation_total_tokens=%v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package tool
+
+import "context"
+
+// Partial result kinds published while a pipeline is still running.
+const (
+	PartialCorrectedText = "corrected_text"
+	PartialTranslation   = "translation"
+)
+
+// PartialResult is an intermediate result, e.g. one finished target language.
+type PartialResult struct {
+	Kind     string
+	Language string // 仅 translation
+	Text     string
+}
+
+// PartialListener receives partial results. It may be called from several goroutines at once.
+type PartialListener func(PartialResult)
+
+type partialListenerKey struct{}
+
+// WithPartialListener returns a context whose tools report partial results to fn.
+func WithPartialListener(ctx context.Context, fn PartialListener) context.Context {
+	return context.WithValue(ctx, partialListenerKey{}, fn)
+}
+
+// HasPartialListener reports whether the caller streams partial results.
+func HasPartialListener(ctx context.Context) bool {
+	fn, _ := ctx.Value(partialListenerKey{}).(PartialListener)
+	return fn != nil
+}
+
+// PublishPartial sends r to the context's listener, if any.
+func PublishPartial(ctx context.Context, r PartialResult) {
+	if fn, _ := ctx.Value(partialListenerKey{}).(PartialListener); fn != nil {
+		fn(r)
+	}
+}
+
+// publishTranslations publishes translations in target-language order.
+func publishTranslations(ctx context.Context, targetLangs []string, translations map[string]string) {
+	for _, code := range targetLangs {
+		if v, ok := translations[code]; ok {
+			PublishPartial(ctx, PartialResult{Kind: PartialTranslation, Language: code, Text: v})
+		}
+	}
+}
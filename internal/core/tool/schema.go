@@ -0,0 +1,210 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaViolation is one mismatch between a value and a JSON schema.
+type SchemaViolation struct {
+	Path    string // e.g. "$.translations.en"
+	Message string
+}
+
+// SchemaError lists every violation found by ValidateSchema.
+type SchemaError []SchemaViolation
+
+func (e SchemaError) Error() string {
+	parts := make([]string, len(e))
+	for i, v := range e {
+		parts[i] = v.Path + ": " + v.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ValidateSchema checks value against a JSON schema and returns a SchemaError listing all
+// violations, or nil. Only the subset of JSON Schema used by tool schemas is supported:
+// type, properties, required, additionalProperties, items, enum, minLength, maxLength,
+// minItems, maxItems, minimum and maximum. Unknown keywords are ignored.
+// Both schema and value may use Go types (map[string]string, []string, ...); they are
+// normalized through JSON first. A nil schema accepts any value.
+func ValidateSchema(schema map[string]interface{}, value interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	var s, v interface{}
+	if err := normalizeJSON(schema, &s); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := normalizeJSON(value, &v); err != nil {
+		return SchemaError{{Path: "$", Message: fmt.Sprintf("value is not JSON-encodable: %v", err)}}
+	}
+
+	var violations SchemaError
+	validateNode(s, v, "$", &violations)
+	if len(violations) > 0 {
+		return violations
+	}
+	return nil
+}
+
+func normalizeJSON(in interface{}, out *interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func validateNode(schemaAny, value interface{}, path string, violations *SchemaError) {
+	schema, ok := schemaAny.(map[string]interface{})
+	if !ok {
+		// true / 缺省表示任意值
+		return
+	}
+	add := func(format string, args ...interface{}) {
+		*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if typ, ok := schema["type"]; ok && !matchesType(typ, value) {
+		add("expected %s, got %s", describeType(typ), jsonType(value))
+		return
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !inEnum(enum, value) {
+		add("value %v is not one of %v", value, enum)
+	}
+
+	switch v := value.(type) {
+	case string:
+		n := float64(len([]rune(v)))
+		if min, ok := schema["minLength"].(float64); ok && n < min {
+			add("string shorter than %v characters", min)
+		}
+		if max, ok := schema["maxLength"].(float64); ok && n > max {
+			add("string longer than %v characters", max)
+		}
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && v < min {
+			add("%v is less than minimum %v", v, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && v > max {
+			add("%v is greater than maximum %v", v, max)
+		}
+	case []interface{}:
+		if min, ok := schema["minItems"].(float64); ok && float64(len(v)) < min {
+			add("expected at least %v items, got %d", min, len(v))
+		}
+		if max, ok := schema["maxItems"].(float64); ok && float64(len(v)) > max {
+			add("expected at most %v items, got %d", max, len(v))
+		}
+		if items, ok := schema["items"]; ok {
+			for i, item := range v {
+				validateNode(items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				if name, ok := r.(string); ok {
+					if _, present := v[name]; !present {
+						add("missing required property %q", name)
+					}
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		additional, hasAdditional := schema["additionalProperties"]
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := path + "." + k
+			if propSchema, ok := properties[k]; ok {
+				validateNode(propSchema, v[k], childPath, violations)
+				continue
+			}
+			if !hasAdditional {
+				continue
+			}
+			if allowed, ok := additional.(bool); ok {
+				if !allowed {
+					*violations = append(*violations, SchemaViolation{Path: childPath, Message: "unexpected property"})
+				}
+				continue
+			}
+			validateNode(additional, v[k], childPath, violations)
+		}
+	}
+}
+
+func matchesType(typ interface{}, value interface{}) bool {
+	switch t := typ.(type) {
+	case string:
+		return matchesSingleType(t, value)
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok && matchesSingleType(name, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func matchesSingleType(typ string, value interface{}) bool {
+	switch typ {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == float64(int64(f))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonType(value) == typ
+	}
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func describeType(typ interface{}) string {
+	if list, ok := typ.([]interface{}); ok {
+		names := make([]string, 0, len(list))
+		for _, item := range list {
+			names = append(names, fmt.Sprint(item))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(typ)
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
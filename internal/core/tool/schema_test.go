@@ -0,0 +1,98 @@
+package tool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"corrected_text": map[string]interface{}{"type": "string", "minLength": 1},
+			"translations": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+			"tags":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "maxItems": 2},
+			"mode":  map[string]interface{}{"enum": []string{"flag", "mask"}},
+			"score": map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+			"count": map[string]interface{}{"type": "integer"},
+		},
+		"required":             []string{"corrected_text"},
+		"additionalProperties": false,
+	}
+
+	cases := map[string]struct {
+		value interface{}
+		want  []string // expected violation paths
+	}{
+		"valid go types": {
+			value: map[string]interface{}{
+				"corrected_text": "你好",
+				"translations":   map[string]string{"en": "hello"},
+				"tags":           []string{"a"},
+				"mode":           "mask",
+				"score":          0.5,
+				"count":          3,
+			},
+		},
+		"missing required": {
+			value: map[string]interface{}{"translations": map[string]interface{}{}},
+			want:  []string{"$"},
+		},
+		"wrong nested types": {
+			value: map[string]interface{}{
+				"corrected_text": "ok",
+				"translations":   map[string]interface{}{"en": "hello", "ja": 1},
+				"tags":           []interface{}{"a", true},
+			},
+			want: []string{"$.tags[1]", "$.translations.ja"},
+		},
+		"constraints": {
+			value: map[string]interface{}{
+				"corrected_text": "",
+				"tags":           []string{"a", "b", "c"},
+				"mode":           "block",
+				"score":          2,
+				"count":          1.5,
+			},
+			want: []string{"$.corrected_text", "$.count", "$.mode", "$.score", "$.tags"},
+		},
+		"unexpected property": {
+			value: map[string]interface{}{"corrected_text": "ok", "extra": 1},
+			want:  []string{"$.extra"},
+		},
+		"not an object": {
+			value: "text",
+			want:  []string{"$"},
+		},
+	}
+	for name, tc := range cases {
+		err := ValidateSchema(schema, tc.value)
+		if len(tc.want) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+			continue
+		}
+		var schemaErr SchemaError
+		if !errors.As(err, &schemaErr) {
+			t.Fatalf("%s: expected SchemaError, got %v", name, err)
+		}
+		var paths []string
+		for _, v := range schemaErr {
+			paths = append(paths, v.Path)
+		}
+		if strings.Join(paths, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("%s: paths=%v want %v (%v)", name, paths, tc.want, err)
+		}
+	}
+
+	if err := ValidateSchema(nil, map[string]interface{}{"anything": true}); err != nil {
+		t.Fatalf("nil schema: %v", err)
+	}
+}
@@ -18,3 +18,22 @@ type Tool interface {
 	// Execute runs the tool and returns a structured output.
 	Execute(ctx context.Context, input Input) (Output, error)
 }
+
+// Passthrough is implemented by tools that can describe their output without running,
+// used when a pipeline step is skipped by its condition. Tools that do not implement it
+// pass their input data through unchanged.
+type Passthrough interface {
+	Passthrough(ctx context.Context, input Input) Output
+}
+
+// PassthroughOutput returns the output of t for a skipped step with the given input.
+func PassthroughOutput(ctx context.Context, t Tool, input Input) Output {
+	if p, ok := t.(Passthrough); ok {
+		return p.Passthrough(ctx, input)
+	}
+	data := make(map[string]interface{}, len(input.Data))
+	for k, v := range input.Data {
+		data[k] = v
+	}
+	return Output{Data: data}
+}
@@ -2,18 +2,30 @@ package tool
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
 	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/llm"
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/prompt"
 )
 
+// DefaultTranslationGroupSize is the number of languages per LLM call in fan-out mode
+// when translation.group_size is unset.
+const DefaultTranslationGroupSize = 2
+
+// DefaultTranslationMaxParallel caps concurrent LLM calls of one translation when
+// translation.max_parallel is unset.
+const DefaultTranslationMaxParallel = 4
+
 type TranslateTool struct {
 	llmManager          *llm.Manager
 	promptEngine        *prompt.Engine
 	toolCallingEnabled  bool
 	toolCallingThinking bool
+	translation         config.TranslationConfig
 }
 
 func NewTranslateTool(llmManager *llm.Manager, promptEngine *prompt.Engine, toolCallingEnabled, allowThinking bool) *TranslateTool {
@@ -25,6 +37,12 @@ func NewTranslateTool(llmManager *llm.Manager, promptEngine *prompt.Engine, tool
 	}
 }
 
+// WithTranslationConfig configures fan-out over language groups and retries of missing languages.
+func (t *TranslateTool) WithTranslationConfig(cfg config.TranslationConfig) *TranslateTool {
+	t.translation = cfg
+	return t
+}
+
 func (t *TranslateTool) Name() string {
 	return "translate"
 }
@@ -93,6 +111,11 @@ func (t *TranslateTool) Validate(input Input) error {
 	return nil
 }
 
+// Passthrough returns the input text as the translation for every target language.
+func (t *TranslateTool) Passthrough(ctx context.Context, input Input) Output {
+	return Output{Data: map[string]interface{}{"translations": passthroughTranslations(ctx, input)}}
+}
+
 func (t *TranslateTool) Execute(ctx context.Context, input Input) (Output, error) {
 	if t.llmManager == nil {
 		return Output{}, coreerrors.NewInternalError("llm manager not configured", nil)
@@ -110,6 +133,192 @@ func (t *TranslateTool) Execute(ctx context.Context, input Input) (Output, error
 	sourceLang, _ := input.Data["source_language"].(string)
 	sourceLang = strings.TrimSpace(sourceLang)
 
+	// 各组（未分组时即整个请求）完成后立即推送其语言，流式调用方无需额外拆分请求
+	if t.translation.FanOut && len(targetLangs) > 1 {
+		return t.executeGroups(ctx, input, sourceText, sourceLang, targetLangs, t.languageGroups, "fan_out")
+	}
+	return t.executeGroups(ctx, input, sourceText, sourceLang, targetLangs, oneGroup, "")
+}
+
+// executeGroups translates every group returned by split with its own concurrent LLM call,
+// merges the results and re-requests languages missing from the replies up to
+// translation.RetryMissing times. Languages still missing are reported in metadata as
+// missing_languages and warnings. It fails only if no language was translated and a call failed.
+func (t *TranslateTool) executeGroups(ctx context.Context, input Input, sourceText, sourceLang string, targetLangs []string, split func([]string) [][]string, mode string) (Output, error) {
+	merged := make(map[string]string, len(targetLangs))
+	rawResponses := []string{}
+	errored := map[string]bool{}
+	var firstErr error
+	var backend interface{}
+	var model string
+	promptTokens, totalTokens := 0, 0
+
+	pending := targetLangs
+	for round := 0; len(pending) > 0 && round <= t.translation.RetryMissing; round++ {
+		groups := split(pending)
+		for i, r := range t.translateGroups(ctx, input, sourceText, sourceLang, groups) {
+			if r.err != nil {
+				for _, code := range groups[i] {
+					errored[code] = true
+				}
+				if firstErr == nil {
+					firstErr = r.err
+				}
+				continue
+			}
+			for k, v := range r.translations {
+				merged[k] = v
+			}
+			if raw := bestEffortRawResponse(r.resp); raw != "" {
+				rawResponses = append(rawResponses, raw)
+			}
+			if backend == nil {
+				backend, model = r.resp.Metadata["backend"], r.resp.Model
+			}
+			promptTokens += r.resp.PromptTokens
+			totalTokens += r.resp.TotalTokens
+		}
+		pending = missingLanguages(targetLangs, merged)
+	}
+	if len(merged) == 0 && firstErr != nil {
+		return Output{}, firstErr
+	}
+
+	metadata := map[string]interface{}{
+		"backend":       backend,
+		"model":         model,
+		"prompt_tokens": promptTokens,
+		"total_tokens":  totalTokens,
+	}
+	if mode != "" {
+		metadata["translation_mode"] = mode
+	}
+	reportMissingLanguages(metadata, pending, errored)
+	return Output{
+		Data: map[string]interface{}{
+			"translations": merged,
+			"raw_response": strings.Join(rawResponses, "\n\n"),
+		},
+		Metadata: metadata,
+	}, nil
+}
+
+// reportMissingLanguages records languages without a translation as missing_languages and
+// warnings, and those whose LLM call failed as failed_languages.
+func reportMissingLanguages(metadata map[string]interface{}, missing []string, errored map[string]bool) {
+	if len(missing) == 0 {
+		return
+	}
+	failed := []string{}
+	for _, code := range missing {
+		if errored[code] {
+			failed = append(failed, code)
+		}
+	}
+	if len(failed) > 0 {
+		metadata["failed_languages"] = failed
+	}
+	metadata["missing_languages"] = missing
+	metadata["warnings"] = []string{fmt.Sprintf("no translation returned for: %s", strings.Join(missing, ", "))}
+}
+
+type groupResult struct {
+	translations map[string]string
+	resp         *llm.LLMResponse
+	err          error
+}
+
+// translateGroups runs one LLM call per group, at most translation.MaxParallel at a time,
+// and publishes each group's translations as soon as it finishes.
+func (t *TranslateTool) translateGroups(ctx context.Context, input Input, sourceText, sourceLang string, groups [][]string) []groupResult {
+	limit := t.translation.MaxParallel
+	if limit <= 0 {
+		limit = DefaultTranslationMaxParallel
+	}
+	sem := make(chan struct{}, limit)
+
+	results := make([]groupResult, len(groups))
+	var wg sync.WaitGroup
+	for i, group := range groups {
+		wg.Add(1)
+		go func(i int, group []string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i] = groupResult{err: ctx.Err()}
+				return
+			}
+			translations, resp, err := t.translate(ctx, input, sourceText, sourceLang, group, "")
+			results[i] = groupResult{translations: translations, resp: resp, err: err}
+			if err == nil {
+				publishTranslations(ctx, group, translations)
+			}
+		}(i, group)
+	}
+	wg.Wait()
+	return results
+}
+
+// languageGroups splits langs into the configured groups, in request order; languages not
+// covered by translation.groups are chunked by translation.group_size.
+func (t *TranslateTool) languageGroups(langs []string) [][]string {
+	requested := make(map[string]bool, len(langs))
+	for _, code := range langs {
+		requested[code] = true
+	}
+	assigned := make(map[string]bool, len(langs))
+	var groups [][]string
+	for _, configured := range t.translation.Groups {
+		var group []string
+		for _, code := range configured {
+			code = strings.TrimSpace(code)
+			if requested[code] && !assigned[code] {
+				assigned[code] = true
+				group = append(group, code)
+			}
+		}
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+
+	size := t.translation.GroupSize
+	if size <= 0 {
+		size = DefaultTranslationGroupSize
+	}
+	var rest []string
+	for _, code := range langs {
+		if !assigned[code] {
+			rest = append(rest, code)
+		}
+	}
+	for len(rest) > 0 {
+		n := min(size, len(rest))
+		groups = append(groups, rest[:n])
+		rest = rest[n:]
+	}
+	return groups
+}
+
+func oneGroup(langs []string) [][]string {
+	return [][]string{langs}
+}
+
+func missingLanguages(langs []string, translations map[string]string) []string {
+	var missing []string
+	for _, code := range langs {
+		if _, ok := translations[code]; !ok {
+			missing = append(missing, code)
+		}
+	}
+	return missing
+}
+
+// translate runs one LLM call and returns the translations for targetLangs found in the reply.
+// Non-empty constraints are appended to the user prompt.
+func (t *TranslateTool) translate(ctx context.Context, input Input, sourceText, sourceLang string, targetLangs []string, constraints string) (map[string]string, *llm.LLMResponse, error) {
 	promptObj, err := t.promptEngine.BuildTextPrompt(ctx, prompt.PromptRequest{
 		Task:            prompt.TaskTranslate,
 		SourceLanguage:  sourceLang,
@@ -119,7 +328,7 @@ func (t *TranslateTool) Execute(ctx context.Context, input Input) (Output, error
 		},
 	})
 	if err != nil {
-		return Output{}, err
+		return nil, nil, err
 	}
 
 	systemPrompt := promptObj.System
@@ -127,9 +336,14 @@ func (t *TranslateTool) Execute(ctx context.Context, input Input) (Output, error
 		systemPrompt += "\n\n请不要输出解释或思考，仅通过工具调用返回结果。"
 	}
 
+	userPrompt := promptObj.User
+	if constraints != "" {
+		userPrompt += "\n\n" + constraints
+	}
+
 	llmReq := &llm.LLMRequest{
 		SystemPrompt: systemPrompt,
-		UserPrompt:   promptObj.User,
+		UserPrompt:   userPrompt,
 	}
 	if input.Context != nil && input.Context.OriginalRequest != nil {
 		if opts, ok := input.Context.OriginalRequest["options"].(map[string]interface{}); ok {
@@ -142,23 +356,18 @@ func (t *TranslateTool) Execute(ctx context.Context, input Input) (Output, error
 		llmReq.ToolChoice = &llm.ToolChoice{Mode: llm.ToolChoiceRequired}
 	}
 
-	llmResp, err := t.llmManager.ProcessWithTimeout(ctx, llmReq)
+	var toolResult struct {
+		Translations map[string]string `json:"translations"`
+	}
+	llmResp, err := processToolCall(ctx, t.llmManager, llmReq, t.OutputSchema(), &toolResult)
 	if err != nil {
-		return Output{}, err
+		return nil, nil, err
 	}
 
 	translations := map[string]string{}
-
-	if t.toolCallingEnabled {
-		var toolResult struct {
-			Translations map[string]string `json:"translations"`
-		}
-		if err := llm.ParseToolCallResponse(llmResp, submitResultFunctionName, &toolResult); err == nil {
-			for k, v := range toolResult.Translations {
-				if strings.TrimSpace(v) != "" {
-					translations[k] = v
-				}
-			}
+	for k, v := range toolResult.Translations {
+		if strings.TrimSpace(v) != "" {
+			translations[k] = v
 		}
 	}
 
@@ -180,18 +389,5 @@ func (t *TranslateTool) Execute(ctx context.Context, input Input) (Output, error
 			filtered[code] = v
 		}
 	}
-
-	out := Output{
-		Data: map[string]interface{}{
-			"translations": filtered,
-			"raw_response": bestEffortRawResponse(llmResp),
-		},
-		Metadata: map[string]interface{}{
-			"backend":       llmResp.Metadata["backend"],
-			"model":         llmResp.Model,
-			"prompt_tokens": llmResp.PromptTokens,
-			"total_tokens":  llmResp.TotalTokens,
-		},
-	}
-	return out, nil
+	return filtered, llmResp, nil
 }
@@ -0,0 +1,217 @@
+package tool
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	coreerrors "github.com/Lingualink-VRChat/Lingualink_Core/internal/core/errors"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
+)
+
+// Speech delivery modes.
+const (
+	SpeechDeliveryBase64 = "base64"
+	SpeechDeliveryURL    = "url"
+)
+
+// SpeechPathPrefix is the route serving stored speech clips by id.
+const SpeechPathPrefix = "/api/v1/tts/"
+
+// SpeechResult is the speech section of a response.
+type SpeechResult struct {
+	Language    string     `json:"language"`
+	Voice       string     `json:"voice"`
+	Format      string     `json:"format"`
+	ContentType string     `json:"content_type"`
+	Base64      string     `json:"base64,omitempty"`     // delivery=base64
+	URL         string     `json:"url,omitempty"`        // delivery=url，短时有效的下载地址
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // delivery=url
+}
+
+// TTSTool synthesizes speech for one translation. The voice is the voice input, else the
+// voice configured for the language, else tts.default_voice. Audio is returned inline as
+// base64 or kept in the store and returned as a short-lived download URL. An empty text
+// (for example one cleared by moderation) produces no speech.
+type TTSTool struct {
+	manager *tts.Manager
+	store   *tts.Store
+	cfg     config.TTSConfig
+}
+
+// NewTTSTool creates the tool. store may be nil, in which case only base64 delivery works.
+func NewTTSTool(manager *tts.Manager, store *tts.Store, cfg config.TTSConfig) *TTSTool {
+	return &TTSTool{manager: manager, store: store, cfg: cfg}
+}
+
+func (t *TTSTool) Name() string {
+	return "tts"
+}
+
+func (t *TTSTool) Description() string {
+	return "Synthesize speech for a translation"
+}
+
+func (t *TTSTool) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"text": map[string]interface{}{
+				"type":        "string",
+				"description": "Text to speak; defaults to translations[language]",
+			},
+			"translations": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Translations keyed by language code",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"description": "Language of the translation to speak",
+			},
+			"voice": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional voice override",
+			},
+			"delivery": map[string]interface{}{
+				"type":        "string",
+				"description": "base64 (default) or url",
+				"enum":        []string{SpeechDeliveryBase64, SpeechDeliveryURL},
+			},
+		},
+		"required": []string{"language"},
+	}
+}
+
+func (t *TTSTool) OutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"speech": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"language":     map[string]string{"type": "string"},
+					"voice":        map[string]string{"type": "string"},
+					"format":       map[string]string{"type": "string"},
+					"content_type": map[string]string{"type": "string"},
+					"base64":       map[string]string{"type": "string"},
+					"url":          map[string]string{"type": "string"},
+					"expires_at":   map[string]string{"type": "string"},
+				},
+				"required": []string{"language", "voice", "format", "content_type"},
+			},
+		},
+	}
+}
+
+func (t *TTSTool) Validate(input Input) error {
+	if input.Data == nil {
+		return coreerrors.NewValidationError("input data is required", nil)
+	}
+	if lang, ok := input.Data["language"].(string); !ok || strings.TrimSpace(lang) == "" {
+		return coreerrors.NewValidationError("language is required", nil)
+	}
+	if v, ok := input.Data["text"]; ok && v != nil {
+		if _, ok := v.(string); !ok {
+			return coreerrors.NewValidationError("text must be a string", nil)
+		}
+	}
+	if v, ok := input.Data["translations"]; ok && v != nil {
+		if _, ok := coerceStringMap(v); !ok {
+			return coreerrors.NewValidationError("translations must be an object of strings", nil)
+		}
+	}
+	for _, key := range []string{"voice", "delivery"} {
+		if v, ok := input.Data[key]; ok && v != nil {
+			if _, ok := v.(string); !ok {
+				return coreerrors.NewValidationError(key+" must be a string", nil)
+			}
+		}
+	}
+	switch delivery, _ := input.Data["delivery"].(string); delivery {
+	case "", SpeechDeliveryBase64:
+	case SpeechDeliveryURL:
+		if t.store == nil {
+			return coreerrors.NewValidationError("tts url delivery is not available", nil)
+		}
+	default:
+		return coreerrors.NewValidationError("delivery must be base64 or url", nil)
+	}
+	return nil
+}
+
+// Passthrough produces no speech.
+func (t *TTSTool) Passthrough(ctx context.Context, input Input) Output {
+	return Output{Data: map[string]interface{}{}}
+}
+
+func (t *TTSTool) Execute(ctx context.Context, input Input) (Output, error) {
+	if err := t.Validate(input); err != nil {
+		return Output{}, err
+	}
+	if t.manager == nil {
+		return Output{}, coreerrors.NewInternalError("tts is not configured", nil)
+	}
+
+	lang := strings.TrimSpace(input.Data["language"].(string))
+	text, _ := input.Data["text"].(string)
+	if strings.TrimSpace(text) == "" {
+		translations, _ := coerceStringMap(input.Data["translations"])
+		for code, v := range translations {
+			if strings.EqualFold(code, lang) {
+				text = v
+				break
+			}
+		}
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Output{Data: map[string]interface{}{}}, nil
+	}
+
+	voice, _ := input.Data["voice"].(string)
+	voice = strings.TrimSpace(voice)
+	if voice == "" {
+		voice = t.languageVoice(lang)
+	}
+	format := t.cfg.Format
+	if format == "" {
+		format = "mp3"
+	}
+
+	resp, err := t.manager.Synthesize(ctx, &tts.SpeechRequest{Text: text, Voice: voice, Format: format, Language: lang})
+	if err != nil {
+		return Output{}, err
+	}
+
+	speech := SpeechResult{Language: lang, Voice: voice, Format: resp.Format, ContentType: resp.ContentType}
+	if delivery, _ := input.Data["delivery"].(string); delivery == SpeechDeliveryURL {
+		id, expiresAt, err := t.store.Put(resp.Audio, resp.ContentType)
+		if err != nil {
+			return Output{}, coreerrors.NewInternalError("failed to store speech", err)
+		}
+		speech.URL = strings.TrimRight(t.cfg.PublicBaseURL, "/") + SpeechPathPrefix + id
+		speech.ExpiresAt = &expiresAt
+	} else {
+		speech.Base64 = base64.StdEncoding.EncodeToString(resp.Audio)
+	}
+
+	return Output{
+		Data:     map[string]interface{}{"speech": speech},
+		Metadata: map[string]interface{}{"tts_backend": resp.Backend},
+	}, nil
+}
+
+func (t *TTSTool) languageVoice(lang string) string {
+	for code, voice := range t.cfg.Voices {
+		if strings.EqualFold(code, lang) && strings.TrimSpace(voice) != "" {
+			return strings.TrimSpace(voice)
+		}
+	}
+	if t.cfg.DefaultVoice != "" {
+		return t.cfg.DefaultVoice
+	}
+	return "alloy"
+}
@@ -0,0 +1,108 @@
+package tool
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/core/tts"
+	"github.com/sirupsen/logrus"
+)
+
+func TestTTSTool_Execute(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		requests = append(requests, body)
+		mu.Unlock()
+		_, _ = w.Write([]byte("ID3-" + body["input"].(string)))
+	}))
+	t.Cleanup(srv.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	cfg := config.TTSConfig{
+		Providers:     []config.TTSProvider{{Name: "tts1", Type: "openai", URL: srv.URL, Model: "tts-1"}},
+		DefaultVoice:  "alloy",
+		Voices:        map[string]string{"JA": "nova"},
+		Format:        "mp3",
+		PublicBaseURL: "https://example.com/",
+	}
+	manager, err := tts.NewManager(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	store := tts.NewStore(time.Minute, 8)
+	tt := NewTTSTool(manager, store, cfg)
+	translations := map[string]string{"ja": "こんにちは", "en": "hello", "zh": ""}
+
+	out, err := tt.Execute(context.Background(), Input{Data: map[string]any{"translations": translations, "language": "ja"}})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	speech := out.Data["speech"].(SpeechResult)
+	if speech.Voice != "nova" || speech.ContentType != "audio/mpeg" || speech.URL != "" {
+		t.Errorf("speech=%+v", speech)
+	}
+	if audio, _ := base64.StdEncoding.DecodeString(speech.Base64); string(audio) != "ID3-こんにちは" {
+		t.Errorf("audio=%q", audio)
+	}
+	if out.Metadata["tts_backend"] != "tts1" {
+		t.Errorf("metadata=%v", out.Metadata)
+	}
+	if err := ValidateSchema(tt.OutputSchema(), out.Data); err != nil {
+		t.Errorf("output does not match schema: %v", err)
+	}
+
+	out, err = tt.Execute(context.Background(), Input{Data: map[string]any{
+		"translations": translations, "language": "en", "voice": "echo", "delivery": "url",
+	}})
+	if err != nil {
+		t.Fatalf("Execute url: %v", err)
+	}
+	speech = out.Data["speech"].(SpeechResult)
+	if speech.Voice != "echo" || speech.Base64 != "" || speech.ExpiresAt == nil {
+		t.Errorf("speech=%+v", speech)
+	}
+	id := strings.TrimPrefix(speech.URL, "https://example.com"+SpeechPathPrefix)
+	if clip, ok := store.Get(id); !ok || string(clip.Audio) != "ID3-hello" {
+		t.Errorf("stored clip for %q: %+v, %v", speech.URL, clip, ok)
+	}
+	if err := ValidateSchema(tt.OutputSchema(), out.Data); err != nil {
+		t.Errorf("output does not match schema: %v", err)
+	}
+
+	out, err = tt.Execute(context.Background(), Input{Data: map[string]any{"translations": translations, "language": "zh"}})
+	if err != nil {
+		t.Fatalf("Execute empty: %v", err)
+	}
+	if _, ok := out.Data["speech"]; ok {
+		t.Error("empty translation should produce no speech")
+	}
+
+	mu.Lock()
+	if len(requests) != 2 || requests[1]["voice"] != "echo" || requests[0]["response_format"] != "mp3" {
+		t.Errorf("requests=%v", requests)
+	}
+	mu.Unlock()
+
+	if err := NewTTSTool(manager, nil, cfg).Validate(Input{Data: map[string]any{"language": "en", "delivery": "url"}}); err == nil {
+		t.Error("expected error for url delivery without a store")
+	}
+	if err := tt.Validate(Input{Data: map[string]any{"language": "en", "delivery": "wav"}}); err == nil {
+		t.Error("expected error for unknown delivery")
+	}
+}
@@ -1,6 +1,7 @@
 package tool
 
 import (
+	"sync"
 	"time"
 
 	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
@@ -19,10 +20,77 @@ type Output struct {
 }
 
 // PipelineContext carries cross-tool metadata and step outputs.
+// Steps may run concurrently; while a pipeline is executing, StepOutputs and Metrics
+// must be accessed through the methods below.
 type PipelineContext struct {
 	RequestID       string
 	OriginalRequest map[string]interface{}
 	StepOutputs     map[string]Output
 	Dictionary      []config.DictionaryTerm
 	Metrics         map[string]time.Duration
+	StepOutcomes    map[string]StepOutcome
+
+	mu sync.RWMutex
+}
+
+// Step outcome statuses.
+const (
+	OutcomeOK       = "ok"
+	OutcomeRetried  = "retried"  // succeeded after at least one retry
+	OutcomeSkipped  = "skipped"  // condition was false, or the step failed with on_error skip
+	OutcomeFallback = "fallback" // the step failed and its fallback tool produced the output
+)
+
+// StepOutcome records how a pipeline step finished.
+type StepOutcome struct {
+	Status   string `json:"status"`
+	Attempts int    `json:"attempts,omitempty"`
+	Tool     string `json:"tool,omitempty"`  // tool that produced the output, when it is the fallback
+	Error    string `json:"error,omitempty"` // failure that led to skipped / fallback
+}
+
+// Output returns the output stored under key.
+func (c *PipelineContext) Output(key string) (Output, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out, ok := c.StepOutputs[key]
+	return out, ok
+}
+
+// SetOutput stores the output of a step under key.
+func (c *PipelineContext) SetOutput(key string, out Output) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.StepOutputs == nil {
+		c.StepOutputs = make(map[string]Output)
+	}
+	c.StepOutputs[key] = out
+}
+
+// Outcome returns how the step stored under key finished.
+func (c *PipelineContext) Outcome(key string) (StepOutcome, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	outcome, ok := c.StepOutcomes[key]
+	return outcome, ok
+}
+
+// SetOutcome records how the step stored under key finished.
+func (c *PipelineContext) SetOutcome(key string, outcome StepOutcome) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.StepOutcomes == nil {
+		c.StepOutcomes = make(map[string]StepOutcome)
+	}
+	c.StepOutcomes[key] = outcome
+}
+
+// SetMetric records the duration of a step under key.
+func (c *PipelineContext) SetMetric(key string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Metrics == nil {
+		c.Metrics = make(map[string]time.Duration)
+	}
+	c.Metrics[key] = d
 }
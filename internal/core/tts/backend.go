@@ -0,0 +1,10 @@
+package tts
+
+import "context"
+
+// Backend defines a TTS backend implementation.
+type Backend interface {
+	Synthesize(ctx context.Context, req *SpeechRequest) (*SpeechResponse, error)
+	HealthCheck(ctx context.Context) error
+	GetName() string
+}
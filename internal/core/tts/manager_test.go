@@ -0,0 +1,101 @@
+package tts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+func TestManager_Synthesize(t *testing.T) {
+	t.Parallel()
+
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/speech" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "audio/ogg")
+		_, _ = w.Write([]byte("OggS"))
+	}))
+	t.Cleanup(srv.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	m, err := NewManager(config.TTSConfig{
+		Providers: []config.TTSProvider{{
+			Name:       "tts1",
+			Type:       "openai",
+			URL:        srv.URL + "/v1/",
+			Model:      "tts-1",
+			APIKey:     "sk-test",
+			Parameters: map[string]interface{}{"speed": 1.25},
+		}},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	resp, err := m.Synthesize(context.Background(), &SpeechRequest{Text: "hello", Voice: "nova", Format: "opus"})
+	if err != nil {
+		t.Fatalf("Synthesize: %v", err)
+	}
+	if string(resp.Audio) != "OggS" || resp.ContentType != "audio/ogg" || resp.Backend != "tts1" {
+		t.Fatalf("resp = %+v", resp)
+	}
+	want := map[string]any{"model": "tts-1", "input": "hello", "voice": "nova", "response_format": "opus", "speed": 1.25}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("request %s = %v want %v", k, got[k], v)
+		}
+	}
+
+	if _, err := NewManager(config.TTSConfig{}, logger); err == nil {
+		t.Error("expected error without providers")
+	}
+	if _, err := NewManager(config.TTSConfig{Providers: []config.TTSProvider{{Name: "x", Type: "espeak"}}}, logger); err == nil {
+		t.Error("expected error for unsupported provider type")
+	}
+}
+
+func TestStore_PutGetExpire(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(50*time.Millisecond, 2)
+	first, _, err := s.Put([]byte("a"), "audio/mpeg")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if clip, ok := s.Get(first); !ok || string(clip.Audio) != "a" || clip.ContentType != "audio/mpeg" {
+		t.Fatalf("Get = %+v, %v", clip, ok)
+	}
+
+	second, _, _ := s.Put([]byte("b"), "audio/mpeg")
+	third, _, _ := s.Put([]byte("c"), "audio/mpeg")
+	if _, ok := s.Get(first); ok {
+		t.Error("oldest entry should be evicted at capacity")
+	}
+	if _, ok := s.Get(second); !ok {
+		t.Error("second entry should still be present")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := s.Get(third); ok {
+		t.Error("entry should expire after ttl")
+	}
+	if _, ok := s.Get("missing"); ok {
+		t.Error("unknown id should not be found")
+	}
+}
@@ -0,0 +1,160 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Lingualink-VRChat/Lingualink_Core/internal/config"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/logging"
+	"github.com/Lingualink-VRChat/Lingualink_Core/pkg/tracing"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OpenAIBackend implements an OpenAI compatible speech API:
+// POST {baseURL}/audio/speech
+type OpenAIBackend struct {
+	name       string
+	baseURL    string
+	model      string
+	apiKey     string
+	parameters map[string]interface{}
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+func NewOpenAIBackend(cfg config.TTSProvider, logger *logrus.Logger) *OpenAIBackend {
+	return &OpenAIBackend{
+		name:       cfg.Name,
+		baseURL:    strings.TrimRight(cfg.URL, "/"),
+		model:      cfg.Model,
+		apiKey:     cfg.APIKey,
+		parameters: cfg.Parameters,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		logger:     logger,
+	}
+}
+
+func (b *OpenAIBackend) GetName() string {
+	return b.name
+}
+
+func (b *OpenAIBackend) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.baseURL+"/models", nil)
+	if err != nil {
+		return err
+	}
+	if b.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+b.apiKey)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return fmt.Errorf("tts health check failed: status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Synthesize sends req to the speech endpoint inside a client span.
+func (b *OpenAIBackend) Synthesize(ctx context.Context, req *SpeechRequest) (*SpeechResponse, error) {
+	ctx, span := tracing.Start(ctx, "tts.synthesize", trace.SpanKindClient,
+		attribute.String("tts.backend", b.name),
+		attribute.String("tts.model", b.model),
+	)
+	if req != nil {
+		span.SetAttributes(attribute.Int("tts.text_length", len(req.Text)))
+	}
+	resp, err := b.synthesize(ctx, req)
+	if resp != nil {
+		span.SetAttributes(attribute.Int("tts.audio_bytes", len(resp.Audio)))
+	}
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (b *OpenAIBackend) synthesize(ctx context.Context, req *SpeechRequest) (*SpeechResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("nil request")
+	}
+
+	format := req.Format
+	if format == "" {
+		format = "mp3"
+	}
+
+	payload := make(map[string]interface{}, len(b.parameters)+4)
+	for k, v := range b.parameters {
+		if v != nil {
+			payload[k] = v
+		}
+	}
+	payload["model"] = b.model
+	payload["input"] = req.Text
+	payload["voice"] = req.Voice
+	payload["response_format"] = format
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/audio/speech", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	tracing.Inject(ctx, httpReq.Header)
+	if b.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+b.apiKey)
+	}
+
+	if b.logger != nil {
+		fields := logrus.Fields{
+			logging.FieldBackend: b.name,
+			"tts_voice":          req.Voice,
+			"tts_language":       req.Language,
+			"text_length":        len(req.Text),
+		}
+		if requestID, ok := logging.RequestIDFromContext(ctx); ok {
+			fields[logging.FieldRequestID] = requestID
+		}
+		b.logger.WithFields(fields).Debug("Sending TTS speech request")
+	}
+
+	resp, err := b.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tts synthesis failed: status %d: %s", resp.StatusCode, string(audio))
+	}
+	if len(audio) == 0 {
+		return nil, fmt.Errorf("tts synthesis returned no audio")
+	}
+
+	return &SpeechResponse{
+		Audio:       audio,
+		Format:      format,
+		ContentType: ContentType(format),
+		Backend:     b.name,
+	}, nil
+}
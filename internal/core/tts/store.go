@@ -0,0 +1,93 @@
+package tts
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Clip is synthesized audio held for download.
+type Clip struct {
+	Audio       []byte
+	ContentType string
+	ExpiresAt   time.Time
+}
+
+// Store keeps synthesized audio for a short time so clients can download it by id.
+type Store struct {
+	mu         sync.Mutex
+	clips      map[string]Clip
+	ttl        time.Duration
+	maxEntries int
+}
+
+// NewStore creates a Store whose entries expire after ttl. When maxEntries is reached the
+// entry closest to expiry is evicted.
+func NewStore(ttl time.Duration, maxEntries int) *Store {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	if maxEntries <= 0 {
+		maxEntries = 256
+	}
+	return &Store{
+		clips:      make(map[string]Clip),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+// Put stores audio and returns its id and expiry time.
+func (s *Store) Put(audio []byte, contentType string) (string, time.Time, error) {
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", time.Time{}, err
+	}
+	id := hex.EncodeToString(raw[:])
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, clip := range s.clips {
+		if now.After(clip.ExpiresAt) {
+			delete(s.clips, key)
+		}
+	}
+	for len(s.clips) >= s.maxEntries {
+		s.evictOldestLocked()
+	}
+	s.clips[id] = Clip{Audio: audio, ContentType: contentType, ExpiresAt: expiresAt}
+	return id, expiresAt, nil
+}
+
+// Get returns the clip stored under id if it has not expired.
+func (s *Store) Get(id string) (Clip, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clip, ok := s.clips[id]
+	if !ok {
+		return Clip{}, false
+	}
+	if time.Now().After(clip.ExpiresAt) {
+		delete(s.clips, id)
+		return Clip{}, false
+	}
+	return clip, true
+}
+
+func (s *Store) evictOldestLocked() {
+	var oldestKey string
+	var oldest time.Time
+	for key, clip := range s.clips {
+		if oldestKey == "" || clip.ExpiresAt.Before(oldest) {
+			oldestKey = key
+			oldest = clip.ExpiresAt
+		}
+	}
+	delete(s.clips, oldestKey)
+}
@@ -0,0 +1,35 @@
+package tts
+
+// SpeechRequest describes a synthesis request.
+type SpeechRequest struct {
+	Text     string
+	Voice    string
+	Format   string // mp3 / opus / aac / flac / wav / pcm
+	Language string // optional hint, used for logging only
+}
+
+// SpeechResponse carries synthesized audio.
+type SpeechResponse struct {
+	Audio       []byte
+	Format      string
+	ContentType string
+	Backend     string
+}
+
+// ContentType returns the MIME type for an audio format.
+func ContentType(format string) string {
+	switch format {
+	case "opus":
+		return "audio/ogg"
+	case "aac":
+		return "audio/aac"
+	case "flac":
+		return "audio/flac"
+	case "wav":
+		return "audio/wav"
+	case "pcm":
+		return "audio/pcm"
+	default:
+		return "audio/mpeg"
+	}
+}
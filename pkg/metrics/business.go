@@ -34,6 +34,14 @@ var (
 		},
 		[]string{"parser"},
 	)
+
+	asrCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "lingualink_asr_cache_lookups_total",
+			Help: "Total ASR cache lookups by result (hit/miss), suitable for calculating hit rate",
+		},
+		[]string{"result"},
+	)
 )
 
 // IncTranslation records a successful translation for a given language pair.
@@ -68,3 +76,12 @@ func ObserveJSONParseSuccess(parser string, success bool) {
 	}
 	jsonParseSuccessRate.WithLabelValues(parser).Set(0)
 }
+
+// ObserveASRCacheLookup records a single ASR cache lookup result.
+func ObserveASRCacheLookup(hit bool) {
+	if hit {
+		asrCacheLookups.WithLabelValues("hit").Inc()
+		return
+	}
+	asrCacheLookups.WithLabelValues("miss").Inc()
+}
@@ -60,6 +60,7 @@ func RegisterPrometheus() {
 			transcriptionsTotal,
 			languagePairUsage,
 			jsonParseSuccessRate,
+			asrCacheLookups,
 		)
 	})
 }
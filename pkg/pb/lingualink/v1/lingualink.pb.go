@@ -0,0 +1,1796 @@
+// 生成 Go 代码（输出到 pkg/pb/lingualink/v1）：
+//   protoc -I proto --go_out=pkg/pb --go_opt=paths=source_relative \
+//     --go-grpc_out=pkg/pb --go-grpc_opt=paths=source_relative lingualink/v1/lingualink.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: lingualink/v1/lingualink.proto
+
+// Lingualink Core gRPC API. 与 REST API 共用同一套处理流程与认证：
+// 通过 metadata 传递 x-api-key 或 authorization（"Bearer <jwt>" / "ApiKey <key>"），可选 x-request-id。
+
+package lingualinkv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreamControl_Action int32
+
+const (
+	StreamControl_ACTION_UNSPECIFIED StreamControl_Action = 0
+	StreamControl_ACTION_FLUSH       StreamControl_Action = 1 // 立即结束当前语句
+	StreamControl_ACTION_STOP        StreamControl_Action = 2 // 处理完剩余语句后结束会话
+)
+
+// Enum value maps for StreamControl_Action.
+var (
+	StreamControl_Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "ACTION_FLUSH",
+		2: "ACTION_STOP",
+	}
+	StreamControl_Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED": 0,
+		"ACTION_FLUSH":       1,
+		"ACTION_STOP":        2,
+	}
+)
+
+func (x StreamControl_Action) Enum() *StreamControl_Action {
+	p := new(StreamControl_Action)
+	*p = x
+	return p
+}
+
+func (x StreamControl_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamControl_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_lingualink_v1_lingualink_proto_enumTypes[0].Descriptor()
+}
+
+func (StreamControl_Action) Type() protoreflect.EnumType {
+	return &file_lingualink_v1_lingualink_proto_enumTypes[0]
+}
+
+func (x StreamControl_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamControl_Action.Descriptor instead.
+func (StreamControl_Action) EnumDescriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{10, 0}
+}
+
+type StreamEvent_Type int32
+
+const (
+	StreamEvent_TYPE_UNSPECIFIED StreamEvent_Type = 0
+	StreamEvent_TYPE_READY       StreamEvent_Type = 1
+	StreamEvent_TYPE_PARTIAL     StreamEvent_Type = 2
+	StreamEvent_TYPE_FINAL       StreamEvent_Type = 3
+	StreamEvent_TYPE_ERROR       StreamEvent_Type = 4
+)
+
+// Enum value maps for StreamEvent_Type.
+var (
+	StreamEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_READY",
+		2: "TYPE_PARTIAL",
+		3: "TYPE_FINAL",
+		4: "TYPE_ERROR",
+	}
+	StreamEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_READY":       1,
+		"TYPE_PARTIAL":     2,
+		"TYPE_FINAL":       3,
+		"TYPE_ERROR":       4,
+	}
+)
+
+func (x StreamEvent_Type) Enum() *StreamEvent_Type {
+	p := new(StreamEvent_Type)
+	*p = x
+	return p
+}
+
+func (x StreamEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_lingualink_v1_lingualink_proto_enumTypes[1].Descriptor()
+}
+
+func (StreamEvent_Type) Type() protoreflect.EnumType {
+	return &file_lingualink_v1_lingualink_proto_enumTypes[1]
+}
+
+func (x StreamEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamEvent_Type.Descriptor instead.
+func (StreamEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type DictionaryTerm struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          string                 `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	Aliases       []string               `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Translations  map[string]string      `protobuf:"bytes,3,rep,name=translations,proto3" json:"translations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 各目标语言要求的译法，翻译后校验并在违反时重译
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DictionaryTerm) Reset() {
+	*x = DictionaryTerm{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DictionaryTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DictionaryTerm) ProtoMessage() {}
+
+func (x *DictionaryTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DictionaryTerm.ProtoReflect.Descriptor instead.
+func (*DictionaryTerm) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DictionaryTerm) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *DictionaryTerm) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *DictionaryTerm) GetTranslations() map[string]string {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+type ProcessTextRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Text            string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Task            string                 `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"` // translate（默认）/ transcribe
+	SourceLanguage  string                 `protobuf:"bytes,3,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"`
+	TargetLanguages []string               `protobuf:"bytes,4,rep,name=target_languages,json=targetLanguages,proto3" json:"target_languages,omitempty"`
+	UserDictionary  []*DictionaryTerm      `protobuf:"bytes,5,rep,name=user_dictionary,json=userDictionary,proto3" json:"user_dictionary,omitempty"`
+	Options         *structpb.Struct       `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProcessTextRequest) Reset() {
+	*x = ProcessTextRequest{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessTextRequest) ProtoMessage() {}
+
+func (x *ProcessTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessTextRequest.ProtoReflect.Descriptor instead.
+func (*ProcessTextRequest) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProcessTextRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ProcessTextRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *ProcessTextRequest) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *ProcessTextRequest) GetTargetLanguages() []string {
+	if x != nil {
+		return x.TargetLanguages
+	}
+	return nil
+}
+
+func (x *ProcessTextRequest) GetUserDictionary() []*DictionaryTerm {
+	if x != nil {
+		return x.UserDictionary
+	}
+	return nil
+}
+
+func (x *ProcessTextRequest) GetOptions() *structpb.Struct {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ProcessTextResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RequestId      string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	SourceText     string                 `protobuf:"bytes,3,opt,name=source_text,json=sourceText,proto3" json:"source_text,omitempty"`
+	CorrectedText  string                 `protobuf:"bytes,4,opt,name=corrected_text,json=correctedText,proto3" json:"corrected_text,omitempty"`
+	Translations   map[string]string      `protobuf:"bytes,5,rep,name=translations,proto3" json:"translations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RawResponse    string                 `protobuf:"bytes,6,opt,name=raw_response,json=rawResponse,proto3" json:"raw_response,omitempty"`
+	ProcessingTime float64                `protobuf:"fixed64,7,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Moderation     *Moderation            `protobuf:"bytes,9,opt,name=moderation,proto3" json:"moderation,omitempty"` // 仅含 moderate 步骤的 pipeline
+	Speech         *Speech                `protobuf:"bytes,10,opt,name=speech,proto3" json:"speech,omitempty"`        // 仅请求 options.tts 且合成成功时
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProcessTextResponse) Reset() {
+	*x = ProcessTextResponse{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessTextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessTextResponse) ProtoMessage() {}
+
+func (x *ProcessTextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessTextResponse.ProtoReflect.Descriptor instead.
+func (*ProcessTextResponse) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProcessTextResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ProcessTextResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProcessTextResponse) GetSourceText() string {
+	if x != nil {
+		return x.SourceText
+	}
+	return ""
+}
+
+func (x *ProcessTextResponse) GetCorrectedText() string {
+	if x != nil {
+		return x.CorrectedText
+	}
+	return ""
+}
+
+func (x *ProcessTextResponse) GetTranslations() map[string]string {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *ProcessTextResponse) GetRawResponse() string {
+	if x != nil {
+		return x.RawResponse
+	}
+	return ""
+}
+
+func (x *ProcessTextResponse) GetProcessingTime() float64 {
+	if x != nil {
+		return x.ProcessingTime
+	}
+	return 0
+}
+
+func (x *ProcessTextResponse) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ProcessTextResponse) GetModeration() *Moderation {
+	if x != nil {
+		return x.Moderation
+	}
+	return nil
+}
+
+func (x *ProcessTextResponse) GetSpeech() *Speech {
+	if x != nil {
+		return x.Speech
+	}
+	return nil
+}
+
+// Moderation 汇总 pipeline 中 moderate 步骤的结果，与 REST 响应的 moderation 字段一致。
+type Moderation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flagged       bool                   `protobuf:"varint,1,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	Blocked       bool                   `protobuf:"varint,2,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	Matches       []*ModerationMatch     `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Moderation) Reset() {
+	*x = Moderation{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Moderation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Moderation) ProtoMessage() {}
+
+func (x *Moderation) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Moderation.ProtoReflect.Descriptor instead.
+func (*Moderation) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Moderation) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *Moderation) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *Moderation) GetMatches() []*ModerationMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type ModerationMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // text 或 translations.<语言代码>
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Rule          string                 `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"` // 分类器命中为 classifier
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Match         string                 `protobuf:"bytes,5,opt,name=match,proto3" json:"match,omitempty"`
+	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationMatch) Reset() {
+	*x = ModerationMatch{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationMatch) ProtoMessage() {}
+
+func (x *ModerationMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationMatch.ProtoReflect.Descriptor instead.
+func (*ModerationMatch) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ModerationMatch) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ModerationMatch) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ModerationMatch) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ModerationMatch) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ModerationMatch) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *ModerationMatch) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+// Speech 为合成的译文语音，与 REST 响应的 speech 字段一致。
+type Speech struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Voice         string                 `protobuf:"bytes,2,opt,name=voice,proto3" json:"voice,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Audio         []byte                 `protobuf:"bytes,5,opt,name=audio,proto3" json:"audio,omitempty"` // delivery=base64 时为音频字节（无需 base64 解码）
+	Url           string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`     // delivery=url 时的下载地址
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Speech) Reset() {
+	*x = Speech{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Speech) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Speech) ProtoMessage() {}
+
+func (x *Speech) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Speech.ProtoReflect.Descriptor instead.
+func (*Speech) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Speech) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Speech) GetVoice() string {
+	if x != nil {
+		return x.Voice
+	}
+	return ""
+}
+
+func (x *Speech) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Speech) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Speech) GetAudio() []byte {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *Speech) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Speech) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ProcessAudioRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Audio           []byte                 `protobuf:"bytes,1,opt,name=audio,proto3" json:"audio,omitempty"`
+	AudioFormat     string                 `protobuf:"bytes,2,opt,name=audio_format,json=audioFormat,proto3" json:"audio_format,omitempty"` // 留空时按内容识别
+	SampleRate      int32                  `protobuf:"varint,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`   // 仅 pcm_s16le / pcm_f32le
+	Channels        int32                  `protobuf:"varint,4,opt,name=channels,proto3" json:"channels,omitempty"`                         // 仅 pcm_s16le / pcm_f32le，默认 1
+	Task            string                 `protobuf:"bytes,5,opt,name=task,proto3" json:"task,omitempty"`
+	SourceLanguage  string                 `protobuf:"bytes,6,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"`
+	TargetLanguages []string               `protobuf:"bytes,7,rep,name=target_languages,json=targetLanguages,proto3" json:"target_languages,omitempty"`
+	UserDictionary  []*DictionaryTerm      `protobuf:"bytes,8,rep,name=user_dictionary,json=userDictionary,proto3" json:"user_dictionary,omitempty"`
+	Options         *structpb.Struct       `protobuf:"bytes,9,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProcessAudioRequest) Reset() {
+	*x = ProcessAudioRequest{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessAudioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessAudioRequest) ProtoMessage() {}
+
+func (x *ProcessAudioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessAudioRequest.ProtoReflect.Descriptor instead.
+func (*ProcessAudioRequest) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProcessAudioRequest) GetAudio() []byte {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *ProcessAudioRequest) GetAudioFormat() string {
+	if x != nil {
+		return x.AudioFormat
+	}
+	return ""
+}
+
+func (x *ProcessAudioRequest) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *ProcessAudioRequest) GetChannels() int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *ProcessAudioRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *ProcessAudioRequest) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *ProcessAudioRequest) GetTargetLanguages() []string {
+	if x != nil {
+		return x.TargetLanguages
+	}
+	return nil
+}
+
+func (x *ProcessAudioRequest) GetUserDictionary() []*DictionaryTerm {
+	if x != nil {
+		return x.UserDictionary
+	}
+	return nil
+}
+
+func (x *ProcessAudioRequest) GetOptions() *structpb.Struct {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ProcessAudioResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RequestId      string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Transcription  string                 `protobuf:"bytes,3,opt,name=transcription,proto3" json:"transcription,omitempty"`
+	CorrectedText  string                 `protobuf:"bytes,4,opt,name=corrected_text,json=correctedText,proto3" json:"corrected_text,omitempty"`
+	Translations   map[string]string      `protobuf:"bytes,5,rep,name=translations,proto3" json:"translations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RawResponse    string                 `protobuf:"bytes,6,opt,name=raw_response,json=rawResponse,proto3" json:"raw_response,omitempty"`
+	ProcessingTime float64                `protobuf:"fixed64,7,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Moderation     *Moderation            `protobuf:"bytes,9,opt,name=moderation,proto3" json:"moderation,omitempty"`
+	Speech         *Speech                `protobuf:"bytes,10,opt,name=speech,proto3" json:"speech,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProcessAudioResponse) Reset() {
+	*x = ProcessAudioResponse{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessAudioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessAudioResponse) ProtoMessage() {}
+
+func (x *ProcessAudioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessAudioResponse.ProtoReflect.Descriptor instead.
+func (*ProcessAudioResponse) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProcessAudioResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ProcessAudioResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProcessAudioResponse) GetTranscription() string {
+	if x != nil {
+		return x.Transcription
+	}
+	return ""
+}
+
+func (x *ProcessAudioResponse) GetCorrectedText() string {
+	if x != nil {
+		return x.CorrectedText
+	}
+	return ""
+}
+
+func (x *ProcessAudioResponse) GetTranslations() map[string]string {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *ProcessAudioResponse) GetRawResponse() string {
+	if x != nil {
+		return x.RawResponse
+	}
+	return ""
+}
+
+func (x *ProcessAudioResponse) GetProcessingTime() float64 {
+	if x != nil {
+		return x.ProcessingTime
+	}
+	return 0
+}
+
+func (x *ProcessAudioResponse) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ProcessAudioResponse) GetModeration() *Moderation {
+	if x != nil {
+		return x.Moderation
+	}
+	return nil
+}
+
+func (x *ProcessAudioResponse) GetSpeech() *Speech {
+	if x != nil {
+		return x.Speech
+	}
+	return nil
+}
+
+type StreamTranslateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*StreamTranslateRequest_Start
+	//	*StreamTranslateRequest_Audio
+	//	*StreamTranslateRequest_Control
+	Payload       isStreamTranslateRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTranslateRequest) Reset() {
+	*x = StreamTranslateRequest{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTranslateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTranslateRequest) ProtoMessage() {}
+
+func (x *StreamTranslateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTranslateRequest.ProtoReflect.Descriptor instead.
+func (*StreamTranslateRequest) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamTranslateRequest) GetPayload() isStreamTranslateRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *StreamTranslateRequest) GetStart() *StreamStart {
+	if x != nil {
+		if x, ok := x.Payload.(*StreamTranslateRequest_Start); ok {
+			return x.Start
+		}
+	}
+	return nil
+}
+
+func (x *StreamTranslateRequest) GetAudio() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*StreamTranslateRequest_Audio); ok {
+			return x.Audio
+		}
+	}
+	return nil
+}
+
+func (x *StreamTranslateRequest) GetControl() *StreamControl {
+	if x != nil {
+		if x, ok := x.Payload.(*StreamTranslateRequest_Control); ok {
+			return x.Control
+		}
+	}
+	return nil
+}
+
+type isStreamTranslateRequest_Payload interface {
+	isStreamTranslateRequest_Payload()
+}
+
+type StreamTranslateRequest_Start struct {
+	Start *StreamStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type StreamTranslateRequest_Audio struct {
+	Audio []byte `protobuf:"bytes,2,opt,name=audio,proto3,oneof"`
+}
+
+type StreamTranslateRequest_Control struct {
+	Control *StreamControl `protobuf:"bytes,3,opt,name=control,proto3,oneof"`
+}
+
+func (*StreamTranslateRequest_Start) isStreamTranslateRequest_Payload() {}
+
+func (*StreamTranslateRequest_Audio) isStreamTranslateRequest_Payload() {}
+
+func (*StreamTranslateRequest_Control) isStreamTranslateRequest_Payload() {}
+
+type StreamStart struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AudioFormat     string                 `protobuf:"bytes,1,opt,name=audio_format,json=audioFormat,proto3" json:"audio_format,omitempty"` // pcm_s16le / pcm_f32le / opus（Ogg）/ webm
+	SampleRate      int32                  `protobuf:"varint,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	Channels        int32                  `protobuf:"varint,3,opt,name=channels,proto3" json:"channels,omitempty"`
+	Task            string                 `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+	SourceLanguage  string                 `protobuf:"bytes,5,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"`
+	TargetLanguages []string               `protobuf:"bytes,6,rep,name=target_languages,json=targetLanguages,proto3" json:"target_languages,omitempty"`
+	UserDictionary  []*DictionaryTerm      `protobuf:"bytes,7,rep,name=user_dictionary,json=userDictionary,proto3" json:"user_dictionary,omitempty"`
+	Options         *structpb.Struct       `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
+	PartialResults  *bool                  `protobuf:"varint,9,opt,name=partial_results,json=partialResults,proto3,oneof" json:"partial_results,omitempty"` // 默认 true
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StreamStart) Reset() {
+	*x = StreamStart{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamStart) ProtoMessage() {}
+
+func (x *StreamStart) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamStart.ProtoReflect.Descriptor instead.
+func (*StreamStart) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamStart) GetAudioFormat() string {
+	if x != nil {
+		return x.AudioFormat
+	}
+	return ""
+}
+
+func (x *StreamStart) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *StreamStart) GetChannels() int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *StreamStart) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *StreamStart) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *StreamStart) GetTargetLanguages() []string {
+	if x != nil {
+		return x.TargetLanguages
+	}
+	return nil
+}
+
+func (x *StreamStart) GetUserDictionary() []*DictionaryTerm {
+	if x != nil {
+		return x.UserDictionary
+	}
+	return nil
+}
+
+func (x *StreamStart) GetOptions() *structpb.Struct {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *StreamStart) GetPartialResults() bool {
+	if x != nil && x.PartialResults != nil {
+		return *x.PartialResults
+	}
+	return false
+}
+
+type StreamControl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        StreamControl_Action   `protobuf:"varint,1,opt,name=action,proto3,enum=lingualink.v1.StreamControl_Action" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamControl) Reset() {
+	*x = StreamControl{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamControl) ProtoMessage() {}
+
+func (x *StreamControl) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamControl.ProtoReflect.Descriptor instead.
+func (*StreamControl) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamControl) GetAction() StreamControl_Action {
+	if x != nil {
+		return x.Action
+	}
+	return StreamControl_ACTION_UNSPECIFIED
+}
+
+type StreamEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           StreamEvent_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=lingualink.v1.StreamEvent_Type" json:"type,omitempty"`
+	SessionId      string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UtteranceId    int32                  `protobuf:"varint,3,opt,name=utterance_id,json=utteranceId,proto3" json:"utterance_id,omitempty"`
+	Start          float64                `protobuf:"fixed64,4,opt,name=start,proto3" json:"start,omitempty"` // 相对流开始的秒数
+	End            float64                `protobuf:"fixed64,5,opt,name=end,proto3" json:"end,omitempty"`
+	Transcription  string                 `protobuf:"bytes,6,opt,name=transcription,proto3" json:"transcription,omitempty"`
+	CorrectedText  string                 `protobuf:"bytes,7,opt,name=corrected_text,json=correctedText,proto3" json:"corrected_text,omitempty"`
+	Translations   map[string]string      `protobuf:"bytes,8,rep,name=translations,proto3" json:"translations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ProcessingTime float64                `protobuf:"fixed64,9,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
+	Forced         bool                   `protobuf:"varint,10,opt,name=forced,proto3" json:"forced,omitempty"`
+	SampleRate     int32                  `protobuf:"varint,11,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,12,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Code           string                 `protobuf:"bytes,13,opt,name=code,proto3" json:"code,omitempty"`
+	Error          string                 `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	Moderation     *Moderation            `protobuf:"bytes,15,opt,name=moderation,proto3" json:"moderation,omitempty"` // 仅 TYPE_FINAL
+	Speech         *Speech                `protobuf:"bytes,16,opt,name=speech,proto3" json:"speech,omitempty"`         // 仅 TYPE_FINAL
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StreamEvent) Reset() {
+	*x = StreamEvent{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEvent) ProtoMessage() {}
+
+func (x *StreamEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEvent.ProtoReflect.Descriptor instead.
+func (*StreamEvent) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamEvent) GetType() StreamEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return StreamEvent_TYPE_UNSPECIFIED
+}
+
+func (x *StreamEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetUtteranceId() int32 {
+	if x != nil {
+		return x.UtteranceId
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetStart() float64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetEnd() float64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetTranscription() string {
+	if x != nil {
+		return x.Transcription
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetCorrectedText() string {
+	if x != nil {
+		return x.CorrectedText
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetTranslations() map[string]string {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *StreamEvent) GetProcessingTime() float64 {
+	if x != nil {
+		return x.ProcessingTime
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetForced() bool {
+	if x != nil {
+		return x.Forced
+	}
+	return false
+}
+
+func (x *StreamEvent) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *StreamEvent) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetModeration() *Moderation {
+	if x != nil {
+		return x.Moderation
+	}
+	return nil
+}
+
+func (x *StreamEvent) GetSpeech() *Speech {
+	if x != nil {
+		return x.Speech
+	}
+	return nil
+}
+
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{12}
+}
+
+type GetCapabilitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Capabilities  *structpb.Struct       `protobuf:"bytes,1,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesResponse) Reset() {
+	*x = GetCapabilitiesResponse{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesResponse) ProtoMessage() {}
+
+func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetCapabilitiesResponse) GetCapabilities() *structpb.Struct {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type ListLanguagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLanguagesRequest) Reset() {
+	*x = ListLanguagesRequest{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLanguagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLanguagesRequest) ProtoMessage() {}
+
+func (x *ListLanguagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLanguagesRequest.ProtoReflect.Descriptor instead.
+func (*ListLanguagesRequest) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{14}
+}
+
+type Language struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                                                             // standard / fun
+	Names         map[string]string      `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 显示名称，与 GET /languages 的名称字段一致
+	Aliases       []string               `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	StyleNote     string                 `protobuf:"bytes,5,opt,name=style_note,json=styleNote,proto3" json:"style_note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Language) Reset() {
+	*x = Language{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Language) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Language) ProtoMessage() {}
+
+func (x *Language) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Language.ProtoReflect.Descriptor instead.
+func (*Language) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Language) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Language) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Language) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Language) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Language) GetStyleNote() string {
+	if x != nil {
+		return x.StyleNote
+	}
+	return ""
+}
+
+type ListLanguagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Languages     []*Language            `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLanguagesResponse) Reset() {
+	*x = ListLanguagesResponse{}
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLanguagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLanguagesResponse) ProtoMessage() {}
+
+func (x *ListLanguagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lingualink_v1_lingualink_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLanguagesResponse.ProtoReflect.Descriptor instead.
+func (*ListLanguagesResponse) Descriptor() ([]byte, []int) {
+	return file_lingualink_v1_lingualink_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListLanguagesResponse) GetLanguages() []*Language {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+var File_lingualink_v1_lingualink_proto protoreflect.FileDescriptor
+
+const file_lingualink_v1_lingualink_proto_rawDesc = "" +
+	"\n" +
+	"\x1elingualink/v1/lingualink.proto\x12\rlingualink.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd4\x01\n" +
+	"\x0eDictionaryTerm\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\tR\x04term\x12\x18\n" +
+	"\aaliases\x18\x02 \x03(\tR\aaliases\x12S\n" +
+	"\ftranslations\x18\x03 \x03(\v2/.lingualink.v1.DictionaryTerm.TranslationsEntryR\ftranslations\x1a?\n" +
+	"\x11TranslationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8b\x02\n" +
+	"\x12ProcessTextRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04task\x18\x02 \x01(\tR\x04task\x12'\n" +
+	"\x0fsource_language\x18\x03 \x01(\tR\x0esourceLanguage\x12)\n" +
+	"\x10target_languages\x18\x04 \x03(\tR\x0ftargetLanguages\x12F\n" +
+	"\x0fuser_dictionary\x18\x05 \x03(\v2\x1d.lingualink.v1.DictionaryTermR\x0euserDictionary\x121\n" +
+	"\aoptions\x18\x06 \x01(\v2\x17.google.protobuf.StructR\aoptions\"\x9a\x04\n" +
+	"\x13ProcessTextResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vsource_text\x18\x03 \x01(\tR\n" +
+	"sourceText\x12%\n" +
+	"\x0ecorrected_text\x18\x04 \x01(\tR\rcorrectedText\x12X\n" +
+	"\ftranslations\x18\x05 \x03(\v24.lingualink.v1.ProcessTextResponse.TranslationsEntryR\ftranslations\x12!\n" +
+	"\fraw_response\x18\x06 \x01(\tR\vrawResponse\x12'\n" +
+	"\x0fprocessing_time\x18\a \x01(\x01R\x0eprocessingTime\x123\n" +
+	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructR\bmetadata\x129\n" +
+	"\n" +
+	"moderation\x18\t \x01(\v2\x19.lingualink.v1.ModerationR\n" +
+	"moderation\x12-\n" +
+	"\x06speech\x18\n" +
+	" \x01(\v2\x15.lingualink.v1.SpeechR\x06speech\x1a?\n" +
+	"\x11TranslationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"z\n" +
+	"\n" +
+	"Moderation\x12\x18\n" +
+	"\aflagged\x18\x01 \x01(\bR\aflagged\x12\x18\n" +
+	"\ablocked\x18\x02 \x01(\bR\ablocked\x128\n" +
+	"\amatches\x18\x03 \x03(\v2\x1e.lingualink.v1.ModerationMatchR\amatches\"\xa1\x01\n" +
+	"\x0fModerationMatch\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x12\n" +
+	"\x04rule\x18\x03 \x01(\tR\x04rule\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x14\n" +
+	"\x05match\x18\x05 \x01(\tR\x05match\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\"\xd8\x01\n" +
+	"\x06Speech\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05voice\x18\x02 \x01(\tR\x05voice\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05audio\x18\x05 \x01(\fR\x05audio\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xee\x02\n" +
+	"\x13ProcessAudioRequest\x12\x14\n" +
+	"\x05audio\x18\x01 \x01(\fR\x05audio\x12!\n" +
+	"\faudio_format\x18\x02 \x01(\tR\vaudioFormat\x12\x1f\n" +
+	"\vsample_rate\x18\x03 \x01(\x05R\n" +
+	"sampleRate\x12\x1a\n" +
+	"\bchannels\x18\x04 \x01(\x05R\bchannels\x12\x12\n" +
+	"\x04task\x18\x05 \x01(\tR\x04task\x12'\n" +
+	"\x0fsource_language\x18\x06 \x01(\tR\x0esourceLanguage\x12)\n" +
+	"\x10target_languages\x18\a \x03(\tR\x0ftargetLanguages\x12F\n" +
+	"\x0fuser_dictionary\x18\b \x03(\v2\x1d.lingualink.v1.DictionaryTermR\x0euserDictionary\x121\n" +
+	"\aoptions\x18\t \x01(\v2\x17.google.protobuf.StructR\aoptions\"\xa1\x04\n" +
+	"\x14ProcessAudioResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12$\n" +
+	"\rtranscription\x18\x03 \x01(\tR\rtranscription\x12%\n" +
+	"\x0ecorrected_text\x18\x04 \x01(\tR\rcorrectedText\x12Y\n" +
+	"\ftranslations\x18\x05 \x03(\v25.lingualink.v1.ProcessAudioResponse.TranslationsEntryR\ftranslations\x12!\n" +
+	"\fraw_response\x18\x06 \x01(\tR\vrawResponse\x12'\n" +
+	"\x0fprocessing_time\x18\a \x01(\x01R\x0eprocessingTime\x123\n" +
+	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructR\bmetadata\x129\n" +
+	"\n" +
+	"moderation\x18\t \x01(\v2\x19.lingualink.v1.ModerationR\n" +
+	"moderation\x12-\n" +
+	"\x06speech\x18\n" +
+	" \x01(\v2\x15.lingualink.v1.SpeechR\x06speech\x1a?\n" +
+	"\x11TranslationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa9\x01\n" +
+	"\x16StreamTranslateRequest\x122\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.lingualink.v1.StreamStartH\x00R\x05start\x12\x16\n" +
+	"\x05audio\x18\x02 \x01(\fH\x00R\x05audio\x128\n" +
+	"\acontrol\x18\x03 \x01(\v2\x1c.lingualink.v1.StreamControlH\x00R\acontrolB\t\n" +
+	"\apayload\"\x92\x03\n" +
+	"\vStreamStart\x12!\n" +
+	"\faudio_format\x18\x01 \x01(\tR\vaudioFormat\x12\x1f\n" +
+	"\vsample_rate\x18\x02 \x01(\x05R\n" +
+	"sampleRate\x12\x1a\n" +
+	"\bchannels\x18\x03 \x01(\x05R\bchannels\x12\x12\n" +
+	"\x04task\x18\x04 \x01(\tR\x04task\x12'\n" +
+	"\x0fsource_language\x18\x05 \x01(\tR\x0esourceLanguage\x12)\n" +
+	"\x10target_languages\x18\x06 \x03(\tR\x0ftargetLanguages\x12F\n" +
+	"\x0fuser_dictionary\x18\a \x03(\v2\x1d.lingualink.v1.DictionaryTermR\x0euserDictionary\x121\n" +
+	"\aoptions\x18\b \x01(\v2\x17.google.protobuf.StructR\aoptions\x12,\n" +
+	"\x0fpartial_results\x18\t \x01(\bH\x00R\x0epartialResults\x88\x01\x01B\x12\n" +
+	"\x10_partial_results\"\x91\x01\n" +
+	"\rStreamControl\x12;\n" +
+	"\x06action\x18\x01 \x01(\x0e2#.lingualink.v1.StreamControl.ActionR\x06action\"C\n" +
+	"\x06Action\x12\x16\n" +
+	"\x12ACTION_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fACTION_FLUSH\x10\x01\x12\x0f\n" +
+	"\vACTION_STOP\x10\x02\"\x97\x06\n" +
+	"\vStreamEvent\x123\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1f.lingualink.v1.StreamEvent.TypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12!\n" +
+	"\futterance_id\x18\x03 \x01(\x05R\vutteranceId\x12\x14\n" +
+	"\x05start\x18\x04 \x01(\x01R\x05start\x12\x10\n" +
+	"\x03end\x18\x05 \x01(\x01R\x03end\x12$\n" +
+	"\rtranscription\x18\x06 \x01(\tR\rtranscription\x12%\n" +
+	"\x0ecorrected_text\x18\a \x01(\tR\rcorrectedText\x12P\n" +
+	"\ftranslations\x18\b \x03(\v2,.lingualink.v1.StreamEvent.TranslationsEntryR\ftranslations\x12'\n" +
+	"\x0fprocessing_time\x18\t \x01(\x01R\x0eprocessingTime\x12\x16\n" +
+	"\x06forced\x18\n" +
+	" \x01(\bR\x06forced\x12\x1f\n" +
+	"\vsample_rate\x18\v \x01(\x05R\n" +
+	"sampleRate\x123\n" +
+	"\bmetadata\x18\f \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x12\n" +
+	"\x04code\x18\r \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x0e \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"moderation\x18\x0f \x01(\v2\x19.lingualink.v1.ModerationR\n" +
+	"moderation\x12-\n" +
+	"\x06speech\x18\x10 \x01(\v2\x15.lingualink.v1.SpeechR\x06speech\x1a?\n" +
+	"\x11TranslationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"^\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"TYPE_READY\x10\x01\x12\x10\n" +
+	"\fTYPE_PARTIAL\x10\x02\x12\x0e\n" +
+	"\n" +
+	"TYPE_FINAL\x10\x03\x12\x0e\n" +
+	"\n" +
+	"TYPE_ERROR\x10\x04\"\x18\n" +
+	"\x16GetCapabilitiesRequest\"V\n" +
+	"\x17GetCapabilitiesResponse\x12;\n" +
+	"\fcapabilities\x18\x01 \x01(\v2\x17.google.protobuf.StructR\fcapabilities\"\x16\n" +
+	"\x14ListLanguagesRequest\"\xdf\x01\n" +
+	"\bLanguage\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x128\n" +
+	"\x05names\x18\x03 \x03(\v2\".lingualink.v1.Language.NamesEntryR\x05names\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliases\x12\x1d\n" +
+	"\n" +
+	"style_note\x18\x05 \x01(\tR\tstyleNote\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"N\n" +
+	"\x15ListLanguagesResponse\x125\n" +
+	"\tlanguages\x18\x01 \x03(\v2\x17.lingualink.v1.LanguageR\tlanguages2\xd3\x03\n" +
+	"\n" +
+	"Lingualink\x12T\n" +
+	"\vProcessText\x12!.lingualink.v1.ProcessTextRequest\x1a\".lingualink.v1.ProcessTextResponse\x12W\n" +
+	"\fProcessAudio\x12\".lingualink.v1.ProcessAudioRequest\x1a#.lingualink.v1.ProcessAudioResponse\x12X\n" +
+	"\x0fStreamTranslate\x12%.lingualink.v1.StreamTranslateRequest\x1a\x1a.lingualink.v1.StreamEvent(\x010\x01\x12`\n" +
+	"\x0fGetCapabilities\x12%.lingualink.v1.GetCapabilitiesRequest\x1a&.lingualink.v1.GetCapabilitiesResponse\x12Z\n" +
+	"\rListLanguages\x12#.lingualink.v1.ListLanguagesRequest\x1a$.lingualink.v1.ListLanguagesResponseB`ZNgithub.com/Lingualink-VRChat/Lingualink_Core/pkg/pb/lingualink/v1;lingualinkv1\xaa\x02\rLingualink.V1b\x06proto3"
+
+var (
+	file_lingualink_v1_lingualink_proto_rawDescOnce sync.Once
+	file_lingualink_v1_lingualink_proto_rawDescData []byte
+)
+
+func file_lingualink_v1_lingualink_proto_rawDescGZIP() []byte {
+	file_lingualink_v1_lingualink_proto_rawDescOnce.Do(func() {
+		file_lingualink_v1_lingualink_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lingualink_v1_lingualink_proto_rawDesc), len(file_lingualink_v1_lingualink_proto_rawDesc)))
+	})
+	return file_lingualink_v1_lingualink_proto_rawDescData
+}
+
+var file_lingualink_v1_lingualink_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_lingualink_v1_lingualink_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_lingualink_v1_lingualink_proto_goTypes = []any{
+	(StreamControl_Action)(0),       // 0: lingualink.v1.StreamControl.Action
+	(StreamEvent_Type)(0),           // 1: lingualink.v1.StreamEvent.Type
+	(*DictionaryTerm)(nil),          // 2: lingualink.v1.DictionaryTerm
+	(*ProcessTextRequest)(nil),      // 3: lingualink.v1.ProcessTextRequest
+	(*ProcessTextResponse)(nil),     // 4: lingualink.v1.ProcessTextResponse
+	(*Moderation)(nil),              // 5: lingualink.v1.Moderation
+	(*ModerationMatch)(nil),         // 6: lingualink.v1.ModerationMatch
+	(*Speech)(nil),                  // 7: lingualink.v1.Speech
+	(*ProcessAudioRequest)(nil),     // 8: lingualink.v1.ProcessAudioRequest
+	(*ProcessAudioResponse)(nil),    // 9: lingualink.v1.ProcessAudioResponse
+	(*StreamTranslateRequest)(nil),  // 10: lingualink.v1.StreamTranslateRequest
+	(*StreamStart)(nil),             // 11: lingualink.v1.StreamStart
+	(*StreamControl)(nil),           // 12: lingualink.v1.StreamControl
+	(*StreamEvent)(nil),             // 13: lingualink.v1.StreamEvent
+	(*GetCapabilitiesRequest)(nil),  // 14: lingualink.v1.GetCapabilitiesRequest
+	(*GetCapabilitiesResponse)(nil), // 15: lingualink.v1.GetCapabilitiesResponse
+	(*ListLanguagesRequest)(nil),    // 16: lingualink.v1.ListLanguagesRequest
+	(*Language)(nil),                // 17: lingualink.v1.Language
+	(*ListLanguagesResponse)(nil),   // 18: lingualink.v1.ListLanguagesResponse
+	nil,                             // 19: lingualink.v1.DictionaryTerm.TranslationsEntry
+	nil,                             // 20: lingualink.v1.ProcessTextResponse.TranslationsEntry
+	nil,                             // 21: lingualink.v1.ProcessAudioResponse.TranslationsEntry
+	nil,                             // 22: lingualink.v1.StreamEvent.TranslationsEntry
+	nil,                             // 23: lingualink.v1.Language.NamesEntry
+	(*structpb.Struct)(nil),         // 24: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),   // 25: google.protobuf.Timestamp
+}
+var file_lingualink_v1_lingualink_proto_depIdxs = []int32{
+	19, // 0: lingualink.v1.DictionaryTerm.translations:type_name -> lingualink.v1.DictionaryTerm.TranslationsEntry
+	2,  // 1: lingualink.v1.ProcessTextRequest.user_dictionary:type_name -> lingualink.v1.DictionaryTerm
+	24, // 2: lingualink.v1.ProcessTextRequest.options:type_name -> google.protobuf.Struct
+	20, // 3: lingualink.v1.ProcessTextResponse.translations:type_name -> lingualink.v1.ProcessTextResponse.TranslationsEntry
+	24, // 4: lingualink.v1.ProcessTextResponse.metadata:type_name -> google.protobuf.Struct
+	5,  // 5: lingualink.v1.ProcessTextResponse.moderation:type_name -> lingualink.v1.Moderation
+	7,  // 6: lingualink.v1.ProcessTextResponse.speech:type_name -> lingualink.v1.Speech
+	6,  // 7: lingualink.v1.Moderation.matches:type_name -> lingualink.v1.ModerationMatch
+	25, // 8: lingualink.v1.Speech.expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 9: lingualink.v1.ProcessAudioRequest.user_dictionary:type_name -> lingualink.v1.DictionaryTerm
+	24, // 10: lingualink.v1.ProcessAudioRequest.options:type_name -> google.protobuf.Struct
+	21, // 11: lingualink.v1.ProcessAudioResponse.translations:type_name -> lingualink.v1.ProcessAudioResponse.TranslationsEntry
+	24, // 12: lingualink.v1.ProcessAudioResponse.metadata:type_name -> google.protobuf.Struct
+	5,  // 13: lingualink.v1.ProcessAudioResponse.moderation:type_name -> lingualink.v1.Moderation
+	7,  // 14: lingualink.v1.ProcessAudioResponse.speech:type_name -> lingualink.v1.Speech
+	11, // 15: lingualink.v1.StreamTranslateRequest.start:type_name -> lingualink.v1.StreamStart
+	12, // 16: lingualink.v1.StreamTranslateRequest.control:type_name -> lingualink.v1.StreamControl
+	2,  // 17: lingualink.v1.StreamStart.user_dictionary:type_name -> lingualink.v1.DictionaryTerm
+	24, // 18: lingualink.v1.StreamStart.options:type_name -> google.protobuf.Struct
+	0,  // 19: lingualink.v1.StreamControl.action:type_name -> lingualink.v1.StreamControl.Action
+	1,  // 20: lingualink.v1.StreamEvent.type:type_name -> lingualink.v1.StreamEvent.Type
+	22, // 21: lingualink.v1.StreamEvent.translations:type_name -> lingualink.v1.StreamEvent.TranslationsEntry
+	24, // 22: lingualink.v1.StreamEvent.metadata:type_name -> google.protobuf.Struct
+	5,  // 23: lingualink.v1.StreamEvent.moderation:type_name -> lingualink.v1.Moderation
+	7,  // 24: lingualink.v1.StreamEvent.speech:type_name -> lingualink.v1.Speech
+	24, // 25: lingualink.v1.GetCapabilitiesResponse.capabilities:type_name -> google.protobuf.Struct
+	23, // 26: lingualink.v1.Language.names:type_name -> lingualink.v1.Language.NamesEntry
+	17, // 27: lingualink.v1.ListLanguagesResponse.languages:type_name -> lingualink.v1.Language
+	3,  // 28: lingualink.v1.Lingualink.ProcessText:input_type -> lingualink.v1.ProcessTextRequest
+	8,  // 29: lingualink.v1.Lingualink.ProcessAudio:input_type -> lingualink.v1.ProcessAudioRequest
+	10, // 30: lingualink.v1.Lingualink.StreamTranslate:input_type -> lingualink.v1.StreamTranslateRequest
+	14, // 31: lingualink.v1.Lingualink.GetCapabilities:input_type -> lingualink.v1.GetCapabilitiesRequest
+	16, // 32: lingualink.v1.Lingualink.ListLanguages:input_type -> lingualink.v1.ListLanguagesRequest
+	4,  // 33: lingualink.v1.Lingualink.ProcessText:output_type -> lingualink.v1.ProcessTextResponse
+	9,  // 34: lingualink.v1.Lingualink.ProcessAudio:output_type -> lingualink.v1.ProcessAudioResponse
+	13, // 35: lingualink.v1.Lingualink.StreamTranslate:output_type -> lingualink.v1.StreamEvent
+	15, // 36: lingualink.v1.Lingualink.GetCapabilities:output_type -> lingualink.v1.GetCapabilitiesResponse
+	18, // 37: lingualink.v1.Lingualink.ListLanguages:output_type -> lingualink.v1.ListLanguagesResponse
+	33, // [33:38] is the sub-list for method output_type
+	28, // [28:33] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_lingualink_v1_lingualink_proto_init() }
+func file_lingualink_v1_lingualink_proto_init() {
+	if File_lingualink_v1_lingualink_proto != nil {
+		return
+	}
+	file_lingualink_v1_lingualink_proto_msgTypes[8].OneofWrappers = []any{
+		(*StreamTranslateRequest_Start)(nil),
+		(*StreamTranslateRequest_Audio)(nil),
+		(*StreamTranslateRequest_Control)(nil),
+	}
+	file_lingualink_v1_lingualink_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lingualink_v1_lingualink_proto_rawDesc), len(file_lingualink_v1_lingualink_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lingualink_v1_lingualink_proto_goTypes,
+		DependencyIndexes: file_lingualink_v1_lingualink_proto_depIdxs,
+		EnumInfos:         file_lingualink_v1_lingualink_proto_enumTypes,
+		MessageInfos:      file_lingualink_v1_lingualink_proto_msgTypes,
+	}.Build()
+	File_lingualink_v1_lingualink_proto = out.File
+	file_lingualink_v1_lingualink_proto_goTypes = nil
+	file_lingualink_v1_lingualink_proto_depIdxs = nil
+}
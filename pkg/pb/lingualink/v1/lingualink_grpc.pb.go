@@ -0,0 +1,287 @@
+// 生成 Go 代码（输出到 pkg/pb/lingualink/v1）：
+//   protoc -I proto --go_out=pkg/pb --go_opt=paths=source_relative \
+//     --go-grpc_out=pkg/pb --go-grpc_opt=paths=source_relative lingualink/v1/lingualink.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: lingualink/v1/lingualink.proto
+
+// Lingualink Core gRPC API. 与 REST API 共用同一套处理流程与认证：
+// 通过 metadata 传递 x-api-key 或 authorization（"Bearer <jwt>" / "ApiKey <key>"），可选 x-request-id。
+
+package lingualinkv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Lingualink_ProcessText_FullMethodName     = "/lingualink.v1.Lingualink/ProcessText"
+	Lingualink_ProcessAudio_FullMethodName    = "/lingualink.v1.Lingualink/ProcessAudio"
+	Lingualink_StreamTranslate_FullMethodName = "/lingualink.v1.Lingualink/StreamTranslate"
+	Lingualink_GetCapabilities_FullMethodName = "/lingualink.v1.Lingualink/GetCapabilities"
+	Lingualink_ListLanguages_FullMethodName   = "/lingualink.v1.Lingualink/ListLanguages"
+)
+
+// LingualinkClient is the client API for Lingualink service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LingualinkClient interface {
+	// ProcessText translates (or corrects) text, like POST /api/v1/process_text.
+	ProcessText(ctx context.Context, in *ProcessTextRequest, opts ...grpc.CallOption) (*ProcessTextResponse, error)
+	// ProcessAudio transcribes and translates one audio clip, like POST /api/v1/process_audio.
+	ProcessAudio(ctx context.Context, in *ProcessAudioRequest, opts ...grpc.CallOption) (*ProcessAudioResponse, error)
+	// StreamTranslate is the gRPC counterpart of the /ws/stream WebSocket: the first message
+	// must be `start`, followed by `audio` chunks and optional `control` messages.
+	StreamTranslate(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamTranslateRequest, StreamEvent], error)
+	// GetCapabilities does not require authentication.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
+	// ListLanguages does not require authentication.
+	ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error)
+}
+
+type lingualinkClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLingualinkClient(cc grpc.ClientConnInterface) LingualinkClient {
+	return &lingualinkClient{cc}
+}
+
+func (c *lingualinkClient) ProcessText(ctx context.Context, in *ProcessTextRequest, opts ...grpc.CallOption) (*ProcessTextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessTextResponse)
+	err := c.cc.Invoke(ctx, Lingualink_ProcessText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lingualinkClient) ProcessAudio(ctx context.Context, in *ProcessAudioRequest, opts ...grpc.CallOption) (*ProcessAudioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessAudioResponse)
+	err := c.cc.Invoke(ctx, Lingualink_ProcessAudio_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lingualinkClient) StreamTranslate(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamTranslateRequest, StreamEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Lingualink_ServiceDesc.Streams[0], Lingualink_StreamTranslate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTranslateRequest, StreamEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Lingualink_StreamTranslateClient = grpc.BidiStreamingClient[StreamTranslateRequest, StreamEvent]
+
+func (c *lingualinkClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, Lingualink_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lingualinkClient) ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLanguagesResponse)
+	err := c.cc.Invoke(ctx, Lingualink_ListLanguages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LingualinkServer is the server API for Lingualink service.
+// All implementations must embed UnimplementedLingualinkServer
+// for forward compatibility.
+type LingualinkServer interface {
+	// ProcessText translates (or corrects) text, like POST /api/v1/process_text.
+	ProcessText(context.Context, *ProcessTextRequest) (*ProcessTextResponse, error)
+	// ProcessAudio transcribes and translates one audio clip, like POST /api/v1/process_audio.
+	ProcessAudio(context.Context, *ProcessAudioRequest) (*ProcessAudioResponse, error)
+	// StreamTranslate is the gRPC counterpart of the /ws/stream WebSocket: the first message
+	// must be `start`, followed by `audio` chunks and optional `control` messages.
+	StreamTranslate(grpc.BidiStreamingServer[StreamTranslateRequest, StreamEvent]) error
+	// GetCapabilities does not require authentication.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
+	// ListLanguages does not require authentication.
+	ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error)
+	mustEmbedUnimplementedLingualinkServer()
+}
+
+// UnimplementedLingualinkServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLingualinkServer struct{}
+
+func (UnimplementedLingualinkServer) ProcessText(context.Context, *ProcessTextRequest) (*ProcessTextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessText not implemented")
+}
+func (UnimplementedLingualinkServer) ProcessAudio(context.Context, *ProcessAudioRequest) (*ProcessAudioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessAudio not implemented")
+}
+func (UnimplementedLingualinkServer) StreamTranslate(grpc.BidiStreamingServer[StreamTranslateRequest, StreamEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTranslate not implemented")
+}
+func (UnimplementedLingualinkServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedLingualinkServer) ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLanguages not implemented")
+}
+func (UnimplementedLingualinkServer) mustEmbedUnimplementedLingualinkServer() {}
+func (UnimplementedLingualinkServer) testEmbeddedByValue()                    {}
+
+// UnsafeLingualinkServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LingualinkServer will
+// result in compilation errors.
+type UnsafeLingualinkServer interface {
+	mustEmbedUnimplementedLingualinkServer()
+}
+
+func RegisterLingualinkServer(s grpc.ServiceRegistrar, srv LingualinkServer) {
+	// If the following call pancis, it indicates UnimplementedLingualinkServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Lingualink_ServiceDesc, srv)
+}
+
+func _Lingualink_ProcessText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessTextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LingualinkServer).ProcessText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lingualink_ProcessText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LingualinkServer).ProcessText(ctx, req.(*ProcessTextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lingualink_ProcessAudio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessAudioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LingualinkServer).ProcessAudio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lingualink_ProcessAudio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LingualinkServer).ProcessAudio(ctx, req.(*ProcessAudioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lingualink_StreamTranslate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LingualinkServer).StreamTranslate(&grpc.GenericServerStream[StreamTranslateRequest, StreamEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Lingualink_StreamTranslateServer = grpc.BidiStreamingServer[StreamTranslateRequest, StreamEvent]
+
+func _Lingualink_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LingualinkServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lingualink_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LingualinkServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lingualink_ListLanguages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLanguagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LingualinkServer).ListLanguages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lingualink_ListLanguages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LingualinkServer).ListLanguages(ctx, req.(*ListLanguagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Lingualink_ServiceDesc is the grpc.ServiceDesc for Lingualink service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Lingualink_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lingualink.v1.Lingualink",
+	HandlerType: (*LingualinkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessText",
+			Handler:    _Lingualink_ProcessText_Handler,
+		},
+		{
+			MethodName: "ProcessAudio",
+			Handler:    _Lingualink_ProcessAudio_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _Lingualink_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "ListLanguages",
+			Handler:    _Lingualink_ListLanguages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTranslate",
+			Handler:       _Lingualink_StreamTranslate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "lingualink/v1/lingualink.proto",
+}
@@ -0,0 +1,2 @@
+// Package tracing provides OpenTelemetry setup and span helpers.
+package tracing